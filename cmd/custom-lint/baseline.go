@@ -0,0 +1,300 @@
+package main
+
+// This file implements the -baseline mode, which permits incremental
+// adoption of the analyzers in a large existing code base:
+//
+//	$ custom-lint -baseline=lint-baseline.json ./...
+//
+// The first run records all current diagnostics in the baseline file
+// and reports nothing. Subsequent runs report only diagnostics that do
+// not match an entry of the baseline. A diagnostic matches an entry
+// if it has the same file, category, and message, and either its line
+// is within maxLineDrift lines of the recorded one or the text of its
+// line is unchanged, so that unrelated edits above a finding do not
+// resurface it. Use -update-baseline to record the current diagnostics
+// again, for example after fixing some of them. The recorded
+// diagnostics are written once all packages have been analyzed, to a
+// temporary file that then replaces the baseline file, so that an
+// interrupted run leaves the previous baseline intact.
+//
+// The baseline is applied as the analyzers report their diagnostics,
+// so all the usual flags of the command, such as -json and the flags
+// of each analyzer, work in baseline mode too.
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/token"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var (
+	baselineFlag       = flag.String("baseline", "", "report only diagnostics not recorded in the named `file`, creating it if it does not exist")
+	updateBaselineFlag = flag.Bool("update-baseline", false, "record all current diagnostics in the -baseline file")
+)
+
+// maxLineDrift is the maximum distance in lines between a diagnostic
+// and the baseline entry that suppresses it.
+const maxLineDrift = 20
+
+// A baseline is the content of a baseline file.
+type baseline struct {
+	Findings []finding `json:"findings"`
+}
+
+// A finding identifies a diagnostic in a baseline file.
+type finding struct {
+	File     string `json:"file"` // slash-separated, relative to the baseline file
+	Line     int    `json:"line"`
+	Category string `json:"category"`       // analyzer name, and diagnostic category if any
+	Hash     string `json:"hash"`           // hash of the message
+	Code     string `json:"code,omitempty"` // hash of the text of the line, without indentation
+}
+
+// A baselineState applies a baseline file to the diagnostics reported
+// by the analyzers, or, in update mode, records them in it.
+// It is safe for concurrent use.
+type baselineState struct {
+	filename string
+	dir      string // absolute directory of filename
+	update   bool
+
+	mu       sync.Mutex
+	findings []finding       // filter mode: entries of the file; update mode: recorded diagnostics
+	used     []bool          // filter mode: findings[i] has suppressed a diagnostic
+	seen     map[string]bool // keys of diagnostics already suppressed or recorded
+}
+
+var (
+	theBaseline     *baselineState
+	theBaselineOnce sync.Once
+)
+
+// currentBaseline returns the baseline denoted by the command-line
+// flags, or nil if there is none. It must not be called before the
+// flags are parsed.
+func currentBaseline() *baselineState {
+	theBaselineOnce.Do(func() {
+		if *baselineFlag == "" {
+			if *updateBaselineFlag {
+				log.Fatal("-update-baseline requires -baseline")
+			}
+			return
+		}
+		b, err := openBaseline(*baselineFlag, *updateBaselineFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		theBaseline = b
+	})
+	return theBaseline
+}
+
+// openBaseline returns the state of the named baseline file. If the
+// file does not exist, or update is set, the diagnostics will be
+// recorded in it.
+func openBaseline(filename string, update bool) (*baselineState, error) {
+	dir, err := filepath.Abs(filepath.Dir(filename))
+	if err != nil {
+		return nil, err
+	}
+	b := &baselineState{
+		filename: filename,
+		dir:      dir,
+		update:   update,
+		seen:     make(map[string]bool),
+	}
+	if !update {
+		old, err := readBaseline(filename)
+		if errors.Is(err, fs.ErrNotExist) {
+			b.update = true
+		} else if err != nil {
+			return nil, err
+		} else {
+			b.findings = old.Findings
+			b.used = make([]bool, len(old.Findings))
+		}
+	}
+	return b, nil
+}
+
+// suppress reports whether the diagnostic d of the named analyzer, at
+// posn on the given line of text, is matched by the baseline. In update
+// mode, it records the diagnostic and always returns true.
+//
+// Each entry suppresses at most one diagnostic: preferably one on an
+// unchanged line, and otherwise the nearest. Test variants of a package
+// may report the same diagnostic twice; both reports are suppressed, or
+// neither.
+func (b *baselineState) suppress(posn token.Position, line string, analyzer string, d analysis.Diagnostic) bool {
+	file, err := filepath.Rel(b.dir, posn.Filename)
+	if err != nil {
+		file = posn.Filename
+	}
+	category := analyzer
+	if d.Category != "" {
+		category += "/" + d.Category
+	}
+	f := finding{
+		File:     filepath.ToSlash(file),
+		Line:     posn.Line,
+		Category: category,
+		Hash:     messageHash(d.Message),
+	}
+	if line = strings.TrimSpace(line); line != "" {
+		f.Code = messageHash(line)
+	}
+	key := fmt.Sprintf("%s: %s: %s", posn, category, d.Message)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.seen[key] {
+		return true
+	}
+	if b.update {
+		b.seen[key] = true
+		b.findings = append(b.findings, f)
+		return true
+	}
+	sameCode := func(old finding) bool { return old.Code != "" && old.Code == f.Code }
+	best := -1
+	for i, old := range b.findings {
+		if b.used[i] || old.File != f.File || old.Category != f.Category || old.Hash != f.Hash {
+			continue
+		}
+		drift := abs(old.Line - f.Line)
+		if drift > maxLineDrift && !sameCode(old) {
+			continue
+		}
+		if best < 0 {
+			best = i
+			continue
+		}
+		prev := b.findings[best]
+		if sameCode(old) != sameCode(prev) {
+			if sameCode(old) {
+				best = i
+			}
+		} else if drift < abs(prev.Line-f.Line) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return false
+	}
+	b.used[best] = true
+	b.seen[key] = true
+	return true
+}
+
+// write writes the diagnostics recorded in update mode to the baseline
+// file. It is called once, at the end of the analysis.
+func (b *baselineState) write() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.update {
+		return nil
+	}
+	findings := slices.Clone(b.findings)
+	slices.SortFunc(findings, func(x, y finding) int {
+		return cmp.Or(
+			cmp.Compare(x.File, y.File),
+			cmp.Compare(x.Line, y.Line),
+			cmp.Compare(x.Category, y.Category),
+			cmp.Compare(x.Hash, y.Hash),
+			cmp.Compare(x.Code, y.Code))
+	})
+	return writeBaseline(b.filename, &baseline{Findings: findings})
+}
+
+// A lineReader returns the text of the lines of the files of a pass.
+// It is safe for concurrent use.
+type lineReader struct {
+	readFile func(filename string) ([]byte, error)
+
+	mu    sync.Mutex
+	files map[string][]byte // content of files already read, or nil
+}
+
+func newLineReader(pass *analysis.Pass) *lineReader {
+	readFile := pass.ReadFile
+	if readFile == nil {
+		readFile = os.ReadFile
+	}
+	return &lineReader{readFile: readFile, files: make(map[string][]byte)}
+}
+
+// line returns the text of the line of posn, or "" if the file cannot
+// be read.
+func (r *lineReader) line(posn token.Position) string {
+	r.mu.Lock()
+	content, ok := r.files[posn.Filename]
+	if !ok {
+		content, _ = r.readFile(posn.Filename)
+		r.files[posn.Filename] = content
+	}
+	r.mu.Unlock()
+
+	start := posn.Offset - (posn.Column - 1)
+	if posn.Column < 1 || start < 0 || posn.Offset > len(content) {
+		return ""
+	}
+	line := content[start:]
+	if i := bytes.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	return string(line)
+}
+
+// messageHash returns a short hash of a diagnostic message, or of a
+// line of source text.
+func messageHash(message string) string {
+	sum := sha256.Sum256([]byte(message))
+	return hex.EncodeToString(sum[:8])
+}
+
+// readBaseline reads the named baseline file.
+func readBaseline(filename string) (*baseline, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var b baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("reading baseline %s: %v", filename, err)
+	}
+	return &b, nil
+}
+
+// writeBaseline replaces the named file by b.
+func writeBaseline(filename string, b *baseline) error {
+	if b.Findings == nil {
+		b.Findings = []finding{} // not null
+	}
+	data, err := json.MarshalIndent(b, "", "\t")
+	if err != nil {
+		return err
+	}
+	return replaceFile(filename, append(data, '\n'))
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
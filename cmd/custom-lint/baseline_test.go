@@ -0,0 +1,169 @@
+package main
+
+import (
+	"errors"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestBaselineWrite(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "baseline.json")
+
+	b, err := openBaseline(filename, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.update {
+		t.Fatal("missing baseline file did not select update mode")
+	}
+
+	b.suppress(posn(dir, "b.go", 7), "\ts := fmt.Sprint(x)", "nosprintf", diag("second"))
+	b.suppress(posn(dir, "a.go", 3), "", "nosprintf", diag("first"))
+	b.suppress(posn(dir, "a.go", 3), "", "nosprintf", diag("first")) // test variant
+	// Nothing is written before the end of the analysis.
+	if _, err := os.Stat(filename); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("baseline file written before the end of the analysis: %v", err)
+	}
+	if err := b.write(); err != nil {
+		t.Fatal(err)
+	}
+	// The temporary file has replaced the baseline file.
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 1 {
+		t.Errorf("directory holds %v, %v; want only the baseline file", entries, err)
+	}
+
+	got, err := readBaseline(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []finding{
+		{File: "a.go", Line: 3, Category: "nosprintf", Hash: messageHash("first")},
+		{File: "b.go", Line: 7, Category: "nosprintf", Hash: messageHash("second"), Code: messageHash("s := fmt.Sprint(x)")},
+	}
+	if !reflect.DeepEqual(got.Findings, want) {
+		t.Errorf("baseline = %+v, want %+v", got.Findings, want)
+	}
+}
+
+func TestBaselineSuppress(t *testing.T) {
+	dir := t.TempDir()
+	b := testBaseline(t, dir,
+		finding{File: "a.go", Line: 10, Category: "nosprintf", Hash: messageHash("m")},
+		finding{File: "a.go", Line: 30, Category: "nosprintf", Hash: messageHash("m")},
+	)
+
+	for _, test := range []struct {
+		line int
+		want bool
+	}{
+		{28, true},  // nearest entry is line 30
+		{28, true},  // same diagnostic from a test variant
+		{12, true},  // moved down by 2 lines; matches line 10
+		{13, false}, // both entries used
+	} {
+		if got := b.suppress(posn(dir, "a.go", test.line), "", "nosprintf", diag("m")); got != test.want {
+			t.Errorf("suppress(line %d) = %t, want %t", test.line, got, test.want)
+		}
+	}
+}
+
+func TestBaselineStale(t *testing.T) {
+	dir := t.TempDir()
+	b := testBaseline(t, dir,
+		finding{File: "a.go", Line: 10, Category: "nosprintf", Hash: messageHash("m")},
+	)
+
+	for _, test := range []struct {
+		name     string
+		file     string
+		line     int
+		analyzer string
+		message  string
+	}{
+		{"message changed", "a.go", 10, "nosprintf", "other"},
+		{"other file", "b.go", 10, "nosprintf", "m"},
+		{"other analyzer", "a.go", 10, "other", "m"},
+		{"too far", "a.go", 10 + maxLineDrift + 1, "nosprintf", "m"},
+	} {
+		if b.suppress(posn(dir, test.file, test.line), "", test.analyzer, diag(test.message)) {
+			t.Errorf("%s: diagnostic was suppressed by a stale entry", test.name)
+		}
+	}
+}
+
+func TestBaselineCode(t *testing.T) {
+	dir := t.TempDir()
+	code := messageHash("x := fmt.Sprintf(\"%d\", n)")
+	b := testBaseline(t, dir,
+		finding{File: "a.go", Line: 10, Category: "nosprintf", Hash: messageHash("m")},
+		finding{File: "a.go", Line: 50, Category: "nosprintf", Hash: messageHash("m"), Code: code},
+		finding{File: "a.go", Line: 60, Category: "nosprintf", Hash: messageHash("m"), Code: code},
+	)
+
+	for _, test := range []struct {
+		line int
+		text string
+		want bool
+	}{
+		{12, "\tx := fmt.Sprintf(\"%d\", n)", true}, // unchanged line preferred to the nearer line 10
+		{200, "x := fmt.Sprintf(\"%d\", n)", true},  // moved far, but unchanged; matches line 60
+		{300, "x := fmt.Sprintf(\"%d\", n)", false}, // both unchanged entries used
+		{11, "y := fmt.Sprintf(\"%d\", n)", true},   // changed line near line 10
+	} {
+		if got := b.suppress(posn(dir, "a.go", test.line), test.text, "nosprintf", diag("m")); got != test.want {
+			t.Errorf("suppress(line %d, %q) = %t, want %t", test.line, test.text, got, test.want)
+		}
+	}
+}
+
+func TestLineReader(t *testing.T) {
+	r := &lineReader{
+		readFile: func(string) ([]byte, error) { return []byte("package a\n\n\tx := 1\n"), nil },
+		files:    make(map[string][]byte),
+	}
+	for _, test := range []struct {
+		posn token.Position
+		want string
+	}{
+		{token.Position{Filename: "a.go", Offset: 0, Line: 1, Column: 1}, "package a"},
+		{token.Position{Filename: "a.go", Offset: 13, Line: 3, Column: 3}, "\tx := 1"},
+		{token.Position{Filename: "a.go", Offset: 100, Line: 9, Column: 1}, ""},
+	} {
+		if got := r.line(test.posn); got != test.want {
+			t.Errorf("line(%v) = %q, want %q", test.posn, got, test.want)
+		}
+	}
+}
+
+// testBaseline returns the state of a baseline file in dir that holds
+// the given findings.
+func testBaseline(t *testing.T, dir string, findings ...finding) *baselineState {
+	t.Helper()
+	filename := filepath.Join(dir, "baseline.json")
+	if err := writeBaseline(filename, &baseline{Findings: findings}); err != nil {
+		t.Fatal(err)
+	}
+	b, err := openBaseline(filename, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.update {
+		t.Fatal("existing baseline file selected update mode")
+	}
+	return b
+}
+
+func posn(dir, file string, line int) token.Position {
+	return token.Position{Filename: filepath.Join(dir, file), Line: line, Column: 1}
+}
+
+func diag(message string) analysis.Diagnostic {
+	return analysis.Diagnostic{Message: message}
+}
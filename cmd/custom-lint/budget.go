@@ -0,0 +1,129 @@
+package main
+
+// This file implements the flags that keep a run within a time budget
+// on large code bases:
+//
+//	$ custom-lint -p=4 -package-timeout=2m ./...
+//
+// The -p flag limits the number of analyzer passes, each the analysis
+// of a package by an analyzer, that run in parallel. It does not limit
+// the loading of the packages, which 'go list' and the type checker do
+// before any analysis starts.
+//
+// The -package-timeout flag abandons the analysis of a package by an
+// analyzer that runs for longer than the given duration. The analysis
+// then fails with an error that names the skipped package; the checker
+// prints such errors with the diagnostics, once all packages have been
+// analyzed, and exits with a failure status. An abandoned analyzer
+// cannot be stopped, but its later diagnostics and facts are dropped.
+
+import (
+	"flag"
+	"fmt"
+	"go/types"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var (
+	parallelFlag       parallelism
+	packageTimeoutFlag = flag.Duration("package-timeout", 0, "abandon the analysis of a package by an analyzer after this `duration`, and report the package as skipped")
+)
+
+func init() {
+	flag.Var(&parallelFlag, "p", "run at most `n` analyzer passes in parallel (default GOMAXPROCS)")
+}
+
+// parallelism is the value of the -p flag.
+type parallelism int
+
+func (p *parallelism) String() string { return strconv.Itoa(int(*p)) }
+
+func (p *parallelism) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return fmt.Errorf("invalid parallelism %q", s)
+	}
+	*p = parallelism(n)
+	return nil
+}
+
+var (
+	slots     chan struct{}
+	slotsOnce sync.Once
+)
+
+// acquireSlot blocks until fewer than -p analyzer passes are running,
+// and returns a function that releases the slot. It must not be called
+// before the flags are parsed.
+func acquireSlot() (release func()) {
+	slotsOnce.Do(func() {
+		n := int(parallelFlag)
+		if n == 0 {
+			n = runtime.GOMAXPROCS(0)
+		}
+		slots = make(chan struct{}, n)
+	})
+	slots <- struct{}{}
+	return func() { <-slots }
+}
+
+// runWithTimeout calls run(pass). If it does not return within
+// timeout, runWithTimeout abandons it and returns an error that reports
+// the package as skipped. A zero timeout means no limit.
+func runWithTimeout(pass *analysis.Pass, run func(*analysis.Pass) (any, error), timeout time.Duration) (any, error) {
+	if timeout <= 0 {
+		return run(pass)
+	}
+
+	// Run the analyzer on a copy of the pass whose callbacks
+	// are disabled once the analysis has been abandoned,
+	// as the checker forbids their use after Run returns.
+	var (
+		mu        sync.Mutex
+		abandoned bool
+	)
+	guard := func(f func()) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !abandoned {
+			f()
+		}
+	}
+	pass2 := *pass
+	pass2.Report = func(d analysis.Diagnostic) {
+		guard(func() { pass.Report(d) })
+	}
+	pass2.ExportObjectFact = func(obj types.Object, fact analysis.Fact) {
+		guard(func() { pass.ExportObjectFact(obj, fact) })
+	}
+	pass2.ExportPackageFact = func(fact analysis.Fact) {
+		guard(func() { pass.ExportPackageFact(fact) })
+	}
+
+	type result struct {
+		res any
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		res, err := run(&pass2)
+		done <- result{res, err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		return r.res, r.err
+	case <-timer.C:
+		mu.Lock()
+		abandoned = true
+		mu.Unlock()
+		return nil, fmt.Errorf("skipped package %s: analysis took longer than -package-timeout=%v", pass.Pkg.Path(), timeout)
+	}
+}
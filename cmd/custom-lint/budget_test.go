@@ -0,0 +1,61 @@
+package main
+
+import (
+	"go/types"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestRunWithTimeout(t *testing.T) {
+	var reported []string
+	pass := &analysis.Pass{
+		Pkg: types.NewPackage("example.com/slow", "slow"),
+		Report: func(d analysis.Diagnostic) {
+			reported = append(reported, d.Message)
+		},
+	}
+
+	// An analyzer that returns in time is unaffected.
+	res, err := runWithTimeout(pass, func(pass *analysis.Pass) (any, error) {
+		pass.Report(analysis.Diagnostic{Message: "fast"})
+		return 1, nil
+	}, time.Minute)
+	if res != 1 || err != nil {
+		t.Fatalf("runWithTimeout = %v, %v; want 1, nil", res, err)
+	}
+
+	// An analyzer that runs too long is abandoned, and its later
+	// diagnostics are dropped.
+	unblock, finished := make(chan struct{}), make(chan struct{})
+	_, err = runWithTimeout(pass, func(pass *analysis.Pass) (any, error) {
+		defer close(finished)
+		<-unblock
+		pass.Report(analysis.Diagnostic{Message: "slow"})
+		return nil, nil
+	}, time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "skipped package example.com/slow") {
+		t.Errorf("runWithTimeout returned error %v, want skipped package", err)
+	}
+	close(unblock)
+	<-finished
+
+	if got := strings.Join(reported, " "); got != "fast" {
+		t.Errorf("reported diagnostics %q, want %q", got, "fast")
+	}
+}
+
+func TestParallelismFlag(t *testing.T) {
+	var p parallelism
+	if err := p.Set("0"); err == nil {
+		t.Error("Set(0) succeeded")
+	}
+	if err := p.Set("4"); err != nil {
+		t.Fatal(err)
+	}
+	if p != 4 {
+		t.Errorf("parallelism = %d, want 4", p)
+	}
+}
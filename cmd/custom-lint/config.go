@@ -0,0 +1,171 @@
+package main
+
+// This file implements the configuration file, which selects the
+// analyzers to run on a module and sets their flags. The file is
+// named .customlint.json, at the root of the module of the current
+// directory:
+//
+//	{
+//		"exclude": ["internal/gen/...", "*_string.go"],
+//		"analyzers": {
+//			"nosprintf": {
+//				"flags": {"max-args": "3"},
+//				"exclude": ["cmd/..."]
+//			},
+//			"notimenow": {"enabled": false}
+//		}
+//	}
+//
+// Analyzers are enabled unless their "enabled" field is false. Their
+// flags are set before the command line is parsed, so that a flag on
+// the command line, such as -nosprintf.max-args, overrides the file.
+//
+// Diagnostics in the files matched by the top-level "exclude" list, or
+// by that of their analyzer, are not reported. A pattern is a
+// slash-separated path relative to the module root: a pattern ending
+// in "/..." matches the files of a directory and its subdirectories,
+// and any other pattern is matched against the path of each file with
+// path.Match, or against its base name if the pattern has no slash.
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/custom/analyzer/registry"
+)
+
+// configName is the name of the configuration file.
+const configName = ".customlint.json"
+
+// A config is the content of a configuration file.
+type config struct {
+	Exclude   []string                  `json:"exclude"`
+	Analyzers map[string]analyzerConfig `json:"analyzers"` // by analyzer name
+
+	dir string // absolute directory of the file, the module root
+}
+
+// An analyzerConfig holds the configuration of one analyzer.
+type analyzerConfig struct {
+	Enabled *bool             `json:"enabled"` // nil means true
+	Flags   map[string]string `json:"flags"`   // flag values, by name without analyzer prefix
+	Exclude []string          `json:"exclude"`
+}
+
+// loadConfig returns the configuration of the module enclosing dir.
+// If the module has no configuration file, or dir is not in a module,
+// it returns an empty configuration, which enables all analyzers.
+func loadConfig(dir string) (*config, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return &config{}, nil // not in a module
+		}
+		dir = parent
+	}
+	cfg, err := readConfig(filepath.Join(dir, configName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return &config{dir: dir}, nil
+	}
+	return cfg, err
+}
+
+// readConfig reads the named configuration file.
+func readConfig(filename string) (*config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := filepath.Abs(filepath.Dir(filename))
+	if err != nil {
+		return nil, err
+	}
+	cfg := &config{dir: dir}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields() // catch misspelled fields
+	if err := dec.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("reading config %s: %v", filename, err)
+	}
+	for name := range cfg.Analyzers {
+		if registry.Lookup(name) == nil {
+			return nil, fmt.Errorf("reading config %s: unknown analyzer %q", filename, name)
+		}
+	}
+	return cfg, nil
+}
+
+// apply returns the entries of the analyzers enabled by the
+// configuration, in order of name, after setting their flags.
+func (c *config) apply(entries []*registry.Entry) ([]*registry.Entry, error) {
+	var enabled []*registry.Entry
+	for _, e := range entries {
+		ac := c.Analyzers[e.Analyzer.Name]
+		if ac.Enabled != nil && !*ac.Enabled {
+			continue
+		}
+		for name, value := range ac.Flags {
+			if e.Analyzer.Flags.Lookup(name) == nil {
+				return nil, fmt.Errorf("config %s: analyzer %s has no flag %q",
+					filepath.Join(c.dir, configName), e.Analyzer.Name, name)
+			}
+			if err := e.Analyzer.Flags.Set(name, value); err != nil {
+				return nil, fmt.Errorf("config %s: flag %s.%s: %v",
+					filepath.Join(c.dir, configName), e.Analyzer.Name, name, err)
+			}
+		}
+		enabled = append(enabled, e)
+	}
+	return enabled, nil
+}
+
+// excluded reports whether the diagnostics of the named analyzer in
+// the named file are excluded by the configuration.
+func (c *config) excluded(analyzer, filename string) bool {
+	if c.dir == "" {
+		return false
+	}
+	rel, err := filepath.Rel(c.dir, filename)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return false // outside the module
+	}
+	return matchAny(c.Exclude, rel) || matchAny(c.Analyzers[analyzer].Exclude, rel)
+}
+
+// matchAny reports whether the slash-separated relative path of a file
+// matches any of the patterns.
+func matchAny(patterns []string, file string) bool {
+	for _, pattern := range patterns {
+		if dir, ok := strings.CutSuffix(pattern, "/..."); ok {
+			if dir == "." || strings.HasPrefix(file, dir+"/") {
+				return true
+			}
+			continue
+		}
+		name := file
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(file)
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/custom/analyzer/registry"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com\n")
+	writeFile(t, filepath.Join(dir, configName), `{
+	"exclude": ["gen/...", "*_string.go"],
+	"analyzers": {
+		"nosprintf": {"flags": {"max-args": "3"}, "exclude": ["cmd/..."]},
+		"notimenow": {"enabled": false}
+	}
+}`)
+	sub := filepath.Join(dir, "a", "b")
+	if err := os.MkdirAll(sub, 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	// The configuration is found at the module root.
+	cfg, err := loadConfig(sub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flag := registry.Lookup("nosprintf").Analyzer.Flags.Lookup("max-args")
+	defer flag.Value.Set(flag.DefValue)
+	entries, err := cfg.apply(registry.Entries())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Analyzer.Name)
+	}
+	if got, want := strings.Join(names, " "), "nogoroutineleak nosprintf notimeafter"; got != want {
+		t.Errorf("enabled analyzers = %q, want %q", got, want)
+	}
+	if got := flag.Value.String(); got != "3" {
+		t.Errorf("-nosprintf.max-args = %s, want 3", got)
+	}
+
+	for _, test := range []struct {
+		analyzer, file string
+		want           bool
+	}{
+		{"nosprintf", "a/a.go", false},
+		{"nosprintf", "gen/a.go", true},
+		{"nosprintf", "gen/sub/a.go", true},
+		{"nosprintf", "generated.go", false},
+		{"nosprintf", "a/kind_string.go", true},
+		{"nosprintf", "cmd/main.go", true},
+		{"notimenow", "cmd/main.go", false},
+		{"nosprintf", "../other/gen/a.go", false},
+	} {
+		if got := cfg.excluded(test.analyzer, filepath.Join(dir, test.file)); got != test.want {
+			t.Errorf("excluded(%s, %s) = %t, want %t", test.analyzer, test.file, got, test.want)
+		}
+	}
+}
+
+func TestLoadConfigMissing(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com\n")
+
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := cfg.apply(registry.Entries())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(registry.Entries()) {
+		t.Errorf("apply enabled %d analyzers, want all %d", len(entries), len(registry.Entries()))
+	}
+	if cfg.excluded("nosprintf", filepath.Join(dir, "a.go")) {
+		t.Errorf("empty configuration excludes a file")
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	for _, test := range []struct {
+		content, want string
+	}{
+		{`{"analyzers": {"nonesuch": {}}}`, `unknown analyzer "nonesuch"`},
+		{`{"analyzer": {}}`, `unknown field "analyzer"`},
+		{`{"analyzers": {"nosprintf": {"flags": {"nonesuch": "1"}}}}`, `analyzer nosprintf has no flag "nonesuch"`},
+		{`{"analyzers": {"nosprintf": {"flags": {"max-args": "many"}}}}`, `flag nosprintf.max-args`},
+	} {
+		dir := t.TempDir()
+		filename := filepath.Join(dir, configName)
+		writeFile(t, filename, test.content)
+		cfg, err := readConfig(filename)
+		if err == nil {
+			_, err = cfg.apply(registry.Entries())
+		}
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("config %s: got error %v, want %q", test.content, err, test.want)
+		}
+	}
+}
+
+func writeFile(t *testing.T, filename, content string) {
+	t.Helper()
+	if err := os.WriteFile(filename, []byte(content), 0666); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,12 +1,119 @@
+// The custom-lint command runs the custom analyzers.
+//
+// Diagnostics are printed as the analyzers report them. Their severity,
+// which the registry records for each analyzer and category, is not
+// part of the message: the -sarif mode reports it as the level of each
+// result.
+//
+// With the -baseline flag, it reports only diagnostics that are not
+// recorded in a baseline file; see baseline.go.
+//
+// With the -sarif flag, it writes the diagnostics to a SARIF file
+// instead of printing them; see sarif.go.
+//
+// The -p and -package-timeout flags keep a run within a time budget;
+// see budget.go.
+//
+// The .customlint.json file at the root of the module selects the
+// analyzers, sets their flags, and excludes files; see config.go.
 package main
 
 import (
-	"golang.org/x/tools/custom/analyzer/nosprintf"
+	"log"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/tools/custom/analyzer/registry"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/multichecker"
 )
 
 func main() {
-	multichecker.Main(
-		nosprintf.Analyzer,
-	)
+	cfg, err := loadConfig(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+	entries, err := cfg.apply(registry.Entries())
+	if err != nil {
+		log.Fatal(err)
+	}
+	enabledEntries = entries
+	var analyzers []*analysis.Analyzer
+	for _, e := range entries {
+		analyzers = append(analyzers, wrap(e, cfg))
+	}
+	exitcode := multichecker.Run(analyzers...)
+	// The baseline is set by the first analyzer pass, if any.
+	if b := theBaseline; b != nil {
+		if err := b.write(); err != nil {
+			log.Print(err)
+			exitcode = max(exitcode, 1)
+		}
+	}
+	if s := currentSarif(); s != nil {
+		if err := s.write(); err != nil {
+			log.Print(err)
+			exitcode = max(exitcode, 1)
+		}
+	}
+	os.Exit(exitcode)
+}
+
+// wrap returns a copy of the analyzer of e whose diagnostics are
+// filtered by the exclusions of cfg and by the baseline, if any, and
+// recorded in the SARIF file, if any, instead of being printed, and
+// whose passes are subject to the -p and -package-timeout limits.
+func wrap(e *registry.Entry, cfg *config) *analysis.Analyzer {
+	a := *e.Analyzer
+	a.Run = func(pass *analysis.Pass) (any, error) {
+		defer acquireSlot()()
+
+		b := currentBaseline()
+		s := currentSarif()
+		var lines *lineReader
+		if b != nil {
+			lines = newLineReader(pass)
+		}
+		report := pass.Report
+		pass.Report = func(d analysis.Diagnostic) {
+			posn := pass.Fset.Position(d.Pos)
+			if cfg.excluded(e.Analyzer.Name, posn.Filename) {
+				return
+			}
+			if b != nil && b.suppress(posn, lines.line(posn), e.Analyzer.Name, d) {
+				return
+			}
+			if s != nil {
+				s.record(pass.Fset, e, d)
+				return
+			}
+			report(d)
+		}
+		return runWithTimeout(pass, e.Analyzer.Run, *packageTimeoutFlag)
+	}
+	return &a
+}
+
+// replaceFile replaces the content of the named file by data. It writes
+// a temporary file in the same directory, and renames it, so that the
+// file is never left partially written.
+func replaceFile(filename string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Chmod(0644)
+	}
+	if err2 := f.Close(); err == nil {
+		err = err2
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filename)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
 }
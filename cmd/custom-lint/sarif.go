@@ -0,0 +1,345 @@
+package main
+
+// This file implements the -sarif mode, which writes the diagnostics
+// to a file in the SARIF 2.1.0 format, for code scanning services,
+// instead of printing them:
+//
+//	$ custom-lint -sarif=custom-lint.sarif ./...
+//
+// The file describes each enabled analyzer as a rule, with its
+// documentation and default level, and each diagnostic as a result,
+// with its location, related locations, and suggested fixes. The
+// locations of files within the current directory are relative to the
+// %SRCROOT% base, which denotes that directory.
+//
+// The file is written once all packages have been analyzed, to a
+// temporary file that then replaces it.
+//
+// Diagnostics recorded in the file are not printed, so the command
+// does not report failure because of them; errors of the analysis
+// are printed and reported as usual.
+
+import (
+	"cmp"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/token"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/custom/analyzer/registry"
+	"golang.org/x/tools/go/analysis"
+)
+
+var sarifFlag = flag.String("sarif", "", "write the diagnostics to the named `file` in SARIF format instead of printing them")
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	srcRoot      = "%SRCROOT%" // base of the URIs of files in the current directory
+)
+
+// The sarif types are the subset of the SARIF 2.1.0 object model
+// written by the -sarif mode.
+type (
+	sarifLog struct {
+		Version string     `json:"version"`
+		Schema  string     `json:"$schema"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool               sarifTool                        `json:"tool"`
+		OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+		Results            []sarifResult                    `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name  string      `json:"name"`
+		Rules []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID                   string           `json:"id"`
+		ShortDescription     sarifMessage     `json:"shortDescription"`
+		FullDescription      sarifMessage     `json:"fullDescription"`
+		HelpURI              string           `json:"helpUri,omitempty"`
+		DefaultConfiguration sarifRuleConfig  `json:"defaultConfiguration"`
+		Properties           *sarifProperties `json:"properties,omitempty"`
+	}
+	sarifRuleConfig struct {
+		Level string `json:"level"`
+	}
+	sarifProperties struct {
+		Category string `json:"category,omitempty"`
+		Fix      string `json:"fix,omitempty"` // safety of the analyzer's fixes
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID           string           `json:"ruleId"`
+		RuleIndex        int              `json:"ruleIndex"`
+		Level            string           `json:"level"`
+		Message          sarifMessage     `json:"message"`
+		Locations        []sarifLocation  `json:"locations"`
+		RelatedLocations []sarifLocation  `json:"relatedLocations,omitempty"`
+		Fixes            []sarifFix       `json:"fixes,omitempty"`
+		Properties       *sarifProperties `json:"properties,omitempty"`
+	}
+	sarifLocation struct {
+		ID               int                   `json:"id,omitempty"`
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+		Message          *sarifMessage         `json:"message,omitempty"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           sarifRegion           `json:"region"`
+	}
+	sarifArtifactLocation struct {
+		URI       string `json:"uri"`
+		URIBaseID string `json:"uriBaseId,omitempty"`
+	}
+	// A sarifRegion records both lines and columns, for display, and
+	// byte offsets, which unlike SARIF columns are exact for Go's
+	// byte-based positions.
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn"`
+		EndLine     int `json:"endLine"`
+		EndColumn   int `json:"endColumn"`
+		ByteOffset  int `json:"byteOffset"`
+		ByteLength  int `json:"byteLength"`
+	}
+	sarifFix struct {
+		Description     sarifMessage          `json:"description"`
+		ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+	}
+	sarifArtifactChange struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Replacements     []sarifReplacement    `json:"replacements"`
+	}
+	sarifReplacement struct {
+		DeletedRegion   sarifRegion  `json:"deletedRegion"`
+		InsertedContent sarifMessage `json:"insertedContent"`
+	}
+)
+
+// A sarifState records the diagnostics reported by the analyzers and
+// writes them to a SARIF file. It is safe for concurrent use.
+type sarifState struct {
+	filename string
+	dir      string // absolute current directory, the %SRCROOT%
+	rules    []sarifRule
+	index    map[string]int // rule index, by analyzer name
+
+	mu      sync.Mutex
+	results []sarifResult
+	seen    map[string]bool // keys of diagnostics already recorded
+}
+
+var (
+	// enabledEntries holds the entries of the analyzers run by the
+	// command, which the SARIF file describes as rules.
+	enabledEntries []*registry.Entry
+
+	theSarif     *sarifState
+	theSarifOnce sync.Once
+)
+
+// currentSarif returns the SARIF state denoted by the command-line
+// flags, or nil if there is none. It must not be called before the
+// flags are parsed.
+func currentSarif() *sarifState {
+	theSarifOnce.Do(func() {
+		if *sarifFlag == "" {
+			return
+		}
+		dir, err := os.Getwd()
+		if err != nil {
+			log.Fatal(err)
+		}
+		theSarif = newSarif(*sarifFlag, dir, enabledEntries)
+	})
+	return theSarif
+}
+
+// newSarif returns the state of the named SARIF file, whose rules are
+// the analyzers of entries. The locations of files in dir are relative
+// to %SRCROOT%.
+func newSarif(filename, dir string, entries []*registry.Entry) *sarifState {
+	s := &sarifState{
+		filename: filename,
+		dir:      dir,
+		index:    make(map[string]int),
+		seen:     make(map[string]bool),
+	}
+	for _, e := range entries {
+		s.index[e.Analyzer.Name] = len(s.rules)
+		short, _, _ := strings.Cut(e.Analyzer.Doc, "\n\n")
+		s.rules = append(s.rules, sarifRule{
+			ID:                   e.Analyzer.Name,
+			ShortDescription:     sarifMessage{strings.Join(strings.Fields(short), " ")},
+			FullDescription:      sarifMessage{e.Analyzer.Doc},
+			HelpURI:              e.Analyzer.URL,
+			DefaultConfiguration: sarifRuleConfig{sarifLevel(e.Severity)},
+			Properties:           &sarifProperties{Fix: e.Fix.String()},
+		})
+	}
+	return s
+}
+
+// record records the diagnostic d of the analyzer of e.
+// Test variants of a package may report the same diagnostic twice;
+// it is recorded once.
+func (s *sarifState) record(fset *token.FileSet, e *registry.Entry, d analysis.Diagnostic) {
+	loc := s.location(fset, d.Pos, d.End)
+	key := fmt.Sprintf("%s:%d: %s: %s", loc.PhysicalLocation.ArtifactLocation.URI, loc.PhysicalLocation.Region.ByteOffset, e.Analyzer.Name, d.Message)
+
+	r := sarifResult{
+		RuleID:    e.Analyzer.Name,
+		RuleIndex: s.index[e.Analyzer.Name],
+		Level:     sarifLevel(e.CategorySeverity(d.Category)),
+		Message:   sarifMessage{d.Message},
+		Locations: []sarifLocation{loc},
+	}
+	if d.Category != "" {
+		r.Properties = &sarifProperties{Category: d.Category}
+	}
+	for i, rel := range d.Related {
+		loc := s.location(fset, rel.Pos, rel.End)
+		loc.ID = i + 1
+		loc.Message = &sarifMessage{rel.Message}
+		r.RelatedLocations = append(r.RelatedLocations, loc)
+	}
+	for _, fix := range d.SuggestedFixes {
+		var changes []sarifArtifactChange
+		for _, edit := range fix.TextEdits {
+			loc := s.location(fset, edit.Pos, edit.End)
+			repl := sarifReplacement{
+				DeletedRegion:   loc.PhysicalLocation.Region,
+				InsertedContent: sarifMessage{string(edit.NewText)},
+			}
+			// Group the replacements by file, in order.
+			if n := len(changes); n > 0 && changes[n-1].ArtifactLocation == loc.PhysicalLocation.ArtifactLocation {
+				changes[n-1].Replacements = append(changes[n-1].Replacements, repl)
+			} else {
+				changes = append(changes, sarifArtifactChange{
+					ArtifactLocation: loc.PhysicalLocation.ArtifactLocation,
+					Replacements:     []sarifReplacement{repl},
+				})
+			}
+		}
+		r.Fixes = append(r.Fixes, sarifFix{
+			Description:     sarifMessage{fix.Message},
+			ArtifactChanges: changes,
+		})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seen[key] {
+		return
+	}
+	s.seen[key] = true
+	s.results = append(s.results, r)
+}
+
+// location returns the SARIF location of the range [pos, end).
+func (s *sarifState) location(fset *token.FileSet, pos, end token.Pos) sarifLocation {
+	if !end.IsValid() {
+		end = pos
+	}
+	start, stop := fset.Position(pos), fset.Position(end)
+	return sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: s.artifact(start.Filename),
+			Region: sarifRegion{
+				StartLine:   start.Line,
+				StartColumn: start.Column,
+				EndLine:     stop.Line,
+				EndColumn:   stop.Column,
+				ByteOffset:  start.Offset,
+				ByteLength:  stop.Offset - start.Offset,
+			},
+		},
+	}
+}
+
+// artifact returns the SARIF location of the named file: relative to
+// %SRCROOT% if the file is in the current directory, or else absolute.
+func (s *sarifState) artifact(filename string) sarifArtifactLocation {
+	if rel, err := filepath.Rel(s.dir, filename); err == nil {
+		rel = filepath.ToSlash(rel)
+		if rel != ".." && !strings.HasPrefix(rel, "../") {
+			return sarifArtifactLocation{URI: rel, URIBaseID: srcRoot}
+		}
+	}
+	return sarifArtifactLocation{URI: fileURI(filename)}
+}
+
+// write writes the recorded diagnostics to the SARIF file. It is
+// called once, at the end of the analysis.
+func (s *sarifState) write() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := slices.Clone(s.results)
+	if results == nil {
+		results = []sarifResult{} // not null
+	}
+	slices.SortStableFunc(results, func(x, y sarifResult) int {
+		xl, yl := x.Locations[0].PhysicalLocation, y.Locations[0].PhysicalLocation
+		return cmp.Or(
+			cmp.Compare(xl.ArtifactLocation.URIBaseID, yl.ArtifactLocation.URIBaseID),
+			cmp.Compare(xl.ArtifactLocation.URI, yl.ArtifactLocation.URI),
+			cmp.Compare(xl.Region.ByteOffset, yl.Region.ByteOffset),
+			cmp.Compare(x.RuleID, y.RuleID),
+			cmp.Compare(x.Message.Text, y.Message.Text))
+	})
+	rules := s.rules
+	if rules == nil {
+		rules = []sarifRule{}
+	}
+	doc := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{Name: "custom-lint", Rules: rules}},
+			OriginalURIBaseIDs: map[string]sarifArtifactLocation{
+				srcRoot: {URI: fileURI(s.dir) + "/"},
+			},
+			Results: results,
+		}},
+	}
+	data, err := json.MarshalIndent(doc, "", "\t")
+	if err != nil {
+		return err
+	}
+	return replaceFile(s.filename, append(data, '\n'))
+}
+
+// sarifLevel returns the SARIF level of a severity.
+func sarifLevel(s registry.Severity) string {
+	switch s {
+	case registry.SeverityError:
+		return "error"
+	case registry.SeverityWarning:
+		return "warning"
+	}
+	return "note"
+}
+
+// fileURI returns the file URI of the absolute file name.
+func fileURI(filename string) string {
+	path := filepath.ToSlash(filename)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // Windows drive letter
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
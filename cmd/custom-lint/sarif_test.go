@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"golang.org/x/tools/custom/analyzer/nosprintf"
+	"golang.org/x/tools/custom/analyzer/registry"
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestSarif(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "out.sarif")
+	entries := registry.Entries()
+	s := newSarif(filename, dir, entries)
+	if err := s.write(); err != nil {
+		t.Fatal(err)
+	}
+	// Without diagnostics, the file has the rules only.
+	if got := readSarif(t, filename); len(got.Runs[0].Results) != 0 || len(got.Runs[0].Tool.Driver.Rules) != len(entries) {
+		t.Fatalf("empty SARIF file has %d results and %d rules, want 0 and %d",
+			len(got.Runs[0].Results), len(got.Runs[0].Tool.Driver.Rules), len(entries))
+	}
+
+	fset := token.NewFileSet()
+	a := fset.AddFile(filepath.Join(dir, "a.go"), -1, 100)
+	a.SetLines([]int{0, 20, 40})
+	other := fset.AddFile("/elsewhere/b.go", -1, 10)
+	d := analysis.Diagnostic{
+		Pos:      a.Pos(25),
+		End:      a.Pos(30),
+		Category: nosprintf.CategoryHigh,
+		Message:  "costly call",
+		Related:  []analysis.RelatedInformation{{Pos: other.Pos(3), Message: "see here"}},
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "Use strconv",
+			TextEdits: []analysis.TextEdit{{Pos: a.Pos(25), End: a.Pos(30), NewText: []byte("x")}},
+		}},
+	}
+	e := registry.Lookup("nosprintf")
+	s.record(fset, e, d)
+	s.record(fset, e, d) // test variant
+	if err := s.write(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := readSarif(t, filename)
+	if got.Version != "2.1.0" {
+		t.Errorf("version = %q, want 2.1.0", got.Version)
+	}
+	if len(got.Runs[0].Results) != 1 {
+		t.Fatalf("got %d results, want 1", len(got.Runs[0].Results))
+	}
+	r := got.Runs[0].Results[0]
+	region := sarifRegion{StartLine: 2, StartColumn: 6, EndLine: 2, EndColumn: 11, ByteOffset: 25, ByteLength: 5}
+	want := sarifResult{
+		RuleID:    "nosprintf",
+		RuleIndex: s.index["nosprintf"],
+		Level:     "error", // severity of CategoryHigh
+		Message:   sarifMessage{"costly call"},
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: "a.go", URIBaseID: srcRoot},
+				Region:           region,
+			},
+		}},
+		RelatedLocations: []sarifLocation{{
+			ID: 1,
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: "file:///elsewhere/b.go"},
+				Region:           sarifRegion{StartLine: 1, StartColumn: 4, EndLine: 1, EndColumn: 4, ByteOffset: 3},
+			},
+			Message: &sarifMessage{"see here"},
+		}},
+		Fixes: []sarifFix{{
+			Description: sarifMessage{"Use strconv"},
+			ArtifactChanges: []sarifArtifactChange{{
+				ArtifactLocation: sarifArtifactLocation{URI: "a.go", URIBaseID: srcRoot},
+				Replacements:     []sarifReplacement{{DeletedRegion: region, InsertedContent: sarifMessage{"x"}}},
+			}},
+		}},
+		Properties: &sarifProperties{Category: nosprintf.CategoryHigh},
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("result = %+v, want %+v", r, want)
+	}
+	if rule := got.Runs[0].Tool.Driver.Rules[r.RuleIndex]; rule.ID != "nosprintf" || rule.DefaultConfiguration.Level != "warning" || rule.HelpURI == "" {
+		t.Errorf("rule of result = %+v, want nosprintf rule with level warning and help URI", rule)
+	}
+}
+
+func readSarif(t *testing.T, filename string) *sarifLog {
+	t.Helper()
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatal(err)
+	}
+	return &log
+}
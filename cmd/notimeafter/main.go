@@ -0,0 +1,8 @@
+package main
+
+import (
+	"golang.org/x/tools/custom/analyzer/notimeafter"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() { singlechecker.Main(notimeafter.Analyzer) }
@@ -0,0 +1,8 @@
+package main
+
+import (
+	"golang.org/x/tools/custom/analyzer/notimenow"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() { singlechecker.Main(notimenow.Analyzer) }
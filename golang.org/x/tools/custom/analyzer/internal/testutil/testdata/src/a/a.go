@@ -0,0 +1,5 @@
+package a
+
+func f() {
+	println("hello") // want `call of println`
+}
@@ -0,0 +1,100 @@
+// Package testutil runs the tests of the custom analyzers.
+//
+// Run checks the diagnostics of an analyzer against the "want"
+// comments of its testdata, as analysistest.Run does, and checks its
+// suggested fixes against the .golden files placed alongside each
+// source file, as analysistest.RunWithSuggestedFixes does. In
+// addition, it reports the golden files that no suggested fix
+// verifies, so that a fix that is no longer offered fails the test
+// rather than going unnoticed. A typical test is:
+//
+//	func Test(t *testing.T) {
+//		testutil.Run(t, myanalyzer.Analyzer, "a", "b")
+//	}
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// Run runs the analyzer on the packages of the testdata directory
+// that match the patterns, and checks its diagnostics and suggested
+// fixes. It returns the results of the analysis.
+func Run(t *testing.T, a *analysis.Analyzer, patterns ...string) []*analysistest.Result {
+	t.Helper()
+	results := analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, patterns...)
+	CheckGolden(t, results)
+	return results
+}
+
+// CheckGolden reports each golden file in the directories of the
+// analyzed packages whose source file has no suggested fix.
+func CheckGolden(t analysistest.Testing, results []*analysistest.Result) {
+	var (
+		dirs  = make(map[string]bool)
+		fixed = make(map[string]bool) // files with suggested fixes
+	)
+	for _, result := range results {
+		pass := result.Pass
+		for _, file := range pass.Files {
+			dirs[filepath.Dir(pass.Fset.File(file.Pos()).Name())] = true
+		}
+		for _, diag := range result.Diagnostics {
+			for _, fix := range diag.SuggestedFixes {
+				for _, edit := range fix.TextEdits {
+					fixed[pass.Fset.File(edit.Pos).Name()] = true
+				}
+			}
+		}
+	}
+	for dir := range dirs {
+		golden, err := filepath.Glob(filepath.Join(dir, "*.golden"))
+		if err != nil {
+			t.Errorf("%v", err)
+			continue
+		}
+		for _, filename := range golden {
+			source := strings.TrimSuffix(filename, ".golden")
+			if fixed[source] {
+				continue
+			}
+			if _, err := os.Stat(source); err != nil {
+				t.Errorf("%s: golden file without source file", relative(filename))
+			} else {
+				t.Errorf("%s: golden file of a file without suggested fixes", relative(filename))
+			}
+		}
+	}
+}
+
+// SetFlag sets the named flag of the analyzer for the duration of the
+// test.
+func SetFlag(t *testing.T, a *analysis.Analyzer, name, value string) {
+	t.Helper()
+	f := a.Flags.Lookup(name)
+	if f == nil {
+		t.Fatalf("analyzer %s has no flag %s", a.Name, name)
+	}
+	prev := f.Value.String()
+	if err := a.Flags.Set(name, value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { a.Flags.Set(name, prev) })
+}
+
+// relative returns filename relative to the current directory, for
+// tidier errors.
+func relative(filename string) string {
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, filename); err == nil {
+			return rel
+		}
+	}
+	return filename
+}
@@ -0,0 +1,64 @@
+package testutil_test
+
+import (
+	"fmt"
+	"go/ast"
+	"slices"
+	"testing"
+
+	"golang.org/x/tools/custom/analyzer/internal/testutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// analyzer reports calls of println, and suggests print instead.
+var analyzer = &analysis.Analyzer{
+	Name: "println",
+	Doc:  "println reports calls of println",
+	Run: func(pass *analysis.Pass) (any, error) {
+		for _, file := range pass.Files {
+			ast.Inspect(file, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					if id, ok := call.Fun.(*ast.Ident); ok && id.Name == "println" {
+						pass.Report(analysis.Diagnostic{
+							Pos:     call.Pos(),
+							End:     call.End(),
+							Message: "call of println",
+							SuggestedFixes: []analysis.SuggestedFix{{
+								Message:   "Use print",
+								TextEdits: []analysis.TextEdit{{Pos: id.Pos(), End: id.End(), NewText: []byte("print")}},
+							}},
+						})
+					}
+				}
+				return true
+			})
+		}
+		return nil, nil
+	},
+}
+
+func TestRun(t *testing.T) {
+	testutil.Run(t, analyzer, "a")
+}
+
+func TestCheckGolden(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), analyzer, "b")
+	var rec recorder
+	testutil.CheckGolden(&rec, results)
+	slices.Sort(rec)
+	want := []string{
+		"testdata/src/b/b.go.golden: golden file of a file without suggested fixes",
+		"testdata/src/b/gone.go.golden: golden file without source file",
+	}
+	if !slices.Equal(rec, want) {
+		t.Errorf("CheckGolden reported %q, want %q", rec, want)
+	}
+}
+
+// A recorder records the errors reported to it.
+type recorder []string
+
+func (r *recorder) Errorf(format string, args ...any) {
+	*r = append(*r, fmt.Sprintf(format, args...))
+}
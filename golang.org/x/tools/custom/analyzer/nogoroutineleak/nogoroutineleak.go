@@ -0,0 +1,225 @@
+// Package nogoroutineleak defines an analyzer that reports goroutines
+// that nothing can stop or wait for.
+package nogoroutineleak
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "nogoroutineleak",
+	Doc: `nogoroutineleak reports goroutines that have no cancellation path.
+
+A goroutine started by a go statement may outlive the function that
+starts it. Unless something can stop it or wait for it, it leaks, along
+with everything it references. The analyzer reports a go statement if
+the goroutine may run forever, as it has a for loop without condition
+or a select statement without cases, and if neither the goroutine's
+function nor the arguments of the call use a context.Context or a
+sync.WaitGroup, or operate on a channel, which are the usual means to
+cancel a goroutine or to wait for its end.
+
+The calls of the functions and methods declared in the same package are
+followed, as their bodies may loop or synchronize in their stead: for
+example, a method that closes a channel of its receiver to signal that
+the work is complete. The call of a method of another package counts as
+a means of synchronization if its receiver has an exported field of
+type context.Context or sync.WaitGroup.
+
+Only the goroutines whose function is a function literal, or a function
+or method declared in the same package, are checked, as the body of
+other functions is not available. Goroutines started by the main
+function of a main package end with the program, and are not reported.`,
+	URL:      "https://github.com/satorunooshie/go-tools/tree/main/golang.org/x/tools/custom/analyzer/nogoroutineleak",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Record the declarations of the functions of the package,
+	// whose bodies the goroutines may run.
+	decls := make(map[*types.Func]*ast.FuncDecl)
+	for cur := range inspect.Root().Preorder((*ast.FuncDecl)(nil)) {
+		decl := cur.Node().(*ast.FuncDecl)
+		if fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func); ok && decl.Body != nil {
+			decls[fn] = decl
+		}
+	}
+
+	for cur := range inspect.Root().Preorder((*ast.GoStmt)(nil)) {
+		stmt := cur.Node().(*ast.GoStmt)
+		if inMain(pass, cur) {
+			continue
+		}
+
+		if _, ok := ast.Unparen(stmt.Call.Fun).(*ast.FuncLit); !ok {
+			fn := typeutil.StaticCallee(pass.TypesInfo, stmt.Call)
+			if fn == nil {
+				continue // dynamic call
+			}
+			if _, ok := decls[fn.Origin()]; !ok {
+				continue // body not available
+			}
+		}
+		// The call includes the goroutine's function literal, if any,
+		// and calls the function or method it runs.
+		c := &checker{info: pass.TypesInfo, decls: decls}
+		if !c.unbounded(stmt.Call) || c.synchronizes(stmt.Call) {
+			continue
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:     stmt.Go,
+			End:     stmt.Go + token.Pos(len("go")),
+			Message: "goroutine has no cancellation path: it may run forever, and uses no context, channel, or sync.WaitGroup",
+		})
+	}
+	return nil, nil
+}
+
+// inMain reports whether the cursor is within the main function of a
+// main package.
+func inMain(pass *analysis.Pass, cur inspector.Cursor) bool {
+	if pass.Pkg.Name() != "main" {
+		return false
+	}
+	for cur := range cur.Enclosing((*ast.FuncDecl)(nil)) {
+		decl := cur.Node().(*ast.FuncDecl)
+		return decl.Recv == nil && decl.Name.Name == "main"
+	}
+	return false
+}
+
+// A checker finds the means by which a goroutine may be cancelled or
+// waited for, and the means by which it may run forever.
+type checker struct {
+	info  *types.Info
+	decls map[*types.Func]*ast.FuncDecl // functions declared in the package
+}
+
+// synchronizes reports whether the code reachable from n synchronizes:
+// whether it refers to a context.Context or a sync.WaitGroup, operates
+// on a channel, or calls a method of another package whose receiver
+// has an exported field of type context.Context or sync.WaitGroup.
+func (c *checker) synchronizes(n ast.Node) bool {
+	return c.reaches(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.UnaryExpr:
+			return n.Op == token.ARROW // receive
+		case *ast.SendStmt:
+			return true
+		case *ast.RangeStmt:
+			_, ok := c.info.TypeOf(n.X).Underlying().(*types.Chan)
+			return ok
+		case *ast.CallExpr:
+			if id, ok := ast.Unparen(n.Fun).(*ast.Ident); ok {
+				if b, ok := c.info.Uses[id].(*types.Builtin); ok && b.Name() == "close" {
+					return true
+				}
+			}
+			if fn := typeutil.StaticCallee(c.info, n); fn != nil && c.decls[fn.Origin()] == nil {
+				recv := fn.Signature().Recv()
+				return recv != nil && holdsSyncType(recv.Type())
+			}
+		case ast.Expr:
+			return isSyncType(c.info.TypeOf(n))
+		}
+		return false
+	})
+}
+
+// unbounded reports whether the code reachable from n may run
+// forever: whether it has a for statement without condition, or a
+// select statement without cases. A goroutine that runs neither ends
+// on its own, and does not leak.
+func (c *checker) unbounded(n ast.Node) bool {
+	return c.reaches(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ForStmt:
+			return n.Cond == nil
+		case *ast.SelectStmt:
+			return len(n.Body.List) == 0
+		}
+		return false
+	})
+}
+
+// reaches reports whether f holds for a node within n, or within the
+// body of a function or method declared in the package that n calls,
+// directly or not.
+func (c *checker) reaches(n ast.Node, f func(ast.Node) bool) bool {
+	seen := make(map[*types.Func]bool)
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		found := false
+		ast.Inspect(n, func(n ast.Node) bool {
+			if found {
+				return false
+			}
+			if f(n) {
+				found = true
+			} else if call, ok := n.(*ast.CallExpr); ok {
+				if fn := typeutil.StaticCallee(c.info, call); fn != nil {
+					fn = fn.Origin()
+					if decl, ok := c.decls[fn]; ok && !seen[fn] {
+						seen[fn] = true
+						found = visit(decl.Body)
+					}
+				}
+			}
+			return !found
+		})
+		return found
+	}
+	return visit(n)
+}
+
+// holdsSyncType reports whether t is a struct, or a pointer to one,
+// that has an exported field of type context.Context or sync.WaitGroup.
+// Its methods are then presumed to use the field: a channel field is
+// not enough, as a time.Timer shows, whose Reset method does not use
+// its channel.
+func holdsSyncType(t types.Type) bool {
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for field := range st.Fields() {
+		if field.Exported() && isSyncType(field.Type()) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSyncType reports whether t is a context.Context or a
+// sync.WaitGroup, or a pointer to one.
+func isSyncType(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	switch path, name := named.Obj().Pkg().Path(), named.Obj().Name(); {
+	case path == "context" && name == "Context",
+		path == "sync" && name == "WaitGroup":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,12 @@
+package nogoroutineleak_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/custom/analyzer/internal/testutil"
+	"golang.org/x/tools/custom/analyzer/nogoroutineleak"
+)
+
+func Test(t *testing.T) {
+	testutil.Run(t, nogoroutineleak.Analyzer, "a", "b")
+}
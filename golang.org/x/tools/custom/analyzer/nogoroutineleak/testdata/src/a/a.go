@@ -0,0 +1,189 @@
+package a
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"work"
+)
+
+func Poll(f func()) {
+	go func() { // want "goroutine has no cancellation path: it may run forever, and uses no context, channel, or sync.WaitGroup"
+		for {
+			f()
+			time.Sleep(time.Second)
+		}
+	}()
+}
+
+func PollContext(ctx context.Context, f func()) {
+	go func() { // ok: consults the context
+		for ctx.Err() == nil {
+			f()
+			time.Sleep(time.Second)
+		}
+	}()
+}
+
+func Wait(fs []func()) {
+	var wg sync.WaitGroup
+	for _, f := range fs {
+		wg.Add(1)
+		go func() { // ok: waited for
+			defer wg.Done()
+			f()
+		}()
+	}
+	wg.Wait()
+}
+
+func Result(f func() int) <-chan int {
+	ch := make(chan int, 1)
+	go func() { ch <- f() }() // ok: reports its end on a channel
+	return ch
+}
+
+type server struct {
+	done chan struct{}
+}
+
+func (s *server) Start() {
+	go s.loop() // ok: loop stops when done is closed
+	go s.spin() // want "goroutine has no cancellation path"
+}
+
+func (s *server) loop() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (s *server) spin() {
+	for {
+		time.Sleep(time.Second)
+	}
+}
+
+func Serve(ctx context.Context) {
+	go serve(ctx)              // ok: the context is passed
+	go tick()                  // want "goroutine has no cancellation path"
+	go time.Sleep(time.Second) // ok: body of other packages is not available
+}
+
+func serve(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+func tick() {
+	for {
+		time.Sleep(time.Second)
+	}
+}
+
+func Dynamic(f func()) {
+	go f() // ok: dynamic call
+}
+
+// A future is completed by closing its channel, as in go/loader.
+type future struct {
+	value    int
+	complete chan struct{}
+}
+
+func (f *future) set(v int) {
+	f.value = v
+	close(f.complete)
+}
+
+func (f *future) compute(g func() int) {
+	for {
+		if v := g(); v >= 0 {
+			f.set(v)
+			return
+		}
+	}
+}
+
+func Start(g func() int) *future {
+	f := &future{complete: make(chan struct{})}
+	go func() { // ok: set closes the channel
+		for {
+			if v := g(); v >= 0 {
+				f.set(v)
+				return
+			}
+		}
+	}()
+	go f.compute(g) // ok: compute calls set, which closes the channel
+	return f
+}
+
+func Rearm(t *time.Timer) {
+	go func() { // want "goroutine has no cancellation path"
+		for {
+			t.Reset(time.Second) // the channel of a Timer is not a means to stop this loop
+		}
+	}()
+}
+
+func Submit(g *work.Group, f func()) {
+	go func() { // ok: the receiver of Go has an exported WaitGroup
+		for {
+			g.Go(f)
+			time.Sleep(time.Second)
+		}
+	}()
+}
+
+func Pump(p *work.Pool, f func()) {
+	go func() { // want "goroutine has no cancellation path"
+		for {
+			p.Run(f)
+		}
+	}()
+}
+
+func Hold() {
+	go func() { // want "goroutine has no cancellation path"
+		ch := make(chan int)
+		for {
+			_ = ch // not an operation on the channel
+			time.Sleep(time.Second)
+		}
+	}()
+}
+
+func Once() {
+	go func() { // ok: ends on its own
+		time.Sleep(time.Second)
+	}()
+}
+
+func Block() {
+	go func() { // want "goroutine has no cancellation path"
+		select {}
+	}()
+}
+
+func Recur() {
+	go recur(3) // want "goroutine has no cancellation path"
+}
+
+func recur(n int) {
+	for {
+		if n > 0 {
+			recur(n - 1)
+		}
+		time.Sleep(time.Second)
+	}
+}
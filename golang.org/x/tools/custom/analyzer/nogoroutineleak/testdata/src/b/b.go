@@ -0,0 +1,20 @@
+package main
+
+import "time"
+
+func main() {
+	go func() { // ok: ends with the program
+		for {
+			time.Sleep(time.Second)
+		}
+	}()
+	start()
+}
+
+func start() {
+	go func() { // want "goroutine has no cancellation path"
+		for {
+			time.Sleep(time.Second)
+		}
+	}()
+}
@@ -0,0 +1,23 @@
+package work
+
+import "sync"
+
+// A Group runs functions, and waits for them with its WaitGroup.
+type Group struct {
+	WG sync.WaitGroup
+}
+
+func (g *Group) Go(f func()) {
+	g.WG.Add(1)
+	go func() {
+		defer g.WG.Done()
+		f()
+	}()
+}
+
+// A Pool runs functions, but its WaitGroup is not part of its API.
+type Pool struct {
+	wg sync.WaitGroup
+}
+
+func (p *Pool) Run(f func()) { f() }
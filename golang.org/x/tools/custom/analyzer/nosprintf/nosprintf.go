@@ -1,6 +1,7 @@
 package nosprintf
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
@@ -8,74 +9,237 @@ import (
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/edge"
 	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
 )
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "nosprintf",
-	Doc:      "nosprintf warns fmt.Sprintf for better performance.",
+	Name: "nosprintf",
+	Doc: `nosprintf warns fmt.Sprintf for better performance.
+
+Calls whose format uses one of the verbs of the -nosprintf.allow-verbs
+flag, or is longer than -nosprintf.max-format-len bytes, and calls with
+more than -nosprintf.max-args arguments, are acceptable.
+
+With the -nosprintf.sprint flag, calls of fmt.Sprint and fmt.Sprintln
+are reported too; with the -nosprintf.errorf flag, so are calls of
+fmt.Errorf whose format does not wrap an error with %w.
+
+With the -nosprintf.cost flag, the analyzer estimates the cost of each
+reported call, and records it in the category of the diagnostic:
+
+  - "high": the result is wasted or copied again, because it is an
+    argument of a debug or trace logging call, which is usually
+    disabled (see -nosprintf.disabled-log-funcs), or of another fmt
+    call, or an operand of a string concatenation;
+  - "low": the call is on an error path, because it is a call of
+    fmt.Errorf, an argument of errors.New or panic, or within the body
+    of an "if err != nil" statement;
+  - "normal": any other call.
+
+The custom-lint command grades the severity of the diagnostics by their
+category: in its -sarif output, those of the "high" category are errors,
+and those of the "low" category are notes. gopls, which does not set the
+flag, reports all of them as warnings.`,
+	URL:      "https://github.com/satorunooshie/go-tools/tree/main/golang.org/x/tools/custom/analyzer/nosprintf",
 	Run:      run,
 	Requires: []*analysis.Analyzer{inspect.Analyzer},
 }
 
-// FIXME: check alias import, dot import, etc.
+// Flags that tune the heuristics by which a call of fmt.Sprintf is
+// acceptable.
+var (
+	allowVerbs   = "%0,%1,%.,%x,%+v,%#v" // -allow-verbs flag
+	maxArgs      = 5                     // -max-args flag
+	maxFormatLen = 32                    // -max-format-len flag
+	sprint       = false                 // -sprint flag
+	errorf       = false                 // -errorf flag
+	cost         = false                 // -cost flag
+
+	disabledLogFuncs = "Debug,Trace" // -disabled-log-funcs flag
+)
+
+// Categories of diagnostics, with the -cost flag.
+const (
+	CategoryHigh   = "high"   // the result is wasted or copied again
+	CategoryNormal = "normal" // neither high nor low
+	CategoryLow    = "low"    // the call is on an error path
+)
+
+func init() {
+	Analyzer.Flags.StringVar(&allowVerbs, "allow-verbs", allowVerbs,
+		"comma-separated list of verbs, or verb prefixes such as %0 or %., whose use in the format makes fmt.Sprintf acceptable")
+	Analyzer.Flags.IntVar(&maxArgs, "max-args", maxArgs,
+		"maximum number of arguments of fmt.Sprintf, format included, above which it is acceptable")
+	Analyzer.Flags.IntVar(&maxFormatLen, "max-format-len", maxFormatLen,
+		"maximum length of a literal format, quotes included, above which fmt.Sprintf is acceptable")
+	Analyzer.Flags.BoolVar(&sprint, "sprint", sprint,
+		"also report calls of fmt.Sprint and fmt.Sprintln")
+	Analyzer.Flags.BoolVar(&errorf, "errorf", errorf,
+		"also report calls of fmt.Errorf whose format does not use %w")
+	Analyzer.Flags.BoolVar(&cost, "cost", cost,
+		"estimate the cost of each call, and record it in the category of the diagnostic")
+	Analyzer.Flags.StringVar(&disabledLogFuncs, "disabled-log-funcs", disabledLogFuncs,
+		"comma-separated list of prefixes of the names of logging functions and methods whose level is usually disabled, such as Debug for Debugf")
+}
+
 func run(pass *analysis.Pass) (interface{}, error) {
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
-	nodeFilter := []ast.Node{
-		(*ast.CallExpr)(nil),
-	}
-
-	inspect.Preorder(nodeFilter, func(n ast.Node) {
-		if strings.HasSuffix(pass.Fset.File(n.Pos()).Name(), "_test.go") {
-			return
+	for cur := range inspect.Root().Preorder((*ast.CallExpr)(nil)) {
+		call := cur.Node().(*ast.CallExpr)
+		if strings.HasSuffix(pass.Fset.File(call.Pos()).Name(), "_test.go") {
+			continue
 		}
 
-		call := n.(*ast.CallExpr)
-		if types.ExprString(call.Fun) != "fmt.Sprintf" {
-			return
+		// Resolve the callee, so that renamed and dot imports of fmt
+		// are handled like the others.
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "fmt" {
+			continue
+		}
+		hasFormat := true
+		switch fn.Name() {
+		case "Sprintf":
+		case "Sprint", "Sprintln":
+			if !sprint {
+				continue
+			}
+			hasFormat = false
+		case "Errorf":
+			if !errorf || wraps(call) {
+				continue
+			}
+		default:
+			continue
 		}
-		if canUse(pass, call) {
-			return
+		if canUse(pass, call, hasFormat) {
+			continue
 		}
 
-		pass.Reportf(call.Pos(), "Don't use fmt.Sprintf")
-	})
+		diag := analysis.Diagnostic{
+			Pos:     call.Pos(),
+			Message: fmt.Sprintf("Don't use fmt.%s", fn.Name()),
+		}
+		if cost {
+			diag.Category = category(pass.TypesInfo, cur, fn)
+		}
+		pass.Report(diag)
+	}
 
 	return nil, nil
 }
 
-func canUse(pass *analysis.Pass, call *ast.CallExpr) bool {
-	if len(call.Args) > 5 {
-		return true
-	}
-
-	if call.Ellipsis != token.NoPos {
-		return true
+// category returns the category of the cost of the call of fmt
+// function fn at cur.
+func category(info *types.Info, cur inspector.Cursor, fn *types.Func) string {
+	if fn.Name() == "Errorf" {
+		return CategoryLow
 	}
 
-	if v, ok := call.Args[0].(*ast.BasicLit); ok {
-		if len(v.Value) > 32 {
-			return true
+	// Where does the result go?
+	switch parent := cur.Parent().Node().(type) {
+	case *ast.BinaryExpr:
+		if parent.Op == token.ADD {
+			return CategoryHigh // concatenated
 		}
-		if strings.Contains(v.Value, "%0") {
-			return true
+	case *ast.CallExpr:
+		if ek, _ := cur.ParentEdge(); ek != edge.CallExpr_Args {
+			break
 		}
-		if strings.Contains(v.Value, "%1") {
-			return true
+		switch callee := typeutil.Callee(info, parent).(type) {
+		case *types.Func:
+			if callee.Pkg() != nil && callee.Pkg().Path() == "fmt" {
+				return CategoryHigh // formatted again
+			}
+			if isDisabledLog(callee.Name()) {
+				return CategoryHigh // logged at a disabled level
+			}
+			if callee.Pkg() != nil && callee.Pkg().Path() == "errors" && callee.Name() == "New" {
+				return CategoryLow
+			}
+		case *types.Builtin:
+			if callee.Name() == "panic" {
+				return CategoryLow
+			}
 		}
-		if strings.Contains(v.Value, "%.") {
-			return true
+	}
+
+	// Is the call within the body of an 'if err != nil' statement?
+	for c := range cur.Enclosing((*ast.IfStmt)(nil), (*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+		ifStmt, ok := c.Node().(*ast.IfStmt)
+		if !ok {
+			break // function boundary
 		}
-		if strings.Contains(v.Value, "%x") {
-			return true
+		if ifStmt.Body.Pos() <= cur.Node().Pos() && cur.Node().End() <= ifStmt.Body.End() && isErrNotNil(info, ifStmt.Cond) {
+			return CategoryLow
 		}
-		if strings.Contains(v.Value, "%+v") {
+	}
+	return CategoryNormal
+}
+
+// isDisabledLog reports whether name is that of a logging function
+// whose level is usually disabled.
+func isDisabledLog(name string) bool {
+	for prefix := range strings.SplitSeq(disabledLogFuncs, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" && strings.HasPrefix(name, prefix) {
 			return true
 		}
-		if strings.Contains(v.Value, "%#v") {
+	}
+	return false
+}
+
+// isErrNotNil reports whether cond has the form 'x != nil', where x
+// is an error.
+func isErrNotNil(info *types.Info, cond ast.Expr) bool {
+	bin, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+	if !ok || bin.Op != token.NEQ {
+		return false
+	}
+	x, y := bin.X, bin.Y
+	if info.Types[x].IsNil() {
+		x, y = y, x
+	}
+	return info.Types[y].IsNil() && types.Identical(info.TypeOf(x), errorType)
+}
+
+var errorType = types.Universe.Lookup("error").Type()
+
+// wraps reports whether the format of a call of fmt.Errorf wraps an
+// error with %w. A format that is not a literal might.
+func wraps(call *ast.CallExpr) bool {
+	if len(call.Args) == 0 {
+		return false
+	}
+	v, ok := call.Args[0].(*ast.BasicLit)
+	return !ok || strings.Contains(v.Value, "%w")
+}
+
+// canUse reports whether the call is acceptable. If hasFormat, its
+// first argument is a format.
+func canUse(pass *analysis.Pass, call *ast.CallExpr, hasFormat bool) bool {
+	if len(call.Args) > maxArgs {
+		return true
+	}
+
+	if call.Ellipsis != token.NoPos {
+		return true
+	}
+
+	if len(call.Args) == 0 {
+		return false
+	}
+
+	if v, ok := call.Args[0].(*ast.BasicLit); ok && hasFormat {
+		if len(v.Value) > maxFormatLen {
 			return true
 		}
+		for verb := range strings.SplitSeq(allowVerbs, ",") {
+			if verb = strings.TrimSpace(verb); verb != "" && strings.Contains(v.Value, verb) {
+				return true
+			}
+		}
 	}
 
 	for _, v := range call.Args {
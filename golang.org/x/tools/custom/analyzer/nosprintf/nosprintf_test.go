@@ -0,0 +1,64 @@
+package nosprintf_test
+
+import (
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/custom/analyzer/internal/testutil"
+	"golang.org/x/tools/custom/analyzer/nosprintf"
+)
+
+func Test(t *testing.T) {
+	testutil.Run(t, nosprintf.Analyzer, "a")
+}
+
+func TestFlags(t *testing.T) {
+	for name, value := range map[string]string{
+		"allow-verbs":    "%d",
+		"max-args":       "2",
+		"max-format-len": "8",
+	} {
+		testutil.SetFlag(t, nosprintf.Analyzer, name, value)
+	}
+
+	testutil.Run(t, nosprintf.Analyzer, "b")
+}
+
+func TestModes(t *testing.T) {
+	for _, name := range []string{"sprint", "errorf"} {
+		testutil.SetFlag(t, nosprintf.Analyzer, name, "true")
+	}
+
+	testutil.Run(t, nosprintf.Analyzer, "c")
+}
+
+// TestCost checks the categories of the diagnostics with the -cost
+// flag against the comment that precedes the want comment of each line.
+func TestCost(t *testing.T) {
+	for _, name := range []string{"cost", "errorf"} {
+		testutil.SetFlag(t, nosprintf.Analyzer, name, "true")
+	}
+
+	categoriesRx := regexp.MustCompile(`/\* ([a-z, ]+) \*/`)
+	for _, result := range testutil.Run(t, nosprintf.Analyzer, "d") {
+		for _, diag := range result.Diagnostics {
+			posn := result.Pass.Fset.Position(diag.Pos)
+			content, err := os.ReadFile(posn.Filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			line := strings.Split(string(content), "\n")[posn.Line-1]
+			m := categoriesRx.FindStringSubmatch(line)
+			if m == nil {
+				t.Errorf("%v: no expected categories", posn)
+				continue
+			}
+			if categories := strings.Split(m[1], ", "); !slices.Contains(categories, diag.Category) {
+				t.Errorf("%v: category %q, want one of %q", posn, diag.Category, categories)
+			}
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package a
+
+import "fmt"
+
+func _(s string, n int) {
+	_ = fmt.Sprintf("%s-%s", s, s) // want "Don't use fmt.Sprintf"
+	_ = fmt.Sprintf("%d", n)       // want "Don't use fmt.Sprintf"
+	_ = fmt.Sprintf("%x", s)
+	_ = fmt.Sprintf("%+v", s)
+	_ = fmt.Sprintf("%05d", n)
+	_ = fmt.Sprintf("%s%s%s%s%s", s, s, s, s, s)
+	_ = fmt.Sprintf("a format that is longer than the limit: %s", s)
+}
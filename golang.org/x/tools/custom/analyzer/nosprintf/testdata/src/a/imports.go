@@ -0,0 +1,13 @@
+package a
+
+import (
+	. "fmt"
+	f "fmt"
+)
+
+func _(s string) {
+	_ = f.Sprintf("%s-%s", s, s) // want "Don't use fmt.Sprintf"
+	_ = Sprintf("%s-%s", s, s)   // want "Don't use fmt.Sprintf"
+	_ = f.Sprint(s)
+	_ = f.Errorf("%s", s)
+}
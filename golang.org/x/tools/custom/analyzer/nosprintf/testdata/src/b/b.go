@@ -0,0 +1,14 @@
+package b
+
+import "fmt"
+
+// This package is checked with -allow-verbs=%d, -max-args=2, and
+// -max-format-len=8.
+
+func _(s string, n int) {
+	_ = fmt.Sprintf("%s", s) // want "Don't use fmt.Sprintf"
+	_ = fmt.Sprintf("%x", s) // want "Don't use fmt.Sprintf"
+	_ = fmt.Sprintf("%d", n)
+	_ = fmt.Sprintf("%s%s", s, s)
+	_ = fmt.Sprintf("%s------", s)
+}
@@ -0,0 +1,18 @@
+package c
+
+import (
+	"errors"
+	"fmt"
+)
+
+// This package is checked with -sprint and -errorf.
+
+func _(s string, err error) {
+	_ = fmt.Sprint(s)                          // want "Don't use fmt.Sprint"
+	_ = fmt.Sprintln(s, s)                     // want "Don't use fmt.Sprintln"
+	_ = fmt.Sprint(s, s, s, s, s, s)           // ok: too many arguments
+	_ = fmt.Errorf("bad %s", s)                // want "Don't use fmt.Errorf"
+	_ = fmt.Errorf("bad %s: %w", s, err)       // ok: wraps err
+	_ = fmt.Errorf("bad %x", s)                // ok: allowed verb
+	_ = errors.New(fmt.Sprintf("%s-%s", s, s)) // want "Don't use fmt.Sprintf"
+}
@@ -0,0 +1,37 @@
+package d
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// This package is checked with -cost and -errorf. The expected
+// category of each diagnostic is written in a comment before it.
+
+type logger struct{}
+
+func (logger) Debugf(format string, args ...any) {}
+func (logger) Infof(format string, args ...any)  {}
+
+func _(s string, n int, log logger, err error) error {
+	log.Debugf(fmt.Sprintf("%s-%s", s, s))                   /* high */         // want "Don't use fmt.Sprintf"
+	slog.Debug(fmt.Sprintf("%s-%s", s, s))                   /* high */         // want "Don't use fmt.Sprintf"
+	_ = fmt.Sprintf("%s: %s", fmt.Sprintf("%s-%s", s, s), s) /* normal, high */ // want "Don't use fmt.Sprintf" "Don't use fmt.Sprintf"
+	_ = "prefix: " + fmt.Sprintf("%s-%s", s, s)              /* high */         // want "Don't use fmt.Sprintf"
+	log.Infof(fmt.Sprintf("%s-%s", s, s))                    /* normal */       // want "Don't use fmt.Sprintf"
+	msg := fmt.Sprintf("%s-%d", s, n)                        /* normal */       // want "Don't use fmt.Sprintf"
+	if err != nil {
+		return errors.New(fmt.Sprintf("%s: %d", msg, n)) /* low */ // want "Don't use fmt.Sprintf"
+	}
+	if n < 0 {
+		panic(fmt.Sprintf("%s-%d", s, n)) /* low */ // want "Don't use fmt.Sprintf"
+	}
+	if err := check(); err != nil {
+		msg := fmt.Sprintf("%s-%d", s, n) /* low */ // want "Don't use fmt.Sprintf"
+		return fmt.Errorf("%s", msg)      /* low */ // want "Don't use fmt.Errorf"
+	}
+	return nil
+}
+
+func check() error { return nil }
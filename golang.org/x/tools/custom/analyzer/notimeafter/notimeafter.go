@@ -0,0 +1,324 @@
+// Package notimeafter defines an analyzer that reports calls of
+// time.After in the cases of a select statement within a loop.
+package notimeafter
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/astutil"
+	"golang.org/x/tools/internal/versions"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "notimeafter",
+	Doc: `notimeafter reports calls of time.After in a select statement within a loop.
+
+Each call of time.After allocates a new timer, which lives until it
+fires, even when another case of the select statement is chosen. In a
+loop, such as one that waits for messages with a timeout, the timers
+accumulate, and memory grows with the rate of the loop rather than with
+the timeout. Since Go 1.23 a timer that is no longer referenced may be
+collected before it fires, but each iteration still allocates one.
+
+The suggested fix creates a single timer with time.NewTimer before the
+loop and resets it before the select statement:
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	for {
+		timer.Reset(d)
+		select {
+		case msg := <-ch:
+			...
+		case <-timer.C:
+			...
+		}
+	}
+
+In files for Go versions before 1.23, the fix stops and drains the
+timer before resetting it. The fix is offered only if the duration can
+be evaluated before the loop, and if the select statement has a single
+call of time.After.`,
+	URL:      "https://github.com/satorunooshie/go-tools/tree/main/golang.org/x/tools/custom/analyzer/notimeafter",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	for cur := range inspect.Root().Preorder((*ast.CallExpr)(nil)) {
+		call := cur.Node().(*ast.CallExpr)
+		if !isTimeAfter(pass.TypesInfo, call) {
+			continue
+		}
+		curSelect, curLoop, ok := enclosingSelectLoop(cur)
+		if !ok {
+			continue
+		}
+		diag := analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: "time.After in a loop allocates a timer at each iteration; use time.NewTimer and Reset",
+		}
+		if edits := fix(pass, cur, curSelect, curLoop); edits != nil {
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "Use time.NewTimer and Reset",
+				TextEdits: edits,
+			}}
+		}
+		pass.Report(diag)
+	}
+	return nil, nil
+}
+
+// isTimeAfter reports whether call is a call of time.After.
+func isTimeAfter(info *types.Info, call *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == "time" && fn.Name() == "After" && fn.Signature().Recv() == nil
+}
+
+// enclosingSelectLoop returns the cursors of the select statement
+// whose case communicates with the call at cur, and of the innermost
+// loop that encloses it, within the same function.
+func enclosingSelectLoop(cur inspector.Cursor) (curSelect, curLoop inspector.Cursor, ok bool) {
+	// The call must be the operand of a receive in the comm of a case:
+	//
+	//	case <-time.After(d):
+	//	case t := <-time.After(d):
+	comm, ok := commClause(cur)
+	if !ok {
+		return
+	}
+	curSelect = comm.Parent().Parent() // CommClause -> BlockStmt -> SelectStmt
+	for cur := range curSelect.Enclosing((*ast.ForStmt)(nil), (*ast.RangeStmt)(nil), (*ast.FuncLit)(nil), (*ast.FuncDecl)(nil)) {
+		switch cur.Node().(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			return curSelect, cur, true
+		}
+		break // function boundary
+	}
+	return curSelect, curLoop, false
+}
+
+// commClause returns the cursor of the comm clause whose receive
+// operation has the call at cur as operand.
+func commClause(cur inspector.Cursor) (inspector.Cursor, bool) {
+	recv, ok := cur.Parent().Node().(*ast.UnaryExpr)
+	if !ok || recv.Op != token.ARROW {
+		return cur, false
+	}
+	cur = cur.Parent().Parent()
+	switch n := cur.Node().(type) {
+	case *ast.ExprStmt:
+		// case <-time.After(d):
+	case *ast.AssignStmt:
+		// case t := <-time.After(d):
+		if len(n.Rhs) != 1 {
+			return cur, false
+		}
+	default:
+		return cur, false
+	}
+	comm, ok := cur.Parent().Node().(*ast.CommClause)
+	if !ok || comm.Comm != cur.Node() {
+		return cur, false
+	}
+	return cur.Parent(), true
+}
+
+// fix returns the edits that replace the call of time.After at cur by
+// the receive from a timer created before the loop, or nil if the
+// replacement is not possible.
+func fix(pass *analysis.Pass, cur, curSelect, curLoop inspector.Cursor) []analysis.TextEdit {
+	var (
+		info = pass.TypesInfo
+		call = cur.Node().(*ast.CallExpr)
+		sel  = curSelect.Node().(*ast.SelectStmt)
+		loop = curLoop.Node()
+	)
+
+	// The select statement must have a single call of time.After,
+	// as they would share the timer.
+	calls := 0
+	for cur := range curSelect.Preorder((*ast.CallExpr)(nil)) {
+		if isTimeAfter(info, cur.Node().(*ast.CallExpr)) {
+			calls++
+		}
+	}
+	if calls > 1 {
+		return nil
+	}
+
+	// The qualifier of time.After names the time package.
+	fun, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	pkg, ok := fun.X.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	// The duration is evaluated before the loop, so it must not have
+	// effects, nor refer to variables declared within the loop.
+	if len(call.Args) != 1 || !movable(info, call.Args[0], loop) {
+		return nil
+	}
+
+	// The timer is declared before the loop, or its label, and the
+	// timer is reset before the select statement, or its label. The
+	// statements are inserted at the start of the line, with the
+	// indentation of the loop or select statement.
+	loopStart, selectStart := loop.Pos(), sel.Pos()
+	if labeled, ok := curLoop.Parent().Node().(*ast.LabeledStmt); ok {
+		loopStart = labeled.Pos()
+	}
+	if labeled, ok := curSelect.Parent().Node().(*ast.LabeledStmt); ok {
+		selectStart = labeled.Pos()
+	}
+	loopLine, ok1 := lineStart(pass, loopStart)
+	selectLine, ok2 := lineStart(pass, selectStart)
+	if !ok1 || !ok2 {
+		return nil // not at the start of a line, or source not available
+	}
+	loopIndent, selectIndent := indent(pass, loop.Pos()), indent(pass, sel.Pos())
+
+	// The name of the timer must be free both before the loop and
+	// before the select statement.
+	name := "timer"
+	for i := 0; !isFree(pass.Pkg, name, loopStart) || !isFree(pass.Pkg, name, selectStart); i++ {
+		name = fmt.Sprintf("timer%d", i)
+	}
+
+	var (
+		d       = string(source(pass, call.Args[0]))
+		newDecl bytes.Buffer
+		reset   bytes.Buffer
+	)
+	fmt.Fprintf(&newDecl, "%s%s := %s.NewTimer(%s)\n", loopIndent, name, pkg.Name, d)
+	if !inLoop(curLoop) {
+		// A deferred call in a loop would run only at the end of
+		// the function: the outer loop recreates the timer anyway.
+		fmt.Fprintf(&newDecl, "%sdefer %s.Stop()\n", loopIndent, name)
+	}
+	if file := astutil.EnclosingFile(cur); file == nil || versions.AtLeast(versions.FileVersion(info, file), versions.Go1_23) {
+		// Since Go 1.23, Reset discards any pending value of the channel.
+		fmt.Fprintf(&reset, "%s%s.Reset(%s)\n", selectIndent, name, d)
+	} else {
+		// Before Go 1.23, a timer that fired but whose value was not
+		// received must be drained, or the next receive would get
+		// the stale value.
+		fmt.Fprintf(&reset, "%[2]sif !%[1]s.Stop() {\n%[2]s\tselect {\n%[2]s\tcase <-%[1]s.C:\n%[2]s\tdefault:\n%[2]s\t}\n%[2]s}\n", name, selectIndent)
+		fmt.Fprintf(&reset, "%s%s.Reset(%s)\n", selectIndent, name, d)
+	}
+
+	return []analysis.TextEdit{
+		{Pos: loopLine, End: loopLine, NewText: newDecl.Bytes()},
+		{Pos: selectLine, End: selectLine, NewText: reset.Bytes()},
+		{Pos: call.Pos(), End: call.End(), NewText: fmt.Appendf(nil, "%s.C", name)},
+	}
+}
+
+// movable reports whether the expression e, within loop, can also be
+// evaluated before the loop: it makes no calls other than conversions,
+// and refers to no object declared within the loop.
+func movable(info *types.Info, e ast.Expr, loop ast.Node) bool {
+	ok := true
+	ast.Inspect(e, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if tv, found := info.Types[n.Fun]; !found || !tv.IsType() {
+				ok = false // not a conversion
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.ARROW {
+				ok = false // receive
+			}
+		case *ast.FuncLit:
+			ok = false
+		case *ast.Ident:
+			if obj := info.Uses[n]; obj != nil && loop.Pos() <= obj.Pos() && obj.Pos() < loop.End() {
+				ok = false
+			}
+		}
+		return ok
+	})
+	return ok
+}
+
+// isFree reports whether name is not declared in the scope at pos.
+func isFree(pkg *types.Package, name string, pos token.Pos) bool {
+	scope := pkg.Scope().Innermost(pos)
+	if scope == nil {
+		return false
+	}
+	_, obj := scope.LookupParent(name, pos)
+	return obj == nil
+}
+
+// inLoop reports whether the loop at cur is itself within a loop of
+// the same function.
+func inLoop(cur inspector.Cursor) bool {
+	for cur := range cur.Parent().Enclosing((*ast.ForStmt)(nil), (*ast.RangeStmt)(nil), (*ast.FuncLit)(nil), (*ast.FuncDecl)(nil)) {
+		switch cur.Node().(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			return true
+		}
+		break // function boundary
+	}
+	return false
+}
+
+// lineStart returns the start of the line of pos, and reports whether
+// only white space precedes pos on the line.
+func lineStart(pass *analysis.Pass, pos token.Pos) (token.Pos, bool) {
+	tokFile := pass.Fset.File(pos)
+	src := readFile(pass, tokFile.Name())
+	if src == nil {
+		return token.NoPos, false
+	}
+	start := tokFile.LineStart(tokFile.Line(pos))
+	prefix := src[tokFile.Offset(start):tokFile.Offset(pos)]
+	return start, len(bytes.TrimLeft(prefix, " \t")) == 0
+}
+
+// indent returns the white space at the start of the line of pos.
+func indent(pass *analysis.Pass, pos token.Pos) string {
+	tokFile := pass.Fset.File(pos)
+	src := readFile(pass, tokFile.Name())
+	line := src[tokFile.Offset(tokFile.LineStart(tokFile.Line(pos))):]
+	return string(line[:len(line)-len(bytes.TrimLeft(line, " \t"))])
+}
+
+// source returns the source text of the node, or nil if the source is
+// not available.
+func source(pass *analysis.Pass, n ast.Node) []byte {
+	tokFile := pass.Fset.File(n.Pos())
+	src := readFile(pass, tokFile.Name())
+	if src == nil {
+		return nil
+	}
+	return src[tokFile.Offset(n.Pos()):tokFile.Offset(n.End())]
+}
+
+// readFile returns the content of the named file, or nil if it cannot
+// be read.
+func readFile(pass *analysis.Pass, filename string) []byte {
+	if pass.ReadFile == nil {
+		return nil
+	}
+	src, err := pass.ReadFile(filename)
+	if err != nil {
+		return nil
+	}
+	return src
+}
@@ -0,0 +1,12 @@
+package notimeafter_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/custom/analyzer/internal/testutil"
+	"golang.org/x/tools/custom/analyzer/notimeafter"
+)
+
+func Test(t *testing.T) {
+	testutil.Run(t, notimeafter.Analyzer, "a")
+}
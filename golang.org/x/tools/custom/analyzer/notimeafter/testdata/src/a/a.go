@@ -0,0 +1,92 @@
+package a
+
+import "time"
+
+func wait(ch <-chan int, d time.Duration) {
+	for {
+		select {
+		case <-ch:
+		case <-time.After(d): // want `time.After in a loop allocates a timer at each iteration`
+			return
+		}
+	}
+}
+
+func received(ch <-chan int) {
+	timer := 0
+	_ = timer
+loop:
+	for range 10 {
+		select {
+		case v := <-ch:
+			_ = v
+		case t := <-time.After(time.Duration(2) * time.Second): // want `time.After in a loop`
+			_ = t
+			break loop
+		}
+	}
+}
+
+func nested(chs [][]int, ch <-chan int) {
+	for range chs {
+		for {
+			select {
+			case <-ch:
+			case <-time.After(time.Second): // want `time.After in a loop`
+				return
+			}
+		}
+	}
+}
+
+func inLoop(ch <-chan int) {
+	for i := 0; i < 10; i++ {
+		d := time.Duration(i) * time.Second
+		select {
+		case <-ch:
+		case <-time.After(d): // want `time.After in a loop`
+		}
+	}
+}
+
+func two(ch <-chan int) {
+	for {
+		select {
+		case <-time.After(time.Second): // want `time.After in a loop`
+		case <-time.After(time.Minute): // want `time.After in a loop`
+		}
+	}
+}
+
+func call(ch <-chan int, f func() time.Duration) {
+	for {
+		select {
+		case <-ch:
+		case <-time.After(f()): // want `time.After in a loop`
+		}
+	}
+}
+
+func notLoop(ch <-chan int) {
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+	}
+}
+
+func funcLit(ch <-chan int) {
+	for {
+		go func() {
+			select {
+			case <-ch:
+			case <-time.After(time.Second):
+			}
+		}()
+	}
+}
+
+func sleep() {
+	for {
+		<-time.After(time.Second)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build go1.22
+
+package a
+
+import "time"
+
+func old(ch <-chan int) {
+	for {
+		select {
+		case <-ch:
+		case <-time.After(time.Second): // want `time.After in a loop`
+			return
+		}
+	}
+}
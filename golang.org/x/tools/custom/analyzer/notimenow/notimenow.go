@@ -0,0 +1,213 @@
+// Package notimenow defines an analyzer that reports calls of time.Now
+// and time.Since in packages that must get the time from an injected
+// clock, so that their tests can control it.
+package notimenow
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/refactor"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "notimenow",
+	Doc: `notimenow reports calls of time.Now and time.Since in clock-injected packages.
+
+The packages are selected by the -notimenow.packages flag, a
+comma-separated list of package paths, in which a path ending in
+"/..." also matches the packages below it. Such packages must get the
+current time from an injected clock, so that their tests can control
+it. Test files, and the Now methods that implement clocks, may call
+time.Now.
+
+If the package has exactly one package-level variable whose type has
+a method Now() time.Time, the suggested fix calls it instead, unless a
+local declaration shadows it, and deletes the import of time if the
+call was its only use.
+
+As gopls cannot set the flag, only the custom-lint and notimenow
+commands run the analyzer.`,
+	URL:      "https://github.com/satorunooshie/go-tools/tree/main/golang.org/x/tools/custom/analyzer/notimenow",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+var packages string // -packages flag
+
+func init() {
+	Analyzer.Flags.StringVar(&packages, "packages", "",
+		`comma-separated list of package paths that must use an injected clock; a path ending in "/..." also matches the packages below it`)
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if !matchPackage(packages, pass.Pkg.Path()) {
+		return nil, nil
+	}
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	clock := findClock(pass.Pkg)
+
+	for cur := range inspect.Root().Preorder((*ast.CallExpr)(nil)) {
+		call := cur.Node().(*ast.CallExpr)
+		if strings.HasSuffix(pass.Fset.File(call.Pos()).Name(), "_test.go") {
+			continue
+		}
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "time" || fn.Signature().Recv() != nil ||
+			fn.Name() != "Now" && fn.Name() != "Since" {
+			continue
+		}
+		if inClockMethod(pass.TypesInfo, cur) {
+			continue
+		}
+
+		diag := analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: "call of time." + fn.Name() + " in a package that must use an injected clock",
+		}
+		if clock != nil && resolves(pass.Pkg, clock, call.Pos()) {
+			// time.Now()      => clock.Now()
+			// time.Since(t)   => clock.Now().Sub(t)
+			newText := clock.Name() + ".Now"
+			end := call.Fun.End()
+			if fn.Name() == "Since" {
+				newText += "().Sub("
+				end = call.Lparen + 1
+			}
+			edits := []analysis.TextEdit{{Pos: call.Fun.Pos(), End: end, NewText: []byte(newText)}}
+			edits = append(edits, deleteImport(pass, cur, call)...)
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "Use " + clock.Name() + ".Now",
+				TextEdits: edits,
+			}}
+		}
+		pass.Report(diag)
+	}
+	return nil, nil
+}
+
+// matchPackage reports whether the package path matches one of the
+// comma-separated patterns.
+func matchPackage(patterns, path string) bool {
+	for pattern := range strings.SplitSeq(patterns, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+			if path == prefix || strings.HasPrefix(path, prefix+"/") {
+				return true
+			}
+		} else if pattern != "" && path == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// findClock returns the package-level variable of pkg whose type has
+// a method Now() time.Time, or nil if there is not exactly one.
+func findClock(pkg *types.Package) *types.Var {
+	var clock *types.Var
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		v, ok := scope.Lookup(name).(*types.Var)
+		if !ok {
+			continue
+		}
+		obj, _, _ := types.LookupFieldOrMethod(v.Type(), true, pkg, "Now")
+		if fn, ok := obj.(*types.Func); !ok || !isNowMethod(fn) {
+			continue
+		}
+		if clock != nil {
+			return nil // ambiguous
+		}
+		clock = v
+	}
+	return clock
+}
+
+// resolves reports whether the name of the clock variable denotes it
+// at pos, where a local declaration may shadow it.
+func resolves(pkg *types.Package, clock *types.Var, pos token.Pos) bool {
+	scope := pkg.Scope().Innermost(pos)
+	if scope == nil {
+		return false
+	}
+	_, obj := scope.LookupParent(clock.Name(), pos)
+	return obj == clock
+}
+
+// deleteImport returns the edits that delete the import of the time
+// package if the call, whose function the fix replaces, is its only
+// use in the file.
+func deleteImport(pass *analysis.Pass, cur inspector.Cursor, call *ast.CallExpr) []analysis.TextEdit {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil // dot import
+	}
+	id, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	pkgName, ok := pass.TypesInfo.Uses[id].(*types.PkgName)
+	if !ok {
+		return nil
+	}
+	curFile, ok := enclosingFile(cur)
+	if !ok {
+		return nil
+	}
+	for curId := range curFile.Preorder((*ast.Ident)(nil)) {
+		if id := curId.Node().(*ast.Ident); id != sel.X && pass.TypesInfo.Uses[id] == pkgName {
+			return nil // other uses
+		}
+	}
+	for curSpec := range curFile.Preorder((*ast.ImportSpec)(nil)) {
+		spec := curSpec.Node().(*ast.ImportSpec)
+		if importedName(pass.TypesInfo, spec) == pkgName {
+			return refactor.DeleteSpec(pass.Fset.File(spec.Pos()), curSpec)
+		}
+	}
+	return nil
+}
+
+// importedName returns the package name declared by the import spec.
+func importedName(info *types.Info, spec *ast.ImportSpec) types.Object {
+	if spec.Name != nil {
+		return info.Defs[spec.Name]
+	}
+	return info.Implicits[spec]
+}
+
+// enclosingFile returns the cursor of the file that encloses cur.
+func enclosingFile(cur inspector.Cursor) (inspector.Cursor, bool) {
+	for cur := range cur.Enclosing((*ast.File)(nil)) {
+		return cur, true
+	}
+	return cur, false
+}
+
+// inClockMethod reports whether the cursor is within a method
+// Now() time.Time, which implements a clock.
+func inClockMethod(info *types.Info, cur inspector.Cursor) bool {
+	for cur := range cur.Enclosing((*ast.FuncDecl)(nil)) {
+		fn, ok := info.Defs[cur.Node().(*ast.FuncDecl).Name].(*types.Func)
+		return ok && fn.Name() == "Now" && fn.Signature().Recv() != nil && isNowMethod(fn)
+	}
+	return false
+}
+
+// isNowMethod reports whether fn has the signature of time.Now.
+func isNowMethod(fn *types.Func) bool {
+	sig := fn.Signature()
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return false
+	}
+	named, ok := types.Unalias(sig.Results().At(0).Type()).(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time"
+}
@@ -0,0 +1,13 @@
+package notimenow_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/custom/analyzer/internal/testutil"
+	"golang.org/x/tools/custom/analyzer/notimenow"
+)
+
+func Test(t *testing.T) {
+	testutil.SetFlag(t, notimenow.Analyzer, "packages", "domain/...")
+	testutil.Run(t, notimenow.Analyzer, "domain", "domain/sub", "other")
+}
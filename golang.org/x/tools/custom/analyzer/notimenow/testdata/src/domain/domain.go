@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() } // ok: implements a clock
+
+var clock Clock = realClock{}
+
+func Expired(deadline time.Time) bool {
+	return time.Now().After(deadline) // want "call of time.Now in a package that must use an injected clock"
+}
+
+func Age(created time.Time) time.Duration {
+	return time.Since(created) // want "call of time.Since in a package that must use an injected clock"
+}
+
+func Until(t time.Time) time.Duration {
+	return time.Until(t) // ok: not reported
+}
@@ -0,0 +1,5 @@
+package domain
+
+import "time"
+
+var testStart = time.Now() // ok: test file
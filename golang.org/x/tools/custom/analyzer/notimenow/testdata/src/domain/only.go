@@ -0,0 +1,11 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// The fix removes the import of time, whose only use it replaces.
+func Stamp() string {
+	return fmt.Sprint(time.Now()) // want "call of time.Now in a package that must use an injected clock"
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// A local clock shadows the clock variable, so there is no fix.
+func Shadowed(clock string) bool {
+	_ = clock
+	return time.Now().IsZero() // want "call of time.Now in a package that must use an injected clock"
+}
+
+func NotShadowed() bool {
+	return time.Now().IsZero() // want "call of time.Now in a package that must use an injected clock"
+}
@@ -0,0 +1,6 @@
+package sub
+
+import "time"
+
+// There is no clock variable, so there is no fix.
+var start = time.Now() // want "call of time.Now in a package that must use an injected clock"
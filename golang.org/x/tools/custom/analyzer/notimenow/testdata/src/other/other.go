@@ -0,0 +1,5 @@
+package other
+
+import "time"
+
+var start = time.Now() // ok: not a clock-injected package
@@ -0,0 +1,151 @@
+// Package registry records the metadata of each custom analyzer, such
+// as its severity and the safety of its fixes, so that gopls and the
+// custom-lint command present the analyzers consistently.
+//
+// To add a custom analyzer, add an Entry to the entries list.
+// Documentation belongs in the analyzer itself: its Doc, and its URL,
+// which gopls links from its diagnostics. Its tests use the
+// custom/analyzer/internal/testutil package, which also checks its
+// suggested fixes against golden files.
+package registry
+
+import (
+	"fmt"
+
+	"golang.org/x/tools/custom/analyzer/nogoroutineleak"
+	"golang.org/x/tools/custom/analyzer/nosprintf"
+	"golang.org/x/tools/custom/analyzer/notimeafter"
+	"golang.org/x/tools/custom/analyzer/notimenow"
+	"golang.org/x/tools/go/analysis"
+)
+
+// entries lists the custom analyzers, in order of name.
+var entries = []*Entry{
+	{
+		Analyzer:   nogoroutineleak.Analyzer,
+		Severity:   SeverityWarning,
+		Fix:        NoFix,
+		NonDefault: true, // under evaluation: may report goroutines stopped by other means
+	},
+	{
+		Analyzer: nosprintf.Analyzer,
+		Severity: SeverityWarning,
+		Categories: map[string]Severity{
+			nosprintf.CategoryHigh: SeverityError,
+			nosprintf.CategoryLow:  SeverityInfo,
+		},
+		Fix: NoFix,
+	},
+	{
+		Analyzer: notimeafter.Analyzer,
+		Severity: SeverityWarning,
+		Fix:      ReviewFix,
+	},
+	{
+		Analyzer:    notimenow.Analyzer,
+		Severity:    SeverityWarning,
+		Fix:         ReviewFix,
+		CommandOnly: true, // does nothing without its -packages flag
+	},
+}
+
+// An Entry holds the metadata of a custom analyzer.
+type Entry struct {
+	Analyzer   *analysis.Analyzer
+	Severity   Severity            // severity of the analyzer's diagnostics
+	Categories map[string]Severity // severity of diagnostics by category in custom-lint, overriding Severity
+	Fix        FixSafety           // safety of the analyzer's suggested fixes
+
+	// CommandOnly reports that only custom-lint runs the analyzer:
+	// gopls cannot set the flags of custom analyzers, so an analyzer
+	// that needs them would do nothing there.
+	CommandOnly bool
+
+	// NonDefault reports that gopls runs the analyzer only if the
+	// "analyses" setting enables it. custom-lint runs it regardless.
+	NonDefault bool
+}
+
+// CategorySeverity returns the severity of the diagnostics of the
+// analyzer in the given category.
+func (e *Entry) CategorySeverity(category string) Severity {
+	if s, ok := e.Categories[category]; ok {
+		return s
+	}
+	return e.Severity
+}
+
+// Entries returns the entries of all custom analyzers, in order of
+// name. The caller must not modify them.
+func Entries() []*Entry { return entries }
+
+// Analyzers returns all custom analyzers, in order of name.
+func Analyzers() []*analysis.Analyzer {
+	analyzers := make([]*analysis.Analyzer, len(entries))
+	for i, e := range entries {
+		analyzers[i] = e.Analyzer
+	}
+	return analyzers
+}
+
+// Lookup returns the entry of the named analyzer, or nil if there is none.
+func Lookup(name string) *Entry {
+	for _, e := range entries {
+		if e.Analyzer.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// Severity is the severity of the diagnostics of an analyzer.
+type Severity int
+
+const (
+	SeverityHint Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityHint:
+		return "hint"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// FixSafety describes whether the suggested fixes of an analyzer may be
+// applied without review.
+type FixSafety int
+
+const (
+	// NoFix indicates that the analyzer does not suggest fixes.
+	NoFix FixSafety = iota
+	// ReviewFix indicates that the fixes may change the behavior of
+	// the program, and must be reviewed before they are applied.
+	ReviewFix
+	// SafeFix indicates that the fixes preserve the behavior of the
+	// program, so they may be applied automatically, as by the
+	// source.fixAll code action or custom-lint -fix.
+	SafeFix
+)
+
+func (s FixSafety) String() string {
+	switch s {
+	case NoFix:
+		return "none"
+	case ReviewFix:
+		return "review"
+	case SafeFix:
+		return "safe"
+	}
+	return fmt.Sprintf("FixSafety(%d)", int(s))
+}
@@ -0,0 +1,65 @@
+package registry_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/custom/analyzer/registry"
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestEntries(t *testing.T) {
+	if err := analysis.Validate(registry.Analyzers()); err != nil {
+		t.Fatal(err)
+	}
+	prev := ""
+	for _, e := range registry.Entries() {
+		name := e.Analyzer.Name
+		if name <= prev {
+			t.Errorf("entry %q is out of order (after %q)", name, prev)
+		}
+		prev = name
+		if e.Analyzer.URL == "" {
+			t.Errorf("analyzer %q has no documentation URL", name)
+		}
+		if got := registry.Lookup(name); got != e {
+			t.Errorf("Lookup(%q) = %v, want %v", name, got, e)
+		}
+	}
+	if got := registry.Lookup("nonesuch"); got != nil {
+		t.Errorf("Lookup(nonesuch) = %v, want nil", got)
+	}
+}
+
+func TestCategorySeverity(t *testing.T) {
+	e := &registry.Entry{
+		Severity:   registry.SeverityWarning,
+		Categories: map[string]registry.Severity{"minor": registry.SeverityHint},
+	}
+	if got, want := e.CategorySeverity("minor"), registry.SeverityHint; got != want {
+		t.Errorf("CategorySeverity(minor) = %v, want %v", got, want)
+	}
+	if got, want := e.CategorySeverity(""), registry.SeverityWarning; got != want {
+		t.Errorf("CategorySeverity(\"\") = %v, want %v", got, want)
+	}
+}
+
+func TestString(t *testing.T) {
+	for _, test := range []struct {
+		v    interface{ String() string }
+		want string
+	}{
+		{registry.SeverityHint, "hint"},
+		{registry.SeverityInfo, "info"},
+		{registry.SeverityWarning, "warning"},
+		{registry.SeverityError, "error"},
+		{registry.Severity(9), "Severity(9)"},
+		{registry.NoFix, "none"},
+		{registry.ReviewFix, "review"},
+		{registry.SafeFix, "safe"},
+		{registry.FixSafety(9), "FixSafety(9)"},
+	} {
+		if got := test.v.String(); got != test.want {
+			t.Errorf("String() = %q, want %q", got, test.want)
+		}
+	}
+}
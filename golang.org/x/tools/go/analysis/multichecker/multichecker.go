@@ -22,6 +22,14 @@ import (
 )
 
 func Main(analyzers ...*analysis.Analyzer) {
+	os.Exit(Run(analyzers...))
+}
+
+// Run is like Main, but returns the exit code instead of exiting, so
+// that the caller can complete its own work, such as writing a file
+// that summarizes the diagnostics, before it exits. When invoked by
+// 'go vet' on a single unit, it exits as Main does.
+func Run(analyzers ...*analysis.Analyzer) (exitcode int) {
 	progname := filepath.Base(os.Args[0])
 	log.SetFlags(0)
 	log.SetPrefix(progname + ": ") // e.g. "vet: "
@@ -43,12 +51,12 @@ Usage: %[1]s [-flag] [package]
 Run '%[1]s help' for more detail,
  or '%[1]s help name' for details and flags of a specific analyzer.
 `, progname)
-		os.Exit(1)
+		return 1
 	}
 
 	if args[0] == "help" {
 		analysisflags.Help(progname, analyzers, args[1:])
-		os.Exit(0)
+		return 0
 	}
 
 	if len(args) == 1 && strings.HasSuffix(args[0], ".cfg") {
@@ -56,5 +64,5 @@ Run '%[1]s help' for more detail,
 		panic("unreachable")
 	}
 
-	os.Exit(checker.Run(args, analyzers))
+	return checker.Run(args, analyzers)
 }
@@ -15,7 +15,9 @@ import (
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/edge"
 	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
 	"golang.org/x/tools/internal/astutil"
 	"golang.org/x/tools/internal/typeparams"
 	"golang.org/x/tools/internal/typesinternal"
@@ -26,7 +28,13 @@ const Doc = `check for locks erroneously passed by value
 
 Inadvertently copying a value containing a lock, such as sync.Mutex or
 sync.WaitGroup, may cause both copies to malfunction. Generally such
-values should be referred to through a pointer.`
+values should be referred to through a pointer.
+
+A method with a value receiver that locks a mutex of the receiver
+locks a copy of it on each call. Where it cannot break the package,
+such as for an unexported method whose callers all have addressable
+operands, the analyzer offers a fix that changes the receiver to a
+pointer.`
 
 var Analyzer = &analysis.Analyzer{
 	Name:             "copylocks",
@@ -62,9 +70,10 @@ func run(pass *analysis.Pass) (any, error) {
 		case *ast.RangeStmt:
 			checkCopyLocksRange(pass, node)
 		case *ast.FuncDecl:
-			checkCopyLocksFunc(pass, node.Name.Name, node.Recv, node.Type)
+			checkCopyLocksRecv(pass, inspect, node)
+			checkCopyLocksFunc(pass, node.Name.Name, node.Type)
 		case *ast.FuncLit:
-			checkCopyLocksFunc(pass, "func", nil, node.Type)
+			checkCopyLocksFunc(pass, "func", node.Type)
 		case *ast.CallExpr:
 			checkCopyLocksCallExpr(pass, node)
 		case *ast.AssignStmt:
@@ -172,18 +181,162 @@ func checkCopyLocksCallExpr(pass *analysis.Pass, ce *ast.CallExpr) {
 	}
 }
 
-// checkCopyLocksFunc checks whether a function might
-// inadvertently copy a lock, by checking whether
-// its receiver, parameters, or return values
-// are locks.
-func checkCopyLocksFunc(pass *analysis.Pass, name string, recv *ast.FieldList, typ *ast.FuncType) {
-	if recv != nil && len(recv.List) > 0 {
-		expr := recv.List[0].Type
-		if path := lockPath(pass.Pkg, pass.TypesInfo.Types[expr].Type, nil); path != nil {
-			pass.ReportRangef(expr, "%s passes lock by value: %v", name, path)
+// checkCopyLocksRecv checks whether a method might inadvertently
+// copy a lock, by checking whether its receiver is a lock.
+//
+// If the method locks a mutex of its receiver, so that the copy is a
+// bug rather than a risk, the diagnostic offers a fix that changes the
+// receiver to a pointer, if this cannot break the package.
+func checkCopyLocksRecv(pass *analysis.Pass, inspect *inspector.Inspector, decl *ast.FuncDecl) {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return
+	}
+	expr := decl.Recv.List[0].Type
+	path := lockPath(pass.Pkg, pass.TypesInfo.Types[expr].Type, nil)
+	if path == nil {
+		return
+	}
+	diag := analysis.Diagnostic{
+		Pos:     expr.Pos(),
+		End:     expr.End(),
+		Message: fmt.Sprintf("%s passes lock by value: %v", decl.Name.Name, path),
+	}
+	fn, _ := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if fn != nil && decl.Body != nil && len(decl.Recv.List[0].Names) > 0 {
+		if recv := fn.Signature().Recv(); recv.Name() != "_" {
+			if lock := lockOfVar(pass.TypesInfo, decl.Body, recv); lock != nil {
+				diag.Related = []analysis.RelatedInformation{{
+					Pos:     lock.Pos(),
+					End:     lock.End(),
+					Message: "lock of the copy",
+				}}
+				if curDecl, ok := inspect.Root().FindNode(decl); ok && canUsePointerReceiver(pass, curDecl, fn, recv) {
+					diag.SuggestedFixes = []analysis.SuggestedFix{{
+						Message: "Use a pointer receiver",
+						TextEdits: []analysis.TextEdit{{
+							Pos:     expr.Pos(),
+							End:     expr.Pos(),
+							NewText: []byte("*"),
+						}},
+					}}
+				}
+			}
+		}
+	}
+	pass.Report(diag)
+}
+
+// lockOfVar returns the first call in body that locks a mutex of the
+// variable v (not through a pointer), or nil if there is none.
+func lockOfVar(info *types.Info, body *ast.BlockStmt, v *types.Var) *ast.CallExpr {
+	var lock *ast.CallExpr
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || lock != nil {
+			return lock == nil
+		}
+		callee, ok := typeutil.Callee(info, call).(*types.Func)
+		if !ok || !(typesinternal.IsMethodNamed(callee, "sync", "Mutex", "Lock", "TryLock") ||
+			typesinternal.IsMethodNamed(callee, "sync", "RWMutex", "Lock", "TryLock", "RLock", "TryRLock")) {
+			return true
+		}
+		if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok && selectsFromVar(info, sel, v) {
+			lock = call
+		}
+		return lock == nil
+	})
+	return lock
+}
+
+// selectsFromVar reports whether sel selects, from v, a method or a
+// field, through fields and array elements but no pointer indirection,
+// as in v.mu.Lock or v.locks[i].Lock.
+func selectsFromVar(info *types.Info, sel *ast.SelectorExpr, v *types.Var) bool {
+	if s, ok := info.Selections[sel]; !ok || s.Indirect() {
+		return false
+	}
+	for e := ast.Unparen(sel.X); ; {
+		switch x := e.(type) {
+		case *ast.Ident:
+			return info.Uses[x] == v
+		case *ast.SelectorExpr:
+			s, ok := info.Selections[x]
+			if !ok || s.Kind() != types.FieldVal || s.Indirect() {
+				return false
+			}
+			e = ast.Unparen(x.X)
+		case *ast.IndexExpr:
+			if _, ok := typeparams.CoreType(info.TypeOf(x.X)).(*types.Array); !ok {
+				return false // slice or map elements are not copied
+			}
+			e = ast.Unparen(x.X)
+		default:
+			return false
+		}
+	}
+}
+
+// canUsePointerReceiver reports whether changing the value receiver
+// recv of method fn, declared by the FuncDecl at curDecl, to a pointer
+// cannot break the package.
+func canUsePointerReceiver(pass *analysis.Pass, curDecl inspector.Cursor, fn *types.Func, recv *types.Var) bool {
+	info := pass.TypesInfo
+
+	// Other packages may call an exported method, or use it to
+	// satisfy an interface, with a value that is not addressable.
+	if fn.Exported() {
+		return false
+	}
+
+	// The body must use the receiver only as the operand of a selector,
+	// whose meaning does not change if it becomes a pointer.
+	for curId := range curDecl.Preorder((*ast.Ident)(nil)) {
+		id := curId.Node().(*ast.Ident)
+		if info.Uses[id] != recv {
+			continue
+		}
+		cur := curId
+		for cur.ParentEdgeKind() == edge.ParenExpr_X {
+			cur = cur.Parent()
+		}
+		if cur.ParentEdgeKind() != edge.SelectorExpr_X {
+			return false
+		}
+	}
+
+	// A method call or method value must have an addressable or
+	// pointer operand, and a method expression T.m would change type.
+	for sel, selection := range info.Selections {
+		if obj, ok := selection.Obj().(*types.Func); !ok || obj.Origin() != fn {
+			continue
+		}
+		if selection.Kind() != types.MethodVal {
+			return false
+		}
+		if _, ok := types.Unalias(selection.Recv()).(*types.Pointer); !ok && !info.Types[sel.X].Addressable() {
+			return false
+		}
+	}
+
+	// The value type must not be used to satisfy an interface of
+	// the package that declares the method.
+	for _, tv := range info.Types {
+		if tv.Type == nil {
+			continue
+		}
+		if iface, ok := tv.Type.Underlying().(*types.Interface); ok {
+			if obj, _, _ := types.LookupFieldOrMethod(iface, false, fn.Pkg(), fn.Name()); obj != nil {
+				return false
+			}
 		}
 	}
+	return true
+}
 
+// checkCopyLocksFunc checks whether a function might
+// inadvertently copy a lock, by checking whether
+// its parameters or return values are locks.
+func checkCopyLocksFunc(pass *analysis.Pass, name string, typ *ast.FuncType) {
 	if typ.Params != nil {
 		for _, field := range typ.Params.List {
 			expr := field.Type
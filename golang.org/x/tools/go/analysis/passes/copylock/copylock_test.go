@@ -27,3 +27,7 @@ func TestVersions21(t *testing.T) {
 	dir := testfiles.ExtractTxtarFileToTmp(t, filepath.Join(analysistest.TestData(), "src", "forstmt", "go21.txtar"))
 	analysistest.Run(t, dir, copylock.Analyzer, "golang.org/fake/forstmt")
 }
+
+func TestPointerReceiverFix(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), copylock.Analyzer, "recv")
+}
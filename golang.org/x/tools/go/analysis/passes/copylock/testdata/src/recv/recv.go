@@ -0,0 +1,117 @@
+// Test of the fix that changes a locked value receiver to a pointer.
+
+package recv
+
+import "sync"
+
+type counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (c counter) get() int { // want `get passes lock by value: recv.counter`
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
+
+func (c *counter) inc() { // ok: pointer receiver
+	c.mu.Lock()
+	c.n++
+	c.mu.Unlock()
+}
+
+// No fix for a method that does not lock its receiver.
+func (c counter) size() int { // want `size passes lock by value: recv.counter contains sync.Mutex`
+	return c.n
+}
+
+func _() {
+	var c counter
+	_ = c.get()
+	f := c.get
+	_ = f
+}
+
+// The lock may be embedded, or nested in fields and arrays.
+
+type cache struct {
+	sync.RWMutex
+	shards [2]struct {
+		counter
+	}
+}
+
+func (c cache) lookup() { // want `lookup passes lock by value: recv.cache`
+	c.RLock()
+	defer c.RUnlock()
+}
+
+func (c cache) shard() { // want `shard passes lock by value: recv.cache`
+	c.shards[0].mu.Lock()
+}
+
+// Locks reached through a pointer are not copied.
+
+type handle struct {
+	mu    *sync.Mutex
+	inner *counter
+	all   []sync.Mutex
+}
+
+func (h handle) lock() { // ok: the locks are not copied
+	h.mu.Lock()
+	h.inner.mu.Lock()
+	h.all[0].Lock()
+}
+
+// No fix for an exported method.
+
+type Stats struct {
+	mu sync.Mutex
+}
+
+func (s Stats) Snapshot() { // want `Snapshot passes lock by value: recv.Stats`
+	s.mu.Lock()
+}
+
+// No fix if the receiver is used other than in a selector.
+
+type value struct {
+	mu sync.Mutex
+}
+
+func (v value) copy() value { // want `copy passes lock by value: recv.value`
+	v.mu.Lock()
+	return v // want `return copies lock value`
+}
+
+// No fix if a call has a non-addressable operand.
+
+type item struct {
+	mu sync.Mutex
+}
+
+func (i item) touch() { // want `touch passes lock by value: recv.item`
+	i.mu.Lock()
+}
+
+func newItem() item { return item{} }
+
+func _() {
+	newItem().touch()
+}
+
+// No fix if an interface declares the method.
+
+type toucher interface{ poke() }
+
+type node struct {
+	mu sync.Mutex
+}
+
+func (n node) poke() { // want `poke passes lock by value: recv.node`
+	n.mu.Lock()
+}
+
+var _ toucher = node{}
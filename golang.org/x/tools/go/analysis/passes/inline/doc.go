@@ -60,6 +60,11 @@ parameter variables. Since the resulting code transformation may be
 stylistically suboptimal, such inlinings may be disabled by specifying
 the -inline.allow_binding_decl=false flag to the analyzer driver.
 
+Inlining a large function at many call sites bloats the callers.
+The -inline.max_lines=N flag limits the suggested fixes to functions
+whose declaration has at most N lines; calls of larger functions are
+still reported, but without a fix.
+
 (In cases where it is not safe to "reduce" a call—that is, to replace
 a call f(x) by the body of function f, suitably substituted—the
 inliner machinery is capable of replacing f by a function literal,
@@ -0,0 +1,126 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline
+
+import (
+	"bytes"
+	"encoding/gob"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/internal/refactor/inline"
+)
+
+var updateFacts = flag.Bool("update-facts", false, "write the facts of the current version to testdata/facts")
+
+// TestFactCompatibility checks that the facts encoded by each version
+// of this analyzer, saved in testdata/facts/v*.gob, can be decoded by
+// the current version. Drivers encode facts as interface values, so
+// the names of the fact types are part of the encoding too.
+//
+// When incrementing factVersion, run this test with -update-facts to
+// save the facts of the new version, and keep the files of earlier
+// versions.
+func TestFactCompatibility(t *testing.T) {
+	gob.Register(new(goFixInlineFuncFact))
+	gob.Register(new(goFixInlineConstFact))
+	gob.Register(new(goFixInlineAliasFact))
+
+	current := filepath.Join("testdata", "facts", fmt.Sprintf("v%d.gob", factVersion))
+	if *updateFacts {
+		facts := []analysis.Fact{
+			&goFixInlineFuncFact{Version: factVersion, Callee: testCallee(t)},
+			&goFixInlineConstFact{Version: factVersion, RHSName: "Pi", RHSPkgPath: "example.com/math", RHSPkgName: "math"},
+			&goFixInlineAliasFact{Version: factVersion},
+		}
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(facts); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(current, buf.Bytes(), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join("testdata", "facts", "v*.gob"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != factVersion+1 {
+		t.Errorf("got %d fact files, want one for each version 0..%d (missing %s? run with -update-facts)", len(files), factVersion, current)
+	}
+	for version := range factVersion + 1 {
+		t.Run(fmt.Sprint(version), func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "facts", fmt.Sprintf("v%d.gob", version)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var facts []analysis.Fact
+			if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&facts); err != nil {
+				t.Fatalf("decoding facts: %v", err)
+			}
+			if len(facts) != 3 {
+				t.Fatalf("got %d facts, want 3", len(facts))
+			}
+
+			fn, ok := facts[0].(*goFixInlineFuncFact)
+			if !ok || fn.Version != version || fn.Callee == nil || fn.Callee.String() != "p.Greet" {
+				t.Errorf("func fact: got %#v, want version %d of callee p.Greet", facts[0], version)
+			}
+			con, ok := facts[1].(*goFixInlineConstFact)
+			if !ok || con.Version != version || con.RHSName != "Pi" || con.RHSPkgPath != "example.com/math" || con.RHSPkgName != "math" {
+				t.Errorf("const fact: got %#v, want version %d of example.com/math.Pi", facts[1], version)
+			}
+			alias, ok := facts[2].(*goFixInlineAliasFact)
+			if !ok || alias.Version != version {
+				t.Errorf("alias fact: got %#v, want version %d", facts[2], version)
+			}
+		})
+	}
+}
+
+// testCallee returns the inline.Callee for a small function.
+func testCallee(t *testing.T) *inline.Callee {
+	const src = `package p
+
+import "fmt"
+
+// Greet returns a greeting.
+func Greet(name string) string { return fmt.Sprintf("hello, %s", name) }
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{
+		Defs:         make(map[*ast.Ident]types.Object),
+		Uses:         make(map[*ast.Ident]types.Object),
+		Types:        make(map[ast.Expr]types.TypeAndValue),
+		Implicits:    make(map[ast.Node]types.Object),
+		Selections:   make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:       make(map[ast.Node]*types.Scope),
+		FileVersions: make(map[*ast.File]string),
+	}
+	conf := types.Config{Importer: importer.Default()}
+	pkg, err := conf.Check("example.com/p", fset, []*ast.File{f}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	callee, err := inline.AnalyzeCallee(discard, fset, pkg, info, f.Decls[1].(*ast.FuncDecl), []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return callee
+}
@@ -52,11 +52,14 @@ var Analyzer = &analysis.Analyzer{
 var (
 	allowBindingDecl bool
 	lazyEdits        bool
+	maxLines         int
 )
 
 func init() {
 	Analyzer.Flags.BoolVar(&allowBindingDecl, "allow_binding_decl", false,
 		"permit inlinings that require a 'var params = args' declaration")
+	Analyzer.Flags.IntVar(&maxLines, "max_lines", 0,
+		"if positive, suggest no fix for calls of functions whose declaration has more lines")
 	Analyzer.Flags.BoolVar(&lazyEdits, "lazy_edits", false,
 		"compute edits lazily (only meaningful to gopls driver)")
 }
@@ -102,14 +105,14 @@ func (a *analyzer) HandleFunc(decl *ast.FuncDecl) {
 		return
 	}
 	fn := a.pass.TypesInfo.Defs[decl.Name].(*types.Func)
-	a.pass.ExportObjectFact(fn, &goFixInlineFuncFact{callee})
+	a.pass.ExportObjectFact(fn, &goFixInlineFuncFact{Version: factVersion, Callee: callee})
 	a.inlinableFuncs[fn] = callee
 }
 
 // HandleAlias exports a fact for aliases marked with go:fix.
 func (a *analyzer) HandleAlias(spec *ast.TypeSpec) {
 	// Remember that this is an inlinable alias.
-	typ := &goFixInlineAliasFact{}
+	typ := &goFixInlineAliasFact{Version: factVersion}
 	lhs := a.pass.TypesInfo.Defs[spec.Name].(*types.TypeName)
 	a.inlinableAliases[lhs] = typ
 	// Create a fact only if the LHS is exported and defined at top level.
@@ -125,6 +128,7 @@ func (a *analyzer) HandleConst(nameIdent, rhsIdent *ast.Ident) {
 	lhs := a.pass.TypesInfo.Defs[nameIdent].(*types.Const)
 	rhs := a.pass.TypesInfo.Uses[rhsIdent].(*types.Const) // must be so in a well-typed program
 	con := &goFixInlineConstFact{
+		Version:    factVersion,
 		RHSName:    rhs.Name(),
 		RHSPkgName: rhs.Pkg().Name(),
 		RHSPkgPath: rhs.Pkg().Path(),
@@ -167,7 +171,7 @@ func (a *analyzer) inlineCall(call *ast.CallExpr, cur inspector.Cursor) {
 		callee, ok := a.inlinableFuncs[fn]
 		if !ok {
 			var fact goFixInlineFuncFact
-			if a.pass.ImportObjectFact(fn, &fact) {
+			if a.pass.ImportObjectFact(fn, &fact) && fact.Version <= factVersion {
 				callee = fact.Callee
 				a.inlinableFuncs[fn] = callee
 			}
@@ -180,6 +184,17 @@ func (a *analyzer) inlineCall(call *ast.CallExpr, cur inspector.Cursor) {
 			return // don't inline a function from within its own test
 		}
 
+		if maxLines > 0 && callee.Lines() > maxLines {
+			// Inlining a large function bloats the caller:
+			// report the call, but suggest no fix.
+			a.pass.Report(analysis.Diagnostic{
+				Pos:     call.Pos(),
+				End:     call.End(),
+				Message: fmt.Sprintf("Call of %v should be inlined (not fixed automatically: %d lines exceed -max_lines=%d)", callee, callee.Lines(), maxLines),
+			})
+			return
+		}
+
 		// Compute the edits.
 		//
 		// Ordinarily the analyzer reports a fix containing
@@ -305,7 +320,7 @@ func (a *analyzer) inlineAlias(tn *types.TypeName, curId inspector.Cursor) {
 	inalias, ok := a.inlinableAliases[tn]
 	if !ok {
 		var fact goFixInlineAliasFact
-		if a.pass.ImportObjectFact(tn, &fact) {
+		if a.pass.ImportObjectFact(tn, &fact) && fact.Version <= factVersion {
 			inalias = &fact
 			a.inlinableAliases[tn] = inalias
 		}
@@ -513,7 +528,7 @@ func (a *analyzer) inlineConst(con *types.Const, cur inspector.Cursor) {
 	incon, ok := a.inlinableConsts[con]
 	if !ok {
 		var fact goFixInlineConstFact
-		if a.pass.ImportObjectFact(con, &fact) {
+		if a.pass.ImportObjectFact(con, &fact) && fact.Version <= factVersion {
 			incon = &fact
 			a.inlinableConsts[con] = incon
 		}
@@ -605,9 +620,29 @@ func (a *analyzer) readFile(node ast.Node) ([]byte, error) {
 	return content, nil
 }
 
+// factVersion is the version of the encoding of the goFixInline*Fact
+// types below, which is recorded in each fact.
+//
+// Facts may be produced and consumed by different versions of this
+// analyzer, for example when gopls and a command-line driver share a
+// cache. Gob matches struct fields by name, so adding a field is
+// compatible, and facts of an older version are decoded as usual. But
+// a change that alters the meaning of an existing field, or of the
+// encoding of [inline.Callee], must increment factVersion so that
+// older analyzers ignore facts they cannot interpret.
+// TestFactCompatibility checks that facts encoded by previous versions
+// can still be decoded.
+//
+// Version 0 is the original encoding, which predates the Version
+// field: gob omits zero fields, so it is unchanged by it.
+const factVersion = 0
+
 // A goFixInlineFuncFact is exported for each function marked "//go:fix inline".
 // It holds information about the callee to support inlining.
-type goFixInlineFuncFact struct{ Callee *inline.Callee }
+type goFixInlineFuncFact struct {
+	Version int // see factVersion
+	Callee  *inline.Callee
+}
 
 func (f *goFixInlineFuncFact) String() string { return "goFixInline " + f.Callee.String() }
 func (*goFixInlineFuncFact) AFact()           {}
@@ -615,6 +650,8 @@ func (*goFixInlineFuncFact) AFact()           {}
 // A goFixInlineConstFact is exported for each constant marked "//go:fix inline".
 // It holds information about an inlinable constant. Gob-serializable.
 type goFixInlineConstFact struct {
+	Version int // see factVersion
+
 	// Information about "const LHSName = RHSName".
 	RHSName    string
 	RHSPkgPath string
@@ -629,8 +666,11 @@ func (c *goFixInlineConstFact) String() string {
 func (*goFixInlineConstFact) AFact() {}
 
 // A goFixInlineAliasFact is exported for each type alias marked "//go:fix inline".
-// It holds no information; its mere existence demonstrates that an alias is inlinable.
-type goFixInlineAliasFact struct{}
+// It holds no information other than its version; its mere existence
+// demonstrates that an alias is inlinable.
+type goFixInlineAliasFact struct {
+	Version int // see factVersion
+}
 
 func (c *goFixInlineAliasFact) String() string { return "goFixInline alias" }
 func (*goFixInlineAliasFact) AFact()           {}
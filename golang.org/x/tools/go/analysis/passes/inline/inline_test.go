@@ -81,6 +81,13 @@ func TestAllowBindingDeclFlag(t *testing.T) {
 	run(false) // testdata/src/binding_false
 }
 
+func TestMaxLinesFlag(t *testing.T) {
+	saved := maxLines
+	defer func() { maxLines = saved }()
+	maxLines = 3
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "maxlines")
+}
+
 func TestTypesWithNames(t *testing.T) {
 	// Test setup inspired by internal/analysis/addimport_test.go.
 	testenv.NeedsDefaultImporter(t)
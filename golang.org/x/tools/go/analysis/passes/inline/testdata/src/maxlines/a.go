@@ -0,0 +1,15 @@
+package a
+
+//go:fix inline
+func small(x int) int { return x + 1 } // want small:`goFixInline a.small`
+
+//go:fix inline
+func large(x int) int { // want large:`goFixInline a.large`
+	y := x * 2
+	return y + 1
+}
+
+func _() {
+	_ = small(1) // want `Call of a.small should be inlined`
+	_ = large(1) // want `Call of a.large should be inlined \(not fixed automatically: 4 lines exceed -max_lines=3\)`
+}
@@ -36,6 +36,11 @@
 //	    }()
 //	}
 //
+// The analyzer suggests this fix, unless the loop body may update the
+// variable (for example by assigning to one of its fields, or by calling
+// a method with a pointer receiver), in which case a copy would change
+// the behavior of the loop.
+//
 // After Go version 1.22, the previous two for loops are equivalent
 // and both are correct.
 //
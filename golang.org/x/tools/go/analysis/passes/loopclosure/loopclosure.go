@@ -5,9 +5,13 @@
 package loopclosure
 
 import (
+	"bytes"
 	_ "embed"
+	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
+	"slices"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -103,7 +107,7 @@ func run(pass *analysis.Pass) (any, error) {
 				}
 			}
 			for _, stmt := range stmts {
-				reportCaptured(pass, vars, stmt)
+				reportCaptured(pass, vars, body, stmt)
 			}
 		})
 
@@ -118,7 +122,7 @@ func run(pass *analysis.Pass) (any, error) {
 			case *ast.ExprStmt:
 				if call, ok := s.X.(*ast.CallExpr); ok {
 					for _, stmt := range parallelSubtest(pass.TypesInfo, call) {
-						reportCaptured(pass, vars, stmt)
+						reportCaptured(pass, vars, body, stmt)
 					}
 
 				}
@@ -132,8 +136,9 @@ func run(pass *analysis.Pass) (any, error) {
 // reportCaptured reports a diagnostic stating a loop variable
 // has been captured by a func literal if checkStmt has escaping
 // references to vars. vars is expected to be variables updated by a loop statement,
+// body is the body of the loop,
 // and checkStmt is expected to be a statements from the body of a func literal in the loop.
-func reportCaptured(pass *analysis.Pass, vars []types.Object, checkStmt ast.Stmt) {
+func reportCaptured(pass *analysis.Pass, vars []types.Object, body *ast.BlockStmt, checkStmt ast.Stmt) {
 	ast.Inspect(checkStmt, func(n ast.Node) bool {
 		id, ok := n.(*ast.Ident)
 		if !ok {
@@ -145,13 +150,84 @@ func reportCaptured(pass *analysis.Pass, vars []types.Object, checkStmt ast.Stmt
 		}
 		for _, v := range vars {
 			if v == obj {
-				pass.ReportRangef(id, "loop variable %s captured by func literal", id.Name)
+				pass.Report(analysis.Diagnostic{
+					Pos:            id.Pos(),
+					End:            id.End(),
+					Message:        fmt.Sprintf("loop variable %s captured by func literal", id.Name),
+					SuggestedFixes: copyVarFix(pass, v, body),
+				})
 			}
 		}
 		return true
 	})
 }
 
+// copyVarFix returns a fix that declares a copy of the loop variable v
+// at the start of the loop body, so that each iteration has its own
+// variable, as in Go 1.22. It returns nil if the loop body may update
+// v, as the copy would change the behavior of the loop.
+func copyVarFix(pass *analysis.Pass, v types.Object, body *ast.BlockStmt) []analysis.SuggestedFix {
+	// isVar reports whether e denotes v, or a part of it
+	// (a field or array element) that is stored within it.
+	var isVar func(e ast.Expr) bool
+	isVar = func(e ast.Expr) bool {
+		switch e := ast.Unparen(e).(type) {
+		case *ast.Ident:
+			return pass.TypesInfo.ObjectOf(e) == v
+		case *ast.SelectorExpr:
+			sel, ok := pass.TypesInfo.Selections[e]
+			return ok && sel.Kind() == types.FieldVal && !sel.Indirect() && isVar(e.X)
+		case *ast.IndexExpr:
+			_, ok := pass.TypesInfo.TypeOf(e.X).Underlying().(*types.Array)
+			return ok && isVar(e.X)
+		}
+		return false
+	}
+	updated := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE {
+				updated = updated || slices.ContainsFunc(n.Lhs, isVar)
+			}
+		case *ast.IncDecStmt:
+			updated = updated || isVar(n.X)
+		case *ast.UnaryExpr:
+			updated = updated || n.Op == token.AND && isVar(n.X)
+		case *ast.SelectorExpr:
+			// A method with a pointer receiver, called on
+			// (a part of) v, may update it through &v.
+			if sel, ok := pass.TypesInfo.Selections[n]; ok && sel.Kind() == types.MethodVal && !sel.Indirect() {
+				if _, ok := sel.Obj().Type().(*types.Signature).Recv().Type().Underlying().(*types.Pointer); ok {
+					updated = updated || isVar(n.X)
+				}
+			}
+		}
+		return !updated
+	})
+	if updated {
+		return nil
+	}
+
+	// Insert the declaration before the first statement,
+	// using the same indentation.
+	first := body.List[0].Pos()
+	indent := ""
+	if content, err := pass.ReadFile(pass.Fset.File(first).Name()); err == nil {
+		offset := pass.Fset.Position(first).Offset
+		lineStart := bytes.LastIndexByte(content[:offset], '\n') + 1
+		indent = string(content[lineStart:offset])
+	}
+	return []analysis.SuggestedFix{{
+		Message: fmt.Sprintf("Declare a per-iteration copy of %s", v.Name()),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     first,
+			End:     first,
+			NewText: fmt.Appendf(nil, "%s := %s\n%s", v.Name(), v.Name(), indent),
+		}},
+	}}
+}
+
 // forEachLastStmt calls onLast on each "last" statement in a list of statements.
 // "Last" is defined recursively so, for example, if the last statement is
 // a switch statement, then each switch case is also visited to examine
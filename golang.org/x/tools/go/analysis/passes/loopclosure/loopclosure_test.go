@@ -17,3 +17,8 @@ func TestVersions(t *testing.T) {
 	dir := testfiles.ExtractTxtarFileToTmp(t, filepath.Join(analysistest.TestData(), "src", "versions", "go22.txtar"))
 	analysistest.Run(t, dir, loopclosure.Analyzer, "golang.org/fake/versions")
 }
+
+func TestFix(t *testing.T) {
+	dir := testfiles.ExtractTxtarFileToTmp(t, filepath.Join(analysistest.TestData(), "src", "versions", "fix.txtar"))
+	analysistest.RunWithSuggestedFixes(t, dir, loopclosure.Analyzer, "golang.org/fake/fix")
+}
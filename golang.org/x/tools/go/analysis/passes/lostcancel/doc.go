@@ -13,4 +13,15 @@
 // WithDeadline and variants such as WithCancelCause must be called,
 // or the new context will remain live until its parent context is cancelled.
 // (The background context is never cancelled.)
+//
+// In a test file, within a function that has a *testing.T, *testing.B,
+// *testing.F, or testing.TB parameter, the analyzer offers a fix that
+// registers the cancel function with the Cleanup method of the test,
+// naming it first if it was discarded:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	t.Cleanup(cancel)
+//
+// Unlike "defer cancel()", this is correct even when the context
+// outlives the function, as in a test helper that returns it.
 package lostcancel
@@ -5,10 +5,13 @@
 package lostcancel
 
 import (
+	"bytes"
 	_ "embed"
 	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/ctrlflow"
@@ -16,6 +19,7 @@ import (
 	"golang.org/x/tools/go/ast/inspector"
 	"golang.org/x/tools/go/cfg"
 	"golang.org/x/tools/internal/analysis/analyzerutil"
+	"golang.org/x/tools/internal/refactor"
 	"golang.org/x/tools/internal/typesinternal"
 )
 
@@ -55,17 +59,15 @@ func run(pass *analysis.Pass) (any, error) {
 
 	// Call runFunc for each Func{Decl,Lit}.
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
-	nodeTypes := []ast.Node{
-		(*ast.FuncLit)(nil),
-		(*ast.FuncDecl)(nil),
+	for curFunc := range inspect.Root().Preorder((*ast.FuncLit)(nil), (*ast.FuncDecl)(nil)) {
+		runFunc(pass, curFunc)
 	}
-	inspect.Preorder(nodeTypes, func(n ast.Node) {
-		runFunc(pass, n)
-	})
 	return nil, nil
 }
 
-func runFunc(pass *analysis.Pass, node ast.Node) {
+func runFunc(pass *analysis.Pass, curFunc inspector.Cursor) {
+	node := curFunc.Node()
+
 	// Find scope of function node
 	var funcScope *types.Scope
 	switch v := node.(type) {
@@ -111,9 +113,12 @@ func runFunc(pass *analysis.Pass, node ast.Node) {
 		}
 		if id != nil {
 			if id.Name == "_" {
-				pass.ReportRangef(id,
-					"the cancel function returned by context.%s should be called, not discarded, to avoid a context leak",
-					n.(*ast.SelectorExpr).Sel.Name)
+				pass.Report(analysis.Diagnostic{
+					Pos:            id.Pos(),
+					End:            id.End(),
+					Message:        fmt.Sprintf("the cancel function returned by context.%s should be called, not discarded, to avoid a context leak", n.(*ast.SelectorExpr).Sel.Name),
+					SuggestedFixes: cleanupFix(pass, curFunc, stmt, id),
+				})
 			} else if v, ok := pass.TypesInfo.Uses[id].(*types.Var); ok {
 				// If the cancel variable is defined outside function scope,
 				// do not analyze it.
@@ -164,7 +169,19 @@ func runFunc(pass *analysis.Pass, node ast.Node) {
 	for v, stmt := range cancelvars {
 		if ret := lostCancelPath(pass, g, v, stmt, sig); ret != nil {
 			lineno := pass.Fset.Position(stmt.Pos()).Line
-			pass.ReportRangef(stmt, "the %s function is not used on all paths (possible context leak)", v.Name())
+			var id *ast.Ident // id of cancel var
+			switch stmt := stmt.(type) {
+			case *ast.ValueSpec:
+				id = stmt.Names[1]
+			case *ast.AssignStmt:
+				id = stmt.Lhs[1].(*ast.Ident)
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos:            stmt.Pos(),
+				End:            stmt.End(),
+				Message:        fmt.Sprintf("the %s function is not used on all paths (possible context leak)", v.Name()),
+				SuggestedFixes: cleanupFix(pass, curFunc, stmt, id),
+			})
 
 			pos, end := ret.Pos(), ret.End()
 			// golang/go#64547: cfg.Block.Return may return a synthetic
@@ -181,6 +198,132 @@ func runFunc(pass *analysis.Pass, node ast.Node) {
 	}
 }
 
+// cleanupFix returns a fix that registers the cancel function, which
+// stmt (a statement of the function at curFunc) assigns to id, with the
+// Cleanup method of the enclosing test, naming it if id is blank.
+// It returns nil outside a test file or a function with a
+// *testing.T, *testing.B, *testing.F, or testing.TB parameter, or if
+// the statement does not permit it.
+//
+// Unlike "defer cancel()", the fix is correct even when the context
+// outlives the function, as in a test helper that returns it.
+func cleanupFix(pass *analysis.Pass, curFunc inspector.Cursor, stmt ast.Node, id *ast.Ident) []analysis.SuggestedFix {
+	tokFile := pass.Fset.File(stmt.Pos())
+	if !strings.HasSuffix(tokFile.Name(), "_test.go") {
+		return nil
+	}
+
+	// Only a short variable declaration or a var declaration may
+	// name a blank cancel function, and only a statement of a block
+	// may be followed by another.
+	curStmt, ok := curFunc.FindNode(stmt)
+	if !ok {
+		return nil
+	}
+	switch stmt := stmt.(type) {
+	case *ast.ValueSpec:
+		curStmt = curStmt.Parent().Parent() // GenDecl, DeclStmt
+		if _, ok := curStmt.Node().(*ast.DeclStmt); !ok {
+			return nil
+		}
+	case *ast.AssignStmt:
+		if id.Name == "_" && stmt.Tok != token.DEFINE {
+			return nil
+		}
+	}
+	switch curStmt.Parent().Node().(type) {
+	case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+	default:
+		return nil
+	}
+
+	// Find the test parameter of the innermost enclosing function
+	// that has one.
+	info := pass.TypesInfo
+	var tparam *types.Var
+outer:
+	for curFunc := range curFunc.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+		var ftype *ast.FuncType
+		switch n := curFunc.Node().(type) {
+		case *ast.FuncDecl:
+			ftype = n.Type
+		case *ast.FuncLit:
+			ftype = n.Type
+		}
+		for _, field := range ftype.Params.List {
+			for _, name := range field.Names {
+				if v, ok := info.Defs[name].(*types.Var); ok && isTest(v.Type()) {
+					tparam = v
+					break outer
+				}
+			}
+		}
+	}
+	if tparam == nil {
+		return nil
+	}
+
+	// The test parameter may be shadowed.
+	pos := stmt.Pos()
+	scope := typesinternal.EnclosingScope(info, curStmt)
+	if _, obj := scope.LookupParent(tparam.Name(), pos); obj != tparam {
+		return nil
+	}
+	content, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return nil
+	}
+
+	var edits []analysis.TextEdit
+	cancel := id.Name
+	if cancel == "_" {
+		cancel = refactor.FreshName(scope, pos, "cancel")
+		edits = append(edits, analysis.TextEdit{
+			Pos:     id.Pos(),
+			End:     id.End(),
+			NewText: []byte(cancel),
+		})
+	}
+
+	// Insert the call after the statement, on a line of its own, using
+	// the indentation of the statement's line, plus one level if the
+	// statement follows a case label on that line. If only a comment
+	// follows the statement, insert the call after the comment.
+	var (
+		start     = tokFile.Offset(curStmt.Node().Pos())
+		end       = tokFile.Offset(curStmt.Node().End())
+		lineStart = bytes.LastIndexByte(content[:start], '\n') + 1
+		prefix    = content[lineStart:start]
+		indent    = string(prefix[:len(prefix)-len(bytes.TrimLeft(prefix, " \t"))])
+		insert    = curStmt.Node().End()
+	)
+	if len(indent) < len(prefix) {
+		indent += "\t"
+	}
+	rest := content[end:]
+	if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+		rest = rest[:i]
+	}
+	if tail := bytes.TrimSpace(rest); len(tail) == 0 || bytes.HasPrefix(tail, []byte("//")) {
+		insert = tokFile.Pos(end + len(rest))
+	}
+	edits = append(edits, analysis.TextEdit{
+		Pos:     insert,
+		End:     insert,
+		NewText: fmt.Appendf(nil, "\n%s%s.Cleanup(%s)", indent, tparam.Name(), cancel),
+	})
+	return []analysis.SuggestedFix{{
+		Message:   fmt.Sprintf("Call %s.Cleanup(%s)", tparam.Name(), cancel),
+		TextEdits: edits,
+	}}
+}
+
+// isTest reports whether t is *testing.T, *testing.B, *testing.F, or testing.TB.
+func isTest(t types.Type) bool {
+	return typesinternal.IsPointerToNamed(t, "testing", "T", "B", "F") ||
+		typesinternal.IsTypeNamed(t, "testing", "TB")
+}
+
 func isCall(n ast.Node) bool { _, ok := n.(*ast.CallExpr); return ok }
 
 // isContextWithCancel reports whether n is one of the qualified identifiers
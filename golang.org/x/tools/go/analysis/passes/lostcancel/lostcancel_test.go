@@ -15,3 +15,7 @@ func Test(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, lostcancel.Analyzer, "a", "b", "typeparams")
 }
+
+func TestCleanupFix(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), lostcancel.Analyzer, "c")
+}
@@ -0,0 +1,12 @@
+package c
+
+import (
+	"context"
+	"testing"
+)
+
+// Not a test file: no fix.
+func helper(t *testing.T) context.Context {
+	ctx, _ := context.WithCancel(context.Background()) // want `the cancel function returned by context.WithCancel should be called, not discarded, to avoid a context leak`
+	return ctx
+}
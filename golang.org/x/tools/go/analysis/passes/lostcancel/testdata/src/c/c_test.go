@@ -0,0 +1,69 @@
+package c
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+	ctx, _ := context.WithTimeout(context.Background(), time.Second) // want `the cancel function returned by context.WithTimeout should be called`
+	_ = ctx
+}
+
+func BenchmarkCancel(b *testing.B) {
+	var ctx, _ = context.WithCancel(context.Background()) // want `context.WithCancel should be called`
+	_ = ctx
+}
+
+func newContext(tb testing.TB) context.Context {
+	ctx, _ := context.WithDeadline(context.Background(), time.Now()) // want `context.WithDeadline should be called`
+	return ctx
+}
+
+func TestSubtest(t *testing.T) {
+	cancel := 0 // the cancel function needs a fresh name
+	_ = cancel
+	t.Run("sub", func(t *testing.T) {
+		if true {
+			ctx, _ := context.WithCancelCause(context.Background()) // want `context.WithCancelCause should be called`
+			_ = ctx
+		}
+	})
+}
+
+func TestNamed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background()) // want `the cancel function is not used on all paths`
+	if ctx == nil {
+		cancel()
+	}
+} // want `this return statement may be reached without using the cancel var defined on line 36`
+
+func TestCase(t *testing.T) {
+	switch x := 1; x {
+	case 1: ctx, _ := context.WithCancel(context.Background()) // want `context.WithCancel should be called`
+		_ = ctx
+	}
+}
+
+func TestNoFix(t *testing.T) {
+	var ctx context.Context
+	ctx, _ = context.WithCancel(context.Background()) // want `context.WithCancel should be called`
+	_ = ctx
+
+	if ctx, _ := context.WithCancel(ctx); ctx != nil { // want `context.WithCancel should be called`
+	}
+
+	func() {
+		t := 0 // shadows the test parameter
+		_ = t
+		ctx, _ := context.WithCancel(context.Background()) // want `context.WithCancel should be called`
+		_ = ctx
+	}()
+}
+
+func TestOK(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	_ = ctx
+}
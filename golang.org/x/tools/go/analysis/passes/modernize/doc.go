@@ -163,9 +163,10 @@ there are no uses of myerr outside the if statement.
 fmtappendf: replace []byte(fmt.Sprintf) with fmt.Appendf
 
 The fmtappendf analyzer suggests replacing `[]byte(fmt.Sprintf(...))` with
-`fmt.Appendf(nil, ...)`. This avoids the intermediate allocation of a string
-by Sprintf, making the code more efficient. The suggestion also applies to
-fmt.Sprint and fmt.Sprintln.
+`fmt.Appendf(nil, ...)`, and `append(buf, fmt.Sprintf(...)...)` with
+`fmt.Appendf(buf, ...)` when buf is a []byte. This avoids the intermediate
+allocation of a string by Sprintf, making the code more efficient. The
+suggestion also applies to fmt.Sprint and fmt.Sprintln.
 
 Since its fix is not a Pareto improvement, fmtappendf is disabled by default in
 the `go fix` analyzer suite; see golang/go#77581.
@@ -34,9 +34,13 @@ var FmtAppendfAnalyzer = &analysis.Analyzer{
 }
 
 // The fmtappend function replaces []byte(fmt.Sprintf(...)) by
-// fmt.Appendf(nil, ...), and similarly for Sprint, Sprintln.
+// fmt.Appendf(nil, ...), and append(buf, fmt.Sprintf(...)...) by
+// fmt.Appendf(buf, ...), and similarly for Sprint, Sprintln.
 func fmtappendf(pass *analysis.Pass) (any, error) {
-	index := pass.ResultOf[typeindexanalyzer.Analyzer].(*typeindex.Index)
+	var (
+		index = pass.ResultOf[typeindexanalyzer.Analyzer].(*typeindex.Index)
+		info  = pass.TypesInfo
+	)
 	for _, fn := range []types.Object{
 		index.Object("fmt", "Sprintf"),
 		index.Object("fmt", "Sprintln"),
@@ -44,77 +48,141 @@ func fmtappendf(pass *analysis.Pass) (any, error) {
 	} {
 		for curCall := range index.Calls(fn) {
 			call := curCall.Node().(*ast.CallExpr)
-			if ek, idx := curCall.ParentEdge(); ek == edge.CallExpr_Args && idx == 0 {
+			if len(call.Args) == 0 {
+				continue
+			}
+			if !analyzerutil.FileUsesGoVersion(pass, astutil.EnclosingFile(curCall), versions.Go1_19) {
+				continue
+			}
+
+			// Find "Sprint" identifier.
+			var id *ast.Ident
+			switch e := ast.Unparen(call.Fun).(type) {
+			case *ast.SelectorExpr:
+				id = e.Sel // "fmt.Sprint"
+			case *ast.Ident:
+				id = e // "Sprint" after `import . "fmt"`
+			}
+			if id == nil {
+				continue
+			}
+
+			old, new := fn.Name(), strings.Replace(fn.Name(), "Sprint", "Append", 1)
+			parent := curCall.Parent().Node()
+			switch ek, idx := curCall.ParentEdge(); {
+			case ek == edge.CallExpr_Args && idx == 0:
 				// Is parent a T(fmt.SprintX(...)) conversion?
-				conv := curCall.Parent().Node().(*ast.CallExpr)
-				info := pass.TypesInfo
+				conv := parent.(*ast.CallExpr)
 				tv := info.Types[conv.Fun]
-				if tv.IsType() && types.Identical(tv.Type, byteSliceType) {
-					// Have: []byte(fmt.SprintX(...))
-					if len(call.Args) == 0 {
+				if !(tv.IsType() && types.Identical(tv.Type, byteSliceType)) {
+					continue
+				}
+				// Have: []byte(fmt.SprintX(...))
+
+				// fmt.Sprint(f) and fmt.Append(f) have different nil semantics
+				// when the format produces an empty string:
+				// []byte(fmt.Sprintf("")) returns an empty but non-nil
+				// []byte{}, while fmt.Appendf(nil, "") returns nil) so we
+				// should skip these cases.
+				if fn.Name() == "Sprint" || fn.Name() == "Sprintf" {
+					format := info.Types[call.Args[0]].Value
+					if format != nil && mayFormatEmpty(constant.StringVal(format)) {
 						continue
 					}
-					// fmt.Sprint(f) and fmt.Append(f) have different nil semantics
-					// when the format produces an empty string:
-					// []byte(fmt.Sprintf("")) returns an empty but non-nil
-					// []byte{}, while fmt.Appendf(nil, "") returns nil) so we
-					// should skip these cases.
-					if fn.Name() == "Sprint" || fn.Name() == "Sprintf" {
-						format := info.Types[call.Args[0]].Value
-						if format != nil && mayFormatEmpty(constant.StringVal(format)) {
-							continue
-						}
-					}
+				}
 
-					// Find "Sprint" identifier.
-					var id *ast.Ident
-					switch e := ast.Unparen(call.Fun).(type) {
-					case *ast.SelectorExpr:
-						id = e.Sel // "fmt.Sprint"
-					case *ast.Ident:
-						id = e // "Sprint" after `import . "fmt"`
-					}
+				edits := []analysis.TextEdit{
+					{
+						// Delete "[]byte(", including any spaces before the first argument.
+						Pos: conv.Pos(),
+						End: conv.Args[0].Pos(), // always exactly one argument in a valid byte slice conversion
+					},
+					{
+						// Delete ")", including any non-args (space or
+						// commas) that come before the right parenthesis.
+						// Leaving an extra comma here produces invalid
+						// code. (See golang/go#74709)
+						// Unfortunately, this and the edit above may result
+						// in deleting some comments.
+						Pos: conv.Args[0].End(),
+						End: conv.Rparen + 1,
+					},
+					{
+						Pos:     id.Pos(),
+						End:     id.End(),
+						NewText: []byte(new),
+					},
+					{
+						Pos:     call.Lparen + 1,
+						NewText: []byte("nil, "),
+					},
+				}
+				pass.Report(analysis.Diagnostic{
+					Pos:     conv.Pos(),
+					End:     conv.End(),
+					Message: fmt.Sprintf("Replace []byte(fmt.%s...) with fmt.%s", old, new),
+					SuggestedFixes: []analysis.SuggestedFix{{
+						Message:   fmt.Sprintf("Replace []byte(fmt.%s...) with fmt.%s", old, new),
+						TextEdits: edits,
+					}},
+				})
 
-					old, new := fn.Name(), strings.Replace(fn.Name(), "Sprint", "Append", 1)
-					edits := []analysis.TextEdit{
-						{
-							// Delete "[]byte(", including any spaces before the first argument.
-							Pos: conv.Pos(),
-							End: conv.Args[0].Pos(), // always exactly one argument in a valid byte slice conversion
-						},
-						{
-							// Delete ")", including any non-args (space or
-							// commas) that come before the right parenthesis.
-							// Leaving an extra comma here produces invalid
-							// code. (See golang/go#74709)
-							// Unfortunately, this and the edit above may result
-							// in deleting some comments.
-							Pos: conv.Args[0].End(),
-							End: conv.Rparen + 1,
-						},
-						{
-							Pos:     id.Pos(),
-							End:     id.End(),
-							NewText: []byte(new),
-						},
-						{
-							Pos:     call.Lparen + 1,
-							NewText: []byte("nil, "),
-						},
-					}
-					if !analyzerutil.FileUsesGoVersion(pass, astutil.EnclosingFile(curCall), versions.Go1_19) {
+			case ek == edge.CallExpr_Args && idx == 1:
+				// Is parent an append(buf, fmt.SprintX(...)...) call?
+				appendCall := parent.(*ast.CallExpr)
+				fun, ok := appendCall.Fun.(*ast.Ident)
+				if !ok || info.Uses[fun] != builtinAppend ||
+					!appendCall.Ellipsis.IsValid() || len(appendCall.Args) != 2 {
+					continue
+				}
+				// fmt.AppendX returns a []byte, so require that buf
+				// have this type, not a named one.
+				if !types.Identical(info.TypeOf(appendCall.Args[0]), byteSliceType) {
+					continue
+				}
+				// Have: append(buf, fmt.SprintX(...)...)
+				//
+				// Unlike with a conversion, the empty string is not a
+				// special case: both forms return buf unchanged.
+
+				// "fmt." or, after `import . "fmt"`, nothing.
+				qual := ""
+				if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok {
+					pkg, ok := sel.X.(*ast.Ident)
+					if !ok {
 						continue
 					}
-					pass.Report(analysis.Diagnostic{
-						Pos:     conv.Pos(),
-						End:     conv.End(),
-						Message: fmt.Sprintf("Replace []byte(fmt.%s...) with fmt.%s", old, new),
-						SuggestedFixes: []analysis.SuggestedFix{{
-							Message:   fmt.Sprintf("Replace []byte(fmt.%s...) with fmt.%s", old, new),
-							TextEdits: edits,
-						}},
-					})
+					qual = pkg.Name + "."
+				}
+				buf := appendCall.Args[0]
+				edits := []analysis.TextEdit{
+					{
+						// Replace "append(" by "fmt.AppendX(".
+						Pos:     appendCall.Pos(),
+						End:     buf.Pos(),
+						NewText: []byte(qual + new + "("),
+					},
+					{
+						// Replace ", fmt.SprintX(" by ", ".
+						Pos:     buf.End(),
+						End:     call.Lparen + 1,
+						NewText: []byte(", "),
+					},
+					{
+						// Delete "...)" after the SprintX call.
+						Pos: call.Rparen + 1,
+						End: appendCall.Rparen + 1,
+					},
 				}
+				pass.Report(analysis.Diagnostic{
+					Pos:     appendCall.Pos(),
+					End:     appendCall.End(),
+					Message: fmt.Sprintf("Replace append(..., fmt.%s(...)...) with fmt.%s", old, new),
+					SuggestedFixes: []analysis.SuggestedFix{{
+						Message:   fmt.Sprintf("Replace append(..., fmt.%s(...)...) with fmt.%s", old, new),
+						TextEdits: edits,
+					}},
+				})
 			}
 		}
 	}
@@ -70,3 +70,19 @@ func multiline() []byte {
 func getString() string {
 	return ""
 }
+
+func appends(buf []byte, s string) []byte {
+	buf = append(buf, fmt.Sprintf("n=%d", 1)...) // want "Replace append.*Sprintf.* with fmt.Appendf"
+	buf = append(buf, fmt.Sprint(s)...)          // want "Replace append.*Sprint.* with fmt.Append"
+	buf = append(buf, fmt.Sprintln(s)...)        // want "Replace append.*Sprintln.* with fmt.Appendln"
+	// the empty string is not a special case: both forms return buf
+	buf = append(buf, fmt.Sprintf("%s", s)...) // want "Replace append.*Sprintf.* with fmt.Appendf"
+
+	// nope - fmt.Appendf returns []byte, not the named type
+	type bytes []byte
+	var b bytes
+	b = append(b, fmt.Sprintf("%d", 1)...)
+	// nope - the string is not appended with an ellipsis
+	buf = append(buf, fmt.Sprint(s)[0])
+	return append(b, buf...)
+}
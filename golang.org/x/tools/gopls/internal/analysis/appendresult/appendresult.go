@@ -0,0 +1,208 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package appendresult
+
+import (
+	_ "embed"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysis/analyzerutil"
+	"golang.org/x/tools/internal/astutil"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "appendresult",
+	Doc:      analyzerutil.MustExtractDoc(doc, "appendresult"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/gopls/internal/analysis/appendresult",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var builtinAppend = types.Universe.Lookup("append")
+
+func run(pass *analysis.Pass) (any, error) {
+	var (
+		inspect = pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+		info    = pass.TypesInfo
+	)
+
+	// isAppend returns the call to append, if any, of expression e.
+	isAppend := func(e ast.Expr) (*ast.CallExpr, bool) {
+		call, ok := ast.Unparen(e).(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 || typeutil.Callee(info, call) != builtinAppend {
+			return nil, false
+		}
+		return call, true
+	}
+
+	// localVar returns the local variable denoted by e, if any.
+	localVar := func(e ast.Expr) (*types.Var, bool) {
+		id, ok := ast.Unparen(e).(*ast.Ident)
+		if !ok {
+			return nil, false
+		}
+		v, ok := info.ObjectOf(id).(*types.Var)
+		if !ok || v.IsField() || v.Parent() == nil || v.Parent() == pass.Pkg.Scope() {
+			return nil, false
+		}
+		return v, true
+	}
+
+	for curStmt := range inspect.Root().Preorder((*ast.AssignStmt)(nil), (*ast.BlockStmt)(nil), (*ast.CaseClause)(nil), (*ast.CommClause)(nil)) {
+		var list []ast.Stmt
+		switch n := curStmt.Node().(type) {
+		case *ast.AssignStmt:
+			checkDiscarded(pass, n, isAppend)
+			continue
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+
+		// Check for successive appends to the same slice variable,
+		// the first of which stores its result elsewhere.
+		type lastAppend struct {
+			index int          // index of statement in list
+			dest  types.Object // variable receiving the result, if known
+		}
+		last := make(map[*types.Var]lastAppend)
+		for i, stmt := range list {
+			assign, ok := stmt.(*ast.AssignStmt)
+			if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+				continue
+			}
+			call, ok := isAppend(assign.Rhs[0])
+			if !ok {
+				continue
+			}
+			slice, ok := localVar(call.Args[0])
+			if !ok {
+				continue
+			}
+			var dest types.Object
+			if id, ok := ast.Unparen(assign.Lhs[0]).(*ast.Ident); ok {
+				if id.Name == "_" {
+					continue // reported by checkDiscarded
+				}
+				dest = info.ObjectOf(id)
+			}
+
+			if prev, ok := last[slice]; ok && (dest == nil || dest != prev.dest) && !modifies(info, list[prev.index+1:i], slice) {
+				pass.ReportRangef(call, "the results of append to %s at lines %d and %d may share its array",
+					slice.Name(),
+					pass.Fset.Position(list[prev.index].Pos()).Line,
+					pass.Fset.Position(assign.Pos()).Line)
+			}
+			if dest == slice {
+				delete(last, slice) // s = append(s, ...): s no longer shares the array of another variable
+			} else {
+				last[slice] = lastAppend{i, dest}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// checkDiscarded reports each call to append in the assignment whose
+// result is assigned to the blank identifier.
+func checkDiscarded(pass *analysis.Pass, assign *ast.AssignStmt, isAppend func(ast.Expr) (*ast.CallExpr, bool)) {
+	if len(assign.Lhs) != len(assign.Rhs) {
+		return
+	}
+	for i, lhs := range assign.Lhs {
+		if id, ok := lhs.(*ast.Ident); !ok || id.Name != "_" {
+			continue
+		}
+		call, ok := isAppend(assign.Rhs[i])
+		if !ok {
+			continue
+		}
+		diag := analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: "the result of append is discarded",
+		}
+		// In a short variable declaration, the operand might
+		// belong to an outer scope and be shadowed by the fix.
+		if assign.Tok == token.ASSIGN && isVariable(pass.TypesInfo, call.Args[0]) {
+			operand := astutil.Format(pass.Fset, ast.Unparen(call.Args[0]))
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message: "Assign the result to " + operand,
+				TextEdits: []analysis.TextEdit{{
+					Pos:     lhs.Pos(),
+					End:     lhs.End(),
+					NewText: []byte(operand),
+				}},
+			}}
+		}
+		pass.Report(diag)
+	}
+}
+
+// isVariable reports whether e is a variable, or a chain of field
+// selections from one, that can be assigned without side effects.
+func isVariable(info *types.Info, e ast.Expr) bool {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		_, ok := info.Uses[e].(*types.Var)
+		return ok
+	case *ast.SelectorExpr:
+		if sel, ok := info.Selections[e]; ok {
+			// A method value is not a variable.
+			return sel.Kind() == types.FieldVal && isVariable(info, e.X)
+		}
+		// qualified identifier
+		_, ok := info.Uses[e.Sel].(*types.Var)
+		return ok
+	}
+	return false
+}
+
+// modifies reports whether any of the statements assigns v,
+// or takes its address.
+func modifies(info *types.Info, stmts []ast.Stmt, v *types.Var) bool {
+	found := false
+	isV := func(e ast.Expr) bool {
+		id, ok := ast.Unparen(e).(*ast.Ident)
+		return ok && info.ObjectOf(id) == v
+	}
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					if isV(lhs) {
+						found = true
+					}
+				}
+			case *ast.RangeStmt:
+				if n.Key != nil && isV(n.Key) || n.Value != nil && isV(n.Value) {
+					found = true
+				}
+			case *ast.UnaryExpr:
+				if n.Op == token.AND && isV(n.X) {
+					found = true
+				}
+			}
+			return !found
+		})
+		if found {
+			break
+		}
+	}
+	return found
+}
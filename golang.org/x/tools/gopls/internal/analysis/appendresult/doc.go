@@ -0,0 +1,41 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package appendresult defines an analyzer that reports misuses of
+// the result of the built-in append function.
+//
+// # Analyzer appendresult
+//
+// appendresult: report discarded and aliased results of append
+//
+// The appendresult analyzer reports two mistakes in the use of the
+// result of append.
+//
+// The first is an assignment of the result to the blank identifier.
+// The compiler rejects a call to append whose result is unused, but
+// not one whose result is explicitly discarded, and the appended
+// elements are silently lost:
+//
+//	_ = append(s, x) // the result of append is discarded
+//
+// The analyzer offers a fix that assigns the result to the slice
+// operand, when it is a variable or a field selection:
+//
+//	s = append(s, x)
+//
+// The second is a pair of appends to the same slice variable, in the
+// same statement list, the first of which assigns its result to
+// another variable:
+//
+//	left := append(path, "L")
+//	right := append(path, "R") // the results of append to path at lines 1 and 2 may share its array
+//
+// If path has spare capacity, both appends store their element in
+// the same array, so the second overwrites the last element of left.
+// The analyzer does not report the second append if the slice
+// variable is assigned, or its address taken, in between. To make
+// each result independent, append to a copy of the slice, or to a
+// full slice expression such as path[:len(path):len(path)], which
+// forces append to allocate a new array.
+package appendresult
@@ -0,0 +1,91 @@
+package a
+
+type T struct {
+	s []int
+	p *T
+}
+
+var global []int
+
+func discarded(s []int, t T, p *T) {
+	_ = append(s, 1)        // want `the result of append is discarded`
+	_ = append((s), 1, 2)   // want `the result of append is discarded`
+	_ = append(t.s, 1)      // want `the result of append is discarded`
+	_ = append(p.p.s, 1)    // want `the result of append is discarded`
+	_ = append(global, 1)   // want `the result of append is discarded`
+	_ = append(s[:1], 1)    // want `the result of append is discarded`
+	_ = append(get(), 1)    // want `the result of append is discarded`
+	x, _ := 1, append(s, 1) // want `the result of append is discarded`
+	_, _ = x, append(s, 1)  // want `the result of append is discarded`
+	var _ = append(s, 1)    // ok: not an assignment
+	s = append(s, 1)        // ok
+	println(len(s))
+}
+
+func get() []int { return nil }
+
+func aliased(path []string) {
+	left := append(path, "L")
+	right := append(path, "R") // want `the results of append to path at lines 28 and 29 may share its array`
+	println(left, right)
+}
+
+func aliasedSelf(s []int) []int {
+	t := append(s, 1)
+	s = append(s, 2) // want `the results of append to s at lines 34 and 35 may share its array`
+	s = append(s, 3) // ok: s no longer shares the array of t
+	println(t)
+	return s
+}
+
+func aliasedField(s []int, t *T) {
+	t.s = append(s, 1)
+	t.p.s = append(s, 2) // want `the results of append to s at lines 42 and 43 may share its array`
+}
+
+func reassigned(s []int) {
+	a := append(s, 1)
+	s = make([]int, 0, 10)
+	b := append(s, 2)                 // ok: s was reassigned
+	c := append(s[:len(s):len(s)], 3) // ok: full slice expression
+	println(a, b, c)
+}
+
+func addressTaken(s []int) {
+	a := append(s, 1)
+	grow(&s)
+	b := append(s, 2) // ok: s may have been reassigned
+	println(a, b)
+}
+
+func grow(p *[]int) {}
+
+func sameDest(s []int) {
+	var a []int
+	a = append(s, 1)
+	a = append(s, 2) // ok: the first result is overwritten
+	println(a)
+}
+
+func grown(s []int) {
+	s = append(s, 1)
+	s = append(s, 2) // ok
+	println(s)
+}
+
+func loop(path []string, n int) {
+	for i := 0; i < n; i++ {
+		next := append(path, "x") // ok: one append per iteration
+		println(next)
+	}
+}
+
+func separateBlocks(s []int, cond bool) {
+	if cond {
+		a := append(s, 1)
+		println(a)
+	} else {
+		b := append(s, 2) // ok: different statement lists
+		println(b)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package losterr defines an analyzer that reports error values that
+// are lost because their variable is shadowed or overwritten.
+//
+// # Analyzer losterr
+//
+// losterr: report errors lost to shadowing or overwriting
+//
+// The losterr analyzer reports two kinds of mistakes that cause an
+// error value to be silently dropped.
+//
+// The first is a short variable declaration of an error variable
+// that shadows an error variable of an enclosing block of the same
+// function, when the outer variable is checked after the inner block
+// ends, without having been checked or reassigned in between, and
+// the inner variable is never returned. This usually means that the
+// inner declaration was intended to assign the outer variable:
+//
+//	var err error // line 1
+//	if err := f(); err != nil { // err shadows the declaration at line 1; the outer err, checked at line 5, does not receive this value
+//		log.Print(err)
+//	}
+//	return err // always nil
+//
+// The second is an assignment (or short variable declaration) of an
+// error variable whose value is overwritten by a later assignment in
+// the same block before it is ever read:
+//
+//	err := f() // the error assigned to err is overwritten at line 2 before being checked
+//	err = g()
+//	if err != nil { ... }
+//
+// Unlike the shadow analyzer, which reports any shadowing
+// declaration, losterr follows the reads and writes of the outer
+// variable, so it reports only declarations that actually cause an
+// error to be lost. Variables whose address is taken, or that are
+// referenced by a function literal, are not analyzed.
+package losterr
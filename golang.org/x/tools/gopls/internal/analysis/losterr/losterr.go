@@ -0,0 +1,300 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package losterr
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/internal/analysis/analyzerutil"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "losterr",
+	Doc:      analyzerutil.MustExtractDoc(doc, "losterr"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/gopls/internal/analysis/losterr",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// A variable records the accesses to a local error variable.
+type variable struct {
+	fn       ast.Node     // enclosing *ast.FuncDecl or *ast.FuncLit of the declaration
+	reads    []token.Pos  // positions of reads
+	writes   []*ast.Ident // explicit assignments (not including the declaration)
+	escapes  bool         // address taken, or referenced by a nested function
+	returned bool         // read within a return statement
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	var (
+		inspect   = pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+		info      = pass.TypesInfo
+		errorType = types.Universe.Lookup("error").Type()
+		vars      = make(map[*types.Var]*variable)
+		order     []*types.Var // variables in order of declaration, for determinism
+	)
+
+	// isLocalError reports whether obj is a local variable of type error.
+	isLocalError := func(obj types.Object) (*types.Var, bool) {
+		v, ok := obj.(*types.Var)
+		if !ok || v.IsField() || v.Parent() == nil || v.Parent() == pass.Pkg.Scope() {
+			return nil, false
+		}
+		return v, types.Identical(v.Type(), errorType)
+	}
+
+	// Gather the declarations, reads, and writes of each local error variable.
+	inspect.WithStack([]ast.Node{(*ast.Ident)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		id := n.(*ast.Ident)
+
+		// Find the enclosing function.
+		var fn ast.Node
+		for _, n := range slices.Backward(stack) {
+			if is[*ast.FuncDecl](n) || is[*ast.FuncLit](n) {
+				fn = n
+				break
+			}
+		}
+
+		if v, ok := isLocalError(info.Defs[id]); ok {
+			rec := &variable{fn: fn}
+			// The defining assignment of a short variable
+			// declaration is a write like any other.
+			if assign, ok := stack[len(stack)-2].(*ast.AssignStmt); ok && assign.Tok == token.DEFINE {
+				rec.writes = append(rec.writes, id)
+			}
+			vars[v] = rec
+			order = append(order, v)
+			return true
+		}
+		v, ok := isLocalError(info.Uses[id])
+		if !ok {
+			return true
+		}
+		rec := vars[v]
+		if rec == nil {
+			return true // e.g. parameter of a function type
+		}
+		if fn != rec.fn {
+			rec.escapes = true
+		}
+		switch parent := stack[len(stack)-2].(type) {
+		case *ast.AssignStmt:
+			if slices.Contains(parent.Lhs, ast.Expr(id)) {
+				rec.writes = append(rec.writes, id)
+				return true
+			}
+		case *ast.UnaryExpr:
+			if parent.Op == token.AND {
+				rec.escapes = true
+			}
+		}
+		for _, n := range slices.Backward(stack) {
+			if n == fn {
+				break
+			}
+			if is[*ast.ReturnStmt](n) {
+				rec.returned = true
+				break
+			}
+		}
+		rec.reads = append(rec.reads, id.Pos())
+		return true
+	})
+
+	// Index the statement list containing each assignment statement,
+	// and the statement defining each short variable declaration.
+	type stmtContext struct {
+		list  []ast.Stmt
+		index int
+	}
+	assigns := make(map[*ast.Ident]stmtContext)         // LHS identifier -> enclosing statement list
+	definingStmt := make(map[token.Pos]*ast.AssignStmt) // position of LHS of := -> statement
+	inspect.Preorder([]ast.Node{(*ast.BlockStmt)(nil), (*ast.CaseClause)(nil), (*ast.CommClause)(nil), (*ast.AssignStmt)(nil)}, func(n ast.Node) {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE {
+				for _, lhs := range n.Lhs {
+					definingStmt[lhs.Pos()] = n
+				}
+			}
+			return
+		}
+		for i, stmt := range list {
+			if assign, ok := stmt.(*ast.AssignStmt); ok {
+				for _, lhs := range assign.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok {
+						assigns[id] = stmtContext{list, i}
+					}
+				}
+			}
+		}
+	})
+
+	for _, v := range order {
+		rec := vars[v]
+		if rec.escapes {
+			continue
+		}
+
+		// Check for a shadowing declaration, never itself returned,
+		// whose value the outer variable was probably meant to receive.
+		if outer, ok := shadowedError(v, isLocalError); ok {
+			if orec := vars[outer]; orec != nil && !orec.escapes && !rec.returned && orec.fn == rec.fn {
+				checkShadow(pass, v, outer, orec, definingStmt)
+			}
+		}
+
+		// Check for assignments overwritten before being read.
+		for i, w := range rec.writes {
+			ctx, ok := assigns[w]
+			if !ok || !producesError(ctx.list[ctx.index].(*ast.AssignStmt)) {
+				continue
+			}
+			if i+1 == len(rec.writes) {
+				break
+			}
+			next := rec.writes[i+1]
+			nextCtx, ok := assigns[next]
+			if !ok || !sameList(ctx.list, nextCtx.list) || nextCtx.index <= ctx.index {
+				continue
+			}
+			// Any read in between (including in the RHS of the
+			// next assignment) means the value was checked.
+			end := ctx.list[nextCtx.index].End()
+			if slices.ContainsFunc(rec.reads, func(pos token.Pos) bool { return w.Pos() < pos && pos < end }) {
+				continue
+			}
+			// Control flow between the two assignments may
+			// transfer the value to a read elsewhere.
+			if slices.ContainsFunc(ctx.list[ctx.index+1:nextCtx.index], transfersControl) {
+				continue
+			}
+			pass.ReportRangef(w, "the error assigned to %s is overwritten at line %d before being checked",
+				w.Name, pass.Fset.Position(next.Pos()).Line)
+		}
+	}
+	return nil, nil
+}
+
+// shadowedError returns the local error variable, if any, shadowed
+// by the declaration of v.
+func shadowedError(v *types.Var, isLocalError func(types.Object) (*types.Var, bool)) (*types.Var, bool) {
+	scope := v.Parent().Parent()
+	if scope == nil {
+		return nil, false
+	}
+	_, obj := scope.LookupParent(v.Name(), v.Pos())
+	return isLocalError(obj)
+}
+
+// checkShadow reports the declaration of inner if it shadows outer
+// while outer holds a value that has not yet been read, and outer is
+// read after the scope of inner without an intervening assignment.
+func checkShadow(pass *analysis.Pass, inner, outer *types.Var, orec *variable, definingStmt map[token.Pos]*ast.AssignStmt) {
+	// Only short variable declarations of a fresh error are of
+	// interest: 'var err error' is a deliberate fresh start.
+	if stmt, ok := definingStmt[inner.Pos()]; !ok || !producesError(stmt) {
+		return
+	}
+
+	// Find the last write to outer before the shadowing declaration.
+	lastWrite := outer.Pos()
+	for _, w := range orec.writes {
+		if w.Pos() < inner.Pos() {
+			lastWrite = max(lastWrite, w.Pos())
+		}
+	}
+	// Has that value already been checked?
+	if slices.ContainsFunc(orec.reads, func(pos token.Pos) bool { return lastWrite < pos && pos < inner.Pos() }) {
+		return
+	}
+
+	// Find the first read of outer after the scope of inner.
+	scopeEnd := inner.Parent().End()
+	var firstRead token.Pos
+	for _, pos := range orec.reads {
+		if pos > scopeEnd && (!firstRead.IsValid() || pos < firstRead) {
+			firstRead = pos
+		}
+	}
+	if !firstRead.IsValid() {
+		return
+	}
+	// Was outer reassigned in the meantime?
+	for _, w := range orec.writes {
+		if inner.Pos() < w.Pos() && w.Pos() < firstRead {
+			return
+		}
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos: inner.Pos(),
+		End: inner.Pos() + token.Pos(len(inner.Name())),
+		Message: fmt.Sprintf("%s shadows the declaration at line %d; the outer %s, checked at line %d, does not receive this value",
+			inner.Name(),
+			pass.Fset.Position(outer.Pos()).Line,
+			outer.Name(),
+			pass.Fset.Position(firstRead).Line),
+	})
+}
+
+// producesError reports whether the assignment obtains its values
+// from a function call, and thus may carry a non-nil error.
+func producesError(assign *ast.AssignStmt) bool {
+	for _, rhs := range assign.Rhs {
+		if is[*ast.CallExpr](ast.Unparen(rhs)) {
+			return true
+		}
+	}
+	return false
+}
+
+// transfersControl reports whether stmt contains a statement that may
+// transfer control out of the enclosing statement list.
+func transfersControl(stmt ast.Stmt) bool {
+	found := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BranchStmt, *ast.ReturnStmt, *ast.LabeledStmt:
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// sameList reports whether x and y are the same statement list.
+func sameList(x, y []ast.Stmt) bool {
+	return len(x) > 0 && len(y) > 0 && &x[0] == &y[0]
+}
+
+func is[T any](x any) bool {
+	_, ok := x.(T)
+	return ok
+}
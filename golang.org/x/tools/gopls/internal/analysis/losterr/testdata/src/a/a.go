@@ -0,0 +1,130 @@
+package a
+
+import "errors"
+
+func f() error        { return errors.New("f") }
+func g() (int, error) { return 0, errors.New("g") }
+
+func shadowed(cond bool) error {
+	var err error
+	if cond {
+		x, err := g() // want `err shadows the declaration at line 9; the outer err, checked at line 14, does not receive this value`
+		println(x, err)
+	}
+	return err
+}
+
+func shadowedInLoop(n int) error {
+	var err error
+	for i := 0; i < n; i++ {
+		_, err := g() // want `err shadows the declaration at line 18; the outer err, checked at line 23, does not receive this value`
+		println(err)
+	}
+	return err
+}
+
+func overwritten() error {
+	err := f() // want `the error assigned to err is overwritten at line 28 before being checked`
+	err = f() // want `the error assigned to err is overwritten at line 29 before being checked`
+	err = f()
+	return err
+}
+
+func shadowedInIf(x int) error {
+	var err error
+	if x > 0 {
+		_, err = g()
+	} else if err := f(); err != nil { // want `err shadows the declaration at line 34; the outer err, checked at line 40, does not receive this value`
+		println(err)
+	}
+	return err
+}
+
+func shadowedInIfWrapped() error {
+	var err error
+	if err := f(); err != nil { // want `err shadows the declaration at line 44; the outer err, checked at line 48, does not receive this value`
+		println("failed:", err.Error())
+	}
+	return err
+}
+
+// Negative cases.
+
+func shadowReturned() error {
+	var err error
+	if err := f(); err != nil {
+		return errors.Join(errors.New("f"), err)
+	}
+	return err
+}
+
+func checkedBeforeShadow() error {
+	err := f()
+	if err != nil {
+		return err
+	}
+	if _, err := g(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func shadowCheckedLocally(cond bool) error {
+	var err error
+	if cond {
+		_, err := g()
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func reassignedAfterShadow(cond bool) error {
+	var err error
+	if cond {
+		_, err := g()
+		_ = err
+	}
+	err = f()
+	return err
+}
+
+func closure() error {
+	var err error
+	func() {
+		_, err = g()
+	}()
+	err = f()
+	return err
+}
+
+func addressTaken() error {
+	var err error
+	p := &err
+	*p = f()
+	err = f()
+	return err
+}
+
+func readInBetween() error {
+	err := f()
+	err = errors.Join(err, f())
+	return err
+}
+
+func branchInBetween(cond bool) error {
+	err := f()
+	if cond {
+		return err
+	}
+	err = f()
+	return err
+}
+
+func notFromCall() error {
+	var err error
+	err = nil
+	err = f()
+	return err
+}
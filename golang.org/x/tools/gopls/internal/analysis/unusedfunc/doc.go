@@ -20,6 +20,12 @@
 // that of any method of an interface type declared within the same
 // package.
 //
+// In a main package, which cannot be imported, exported functions,
+// types, constants, and vars are reported in the same way as
+// unexported ones. (Exported methods are not, as they may be called
+// through an interface declared in another package.) The
+// -exportedmain=false flag disables this.
+//
 // The tool may report false positives in some situations, for
 // example:
 //
@@ -46,6 +52,17 @@
 // See https://go.dev/issue/71686 and https://go.dev/issue/74130 for
 // further discussion of these limitations.
 //
+// Deleting an unused function often makes its callees unused too.
+// The analyzer reports such declarations in the same run, along with
+// the chain of unused declarations whose deletion makes them unused,
+// for example:
+//
+//	function "leaf" is unused once "root" is deleted (root -> middle -> leaf)
+//
+// These diagnostics offer the same fix as the others, which deletes
+// the declaration; it should be applied along with the fixes of the
+// declarations that refer to it, for example by source.fixAll.
+//
 // The unusedfunc algorithm is not as precise as the
 // golang.org/x/tools/cmd/deadcode tool, but it has the advantage that
 // it runs within the modular analysis framework, enabling near
@@ -55,4 +72,12 @@
 // constants. Enums--constants defined with iota--are ignored since
 // even the unused values must remain present to preserve the logical
 // ordering.
+//
+// It also reports unexported package-level variables that are
+// assigned but never read: every reference to them, such as v = x,
+// v += x, or v++, updates the variable without observing its value.
+// No fix is offered for these, as the assignments may have side
+// effects. Variables named by a go:linkname directive, and all
+// variables of packages that use cgo, are not reported, as they may
+// be read by code that the analyzer cannot see.
 package unusedfunc
@@ -10,6 +10,7 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
+	"slices"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
@@ -21,6 +22,7 @@ import (
 	"golang.org/x/tools/internal/astutil"
 	"golang.org/x/tools/internal/packagepath"
 	"golang.org/x/tools/internal/refactor"
+	"golang.org/x/tools/internal/typesinternal"
 	"golang.org/x/tools/internal/typesinternal/typeindex"
 )
 
@@ -55,6 +57,11 @@ import (
 //
 // Types (sans methods), constants, and vars are more straightforward.
 //
+// A main package cannot be imported, so by default its exported
+// functions, types, constants, and vars are treated like unexported
+// ones. Exported methods are still exempt, as they may be called
+// through interfaces declared in other packages.
+//
 // For enums (defined here as const decls where all consts have the same type),
 // we only require that one of the names in the group is used, since it is
 // common for at least some values to be unused when they are added for
@@ -63,6 +70,12 @@ import (
 //go:embed doc.go
 var doc string
 
+var exportedMain bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&exportedMain, "exportedmain", true, "whether to report unused exported symbols of main packages")
+}
+
 var Analyzer = &analysis.Analyzer{
 	Name:     "unusedfunc",
 	Doc:      analyzerutil.MustExtractDoc(doc, "unusedfunc"),
@@ -83,6 +96,34 @@ func run(pass *analysis.Pass) (any, error) {
 		index   = pass.ResultOf[typeindexanalyzer.Analyzer].(*typeindex.Index)
 	)
 
+	// Exported symbols of a main package cannot be referenced
+	// from other packages, so they too may be unused.
+	checkExported := exportedMain && pass.Pkg.Name() == "main"
+
+	// Gather the local names of //go:linkname directives anywhere in
+	// the package, and note whether the package uses cgo: variables
+	// named by either may be read by code that we cannot see.
+	var (
+		linknames = make(map[string]bool)
+		usesCgo   = typesinternal.Imports(pass.Pkg, "runtime/cgo")
+	)
+	for _, file := range pass.Files {
+		for _, cg := range file.Comments {
+			for _, comment := range cg.List {
+				if rest, ok := strings.CutPrefix(comment.Text, "//go:linkname "); ok {
+					if fields := strings.Fields(rest); len(fields) > 0 {
+						linknames[fields[0]] = true
+					}
+				}
+			}
+		}
+		for _, spec := range file.Imports {
+			if spec.Path.Value == `"C"` {
+				usesCgo = true
+			}
+		}
+	}
+
 	// Gather names of unexported interface methods declared in this package.
 	localIfaceMethods := make(map[string]bool)
 	nodeFilter := []ast.Node{(*ast.InterfaceType)(nil)}
@@ -99,11 +140,21 @@ func run(pass *analysis.Pass) (any, error) {
 		}
 	})
 
+	// A candidate is an unexported (or, if checkExported, non-method)
+	// declaration that may be unused.
+	type candidate struct {
+		noun    string
+		id      *ast.Ident
+		curSelf inspector.Cursor // references within curSelf are ignored
+		delete  func() []analysis.TextEdit
+	}
+	var candidates []*candidate
+
 	// used reports whether the object declared at id is (potentially) used.
 	// References within curSelf are ignored.
 	used := func(id *ast.Ident, curSelf inspector.Cursor) bool {
 		// Exported functions may be called from other packages.
-		if id.IsExported() {
+		if id.IsExported() && !checkExported {
 			return true
 		}
 
@@ -123,22 +174,15 @@ func run(pass *analysis.Pass) (any, error) {
 		return false
 	}
 
-	// checkUnused reports a diagnostic if the object declared at id
-	// is unexported and unused. References within curSelf are ignored.
+	// checkUnused records the declaration of the object declared at
+	// id as a candidate for an unused diagnostic, if it is
+	// unexported, or if checkExported and it is not a method.
+	// References within curSelf are ignored.
 	checkUnused := func(noun string, id *ast.Ident, curSelf inspector.Cursor, delete func() []analysis.TextEdit) {
-		if used(id, curSelf) {
+		if id.IsExported() && (!checkExported || noun == "method") || id.Name == "_" {
 			return
 		}
-
-		pass.Report(analysis.Diagnostic{
-			Pos:     id.Pos(),
-			End:     id.End(),
-			Message: fmt.Sprintf("%s %q is unused", noun, id.Name),
-			SuggestedFixes: []analysis.SuggestedFix{{
-				Message:   fmt.Sprintf("Delete %s %q", noun, id.Name),
-				TextEdits: delete(),
-			}},
-		})
+		candidates = append(candidates, &candidate{noun, id, curSelf, delete})
 	}
 
 	// isEnum returns true if the decl curGenDecl is a const decl with more than one
@@ -277,9 +321,135 @@ func run(pass *analysis.Pass) (any, error) {
 		}
 	}
 
+	// Report the candidates that are unused, and then, iteratively,
+	// the candidates that become unused once the unused ones are
+	// deleted: deleting one unused function often makes its sole
+	// callees unused too.
+	//
+	// A candidate is unused if all its references (except those
+	// within itself) lie within unused candidates. The cause of
+	// an unused candidate is the unused candidate that (first)
+	// refers to it, or nil if it is not referenced at all.
+	var (
+		cause      = make(map[*candidate]*candidate)
+		unused     []*candidate                    // in order of discovery
+		unusedDecl = make(map[ast.Node]*candidate) // maps curSelf of each unused candidate to it
+	)
+	markUnused := func(c, user *candidate) {
+		cause[c] = user
+		unused = append(unused, c)
+		unusedDecl[c.curSelf.Node()] = c
+	}
+	// enclosingUnused returns the unused candidate whose
+	// declaration encloses cur, or nil if there is none.
+	enclosingUnused := func(cur inspector.Cursor) *candidate {
+		for cur := range cur.Enclosing() {
+			if c, ok := unusedDecl[cur.Node()]; ok {
+				return c
+			}
+		}
+		return nil
+	}
+	for _, c := range candidates {
+		if !used(c.id, c.curSelf) {
+			markUnused(c, nil)
+		}
+	}
+	for changed := len(unused) > 0; changed; {
+		changed = false
+	nextCandidate:
+		for _, c := range candidates {
+			if _, ok := cause[c]; ok {
+				continue
+			}
+			var user *candidate
+			for curId := range index.Uses(pass.TypesInfo.Defs[c.id]) {
+				if c.curSelf.Contains(curId) {
+					continue // self reference
+				}
+				u := enclosingUnused(curId)
+				if u == nil {
+					continue nextCandidate // referenced by a live declaration
+				}
+				if user == nil {
+					user = u
+				}
+			}
+			markUnused(c, user)
+			changed = true
+		}
+	}
+	for _, c := range unused {
+		diag := analysis.Diagnostic{
+			Pos:     c.id.Pos(),
+			End:     c.id.End(),
+			Message: fmt.Sprintf("%s %q is unused", c.noun, c.id.Name),
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   fmt.Sprintf("Delete %s %q", c.noun, c.id.Name),
+				TextEdits: c.delete(),
+			}},
+		}
+		if user := cause[c]; user != nil {
+			// Report the chain of declarations whose deletion
+			// makes this one unused, starting from its root.
+			// The fix must be applied along with those of the
+			// chain, as the declaration is still referenced.
+			chain := []string{c.id.Name}
+			for u := user; u != nil; u = cause[u] {
+				chain = append(chain, u.id.Name)
+			}
+			slices.Reverse(chain)
+			diag.Message = fmt.Sprintf("%s %q is unused once %q is deleted (%s)", c.noun, c.id.Name, chain[0], strings.Join(chain, " -> "))
+			diag.Related = []analysis.RelatedInformation{{
+				Pos:     user.id.Pos(),
+				End:     user.id.End(),
+				Message: fmt.Sprintf("referenced by unused %s %q", user.noun, user.id.Name),
+			}}
+		}
+		pass.Report(diag)
+	}
+
+	// Report package-level variables that are referenced only
+	// by assignments: their values are never read. No fix is
+	// offered, as the assignments may have side effects.
+	if !usesCgo {
+		for _, c := range candidates {
+			if _, ok := cause[c]; ok || c.noun != "var" || linknames[c.id.Name] {
+				continue
+			}
+			if writeOnly(pass.TypesInfo.Defs[c.id], c.curSelf, index) {
+				pass.Report(analysis.Diagnostic{
+					Pos:     c.id.Pos(),
+					End:     c.id.End(),
+					Message: fmt.Sprintf("var %q is assigned but never read", c.id.Name),
+				})
+			}
+		}
+	}
+
 	return nil, nil
 }
 
+// writeOnly reports whether all references to the variable, except
+// those within curSelf, are assignments to it, of which there is at
+// least one. An increment or compound assignment such as v += 1 counts
+// as an assignment, as the value it reads is never observed otherwise.
+func writeOnly(v types.Object, curSelf inspector.Cursor, index *typeindex.Index) bool {
+	writes := 0
+	for curId := range index.Uses(v) {
+		if curSelf.Contains(curId) {
+			continue // self reference
+		}
+		switch ek, _ := curId.ParentEdge(); ek {
+		case edge.AssignStmt_Lhs, edge.IncDecStmt_X, edge.RangeStmt_Key, edge.RangeStmt_Value:
+			writes++
+		default:
+			return false // a read, or the address is taken
+		}
+	}
+	return writes > 0
+}
+
 func cond[T any](cond bool, t, f T) T {
 	if cond {
 		return t
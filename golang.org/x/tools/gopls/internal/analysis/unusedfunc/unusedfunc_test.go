@@ -15,5 +15,15 @@ import (
 
 func Test(t *testing.T) {
 	dir := testfiles.ExtractTxtarFileToTmp(t, filepath.Join(analysistest.TestData(), "basic.txtar"))
-	analysistest.RunWithSuggestedFixes(t, dir, unusedfunc.Analyzer, "example.com/a")
+	analysistest.RunWithSuggestedFixes(t, dir, unusedfunc.Analyzer, "example.com/a", "example.com/m")
+}
+
+func TestExportedMain(t *testing.T) {
+	if err := unusedfunc.Analyzer.Flags.Set("exportedmain", "false"); err != nil {
+		t.Fatal(err)
+	}
+	defer unusedfunc.Analyzer.Flags.Set("exportedmain", "true")
+
+	dir := testfiles.ExtractTxtarFileToTmp(t, filepath.Join(analysistest.TestData(), "basic.txtar"))
+	analysistest.Run(t, dir, unusedfunc.Analyzer, "example.com/n")
 }
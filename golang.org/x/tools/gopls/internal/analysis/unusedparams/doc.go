@@ -24,6 +24,11 @@
 //   - functions with empty bodies, or containing just a call to panic.
 //   - parameters that are unnamed, or named "_", the blank identifier.
 //
+// Function literals are checked if they are called immediately, as in
+// "go func(x int) { ... }(x)", or are bound to a variable that is used
+// only in call position, since in either case nothing but the calls
+// constrains their signatures.
+//
 // The analyzer suggests a fix of replacing the parameter name by "_",
 // but in such cases a deeper fix can be obtained by invoking the
 // "Refactor: remove unused parameter" code action, which will
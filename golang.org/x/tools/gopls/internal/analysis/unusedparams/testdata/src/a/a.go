@@ -85,3 +85,23 @@ func _() {
 	}
 	fib(10, 42)
 }
+
+func _(ch chan int) {
+	func(x int) { println() }(1) // want "unused parameter: x"
+
+	go func(x, y int) { // want "unused parameter: y"
+		ch <- x
+	}(1, 2)
+
+	defer func(x int) { println() }(3) // want "unused parameter: x"
+
+	func(x int) {}(4) // empty body: no diagnostic
+
+	(func(x int) { println() })(5) // want "unused parameter: x"
+
+	_ = (func(x int) int { return 0 }) // no report: function is address-taken
+
+	use(func(x int) { println() }) // no report: function is address-taken
+}
+
+func use(any) {}
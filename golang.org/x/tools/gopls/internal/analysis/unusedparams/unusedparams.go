@@ -46,12 +46,13 @@ func run(pass *analysis.Pass) (any, error) {
 	//	f(...)		// not address-taken
 	//      use(f)          // address-taken
 	//
-	// A literal function is address-taken if it is not
-	// immediately bound to a variable, or if that variable is
-	// used not in call position:
+	// A literal function is address-taken if it is neither
+	// immediately called nor immediately bound to a variable,
+	// or if that variable is used not in call position:
 	//
 	//    f := func() { ... }; f()     			used only in call position
 	//    var f func(); f = func() { ...f()... }; f()     	ditto
+	//    func() { ... }()					called immediately
 	//    use(func() { ... })				address-taken
 	//
 
@@ -127,9 +128,10 @@ func run(pass *analysis.Pass) (any, error) {
 funcloop:
 	for c := range inspect.Root().Preorder((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
 		var (
-			fn    types.Object // function symbol (*Func, possibly *Var for a FuncLit)
-			ftype *ast.FuncType
-			body  *ast.BlockStmt
+			fn      types.Object // function symbol (*Func, possibly *Var for a FuncLit)
+			ftype   *ast.FuncType
+			body    *ast.BlockStmt
+			invoked bool // FuncLit is called immediately: func() { ... }()
 		)
 		switch n := c.Node().(type) {
 		case *ast.FuncDecl:
@@ -159,7 +161,7 @@ funcloop:
 		case *ast.FuncLit:
 			// Find the symbol for the variable (if any)
 			// to which the FuncLit is bound.
-			// (We don't bother to allow ParenExprs.)
+			// (We allow ParenExprs only around a literal that is called.)
 			switch parent := c.Parent().Node().(type) {
 			case *ast.AssignStmt:
 				// f  = func() {...}
@@ -185,6 +187,27 @@ funcloop:
 					}
 				}
 
+			case *ast.CallExpr, *ast.ParenExpr:
+				//       func() { ... }()
+				// go    func() { ... }()
+				// defer func() { ... }()
+				//       (func() { ... })()
+				// The literal's type is not constrained by
+				// anything but the call. Fake a local var.
+				cur := c.Parent()
+				for {
+					if _, ok := cur.Node().(*ast.ParenExpr); !ok {
+						break
+					}
+					cur = cur.Parent()
+				}
+				if call, ok := cur.Node().(*ast.CallExpr); ok && ast.Unparen(call.Fun) == n {
+					v := types.NewVar(n.Pos(), pass.Pkg, "", pass.TypesInfo.TypeOf(n))
+					v.SetKind(types.LocalVar)
+					fn = v
+					invoked = true
+				}
+
 			case *ast.ValueSpec:
 				// var f = func() { ... }
 				// (unless f is an exported package-level var)
@@ -263,25 +286,28 @@ funcloop:
 					// This diagnostic carries both an edit-based fix to
 					// rename the unused parameter, and a command-based fix
 					// to remove it (see golang.RemoveUnusedParameter).
+					// The latter is not offered for an immediately
+					// invoked literal, which has no declaration.
+					fixes := []analysis.SuggestedFix{{
+						Message: `Rename parameter to "_"`,
+						TextEdits: []analysis.TextEdit{{
+							Pos:     id.Pos(),
+							End:     id.End(),
+							NewText: []byte("_"),
+						}},
+					}}
+					if !invoked {
+						fixes = append(fixes, analysis.SuggestedFix{
+							Message: fmt.Sprintf("Remove unused parameter %q", id.Name),
+							// No TextEdits => computed by gopls command
+						})
+					}
 					pass.Report(analysis.Diagnostic{
-						Pos:      start,
-						End:      end,
-						Message:  fmt.Sprintf("unused parameter: %s", id.Name),
-						Category: FixCategory,
-						SuggestedFixes: []analysis.SuggestedFix{
-							{
-								Message: `Rename parameter to "_"`,
-								TextEdits: []analysis.TextEdit{{
-									Pos:     id.Pos(),
-									End:     id.End(),
-									NewText: []byte("_"),
-								}},
-							},
-							{
-								Message: fmt.Sprintf("Remove unused parameter %q", id.Name),
-								// No TextEdits => computed by gopls command
-							},
-						},
+						Pos:            start,
+						End:            end,
+						Message:        fmt.Sprintf("unused parameter: %s", id.Name),
+						Category:       FixCategory,
+						SuggestedFixes: fixes,
 					})
 				}
 			}
@@ -7,6 +7,7 @@ package cache
 // This file defines gopls' driver for modular static analysis (go/analysis).
 
 import (
+	"cmp"
 	"context"
 	"crypto/sha256"
 	"encoding/gob"
@@ -111,6 +112,31 @@ const AnalysisProgressTitle = "Analyzing Dependencies"
 //
 // Notifications of progress may be sent to the optional reporter.
 func (s *Snapshot) Analyze(ctx context.Context, pkgs map[PackageID]*metadata.Package, reporter *progress.Tracker) ([]*Diagnostic, error) {
+	diags, _, err := s.analyze(ctx, pkgs, reporter)
+	return diags, err
+}
+
+// AnalyzeTimed is like Analyze, but also returns the running time of
+// each analyzer, including those that only fulfil the requirements of
+// the enabled ones, summed over the packages. The running times are
+// those recorded when the results were computed, possibly by an
+// earlier process whose results are in the file cache.
+func (s *Snapshot) AnalyzeTimed(ctx context.Context, pkgs map[PackageID]*metadata.Package) ([]*Diagnostic, []LabelDuration, error) {
+	diags, durations, err := s.analyze(ctx, pkgs, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	times := make([]LabelDuration, 0, len(durations))
+	for name, d := range durations {
+		times = append(times, LabelDuration{Label: name, Duration: d})
+	}
+	slices.SortFunc(times, func(x, y LabelDuration) int {
+		return cmp.Or(-cmp.Compare(x.Duration, y.Duration), cmp.Compare(x.Label, y.Label))
+	})
+	return diags, times, nil
+}
+
+func (s *Snapshot) analyze(ctx context.Context, pkgs map[PackageID]*metadata.Package, reporter *progress.Tracker) ([]*Diagnostic, map[string]time.Duration, error) {
 	start := time.Now() // for progress reporting
 
 	var tagStr string // sorted comma-separated list of PackageIDs
@@ -146,15 +172,17 @@ func (s *Snapshot) Analyze(ctx context.Context, pkgs map[PackageID]*metadata.Pac
 	// Perform basic sanity checks.
 	// (Ideally we would do this only once.)
 	if err := analysis.Validate(enabledAnalyzers); err != nil {
-		return nil, fmt.Errorf("invalid analyzer configuration: %v", err)
+		return nil, nil, fmt.Errorf("invalid analyzer configuration: %v", err)
 	}
 
 	stableNames := make(map[*analysis.Analyzer]string)
+	names := make(map[string]string) // maps stable name to analyzer name
 
 	var facty []*analysis.Analyzer // facty subset of enabled + transitive requirements
 	for _, a := range enabledAnalyzers {
 		// TODO(adonovan): reject duplicate stable names (very unlikely).
 		stableNames[a] = stableName(a)
+		names[stableNames[a]] = a.Name
 
 		// Register fact types of all required analyzers.
 		if len(a.FactTypes) > 0 {
@@ -172,7 +200,7 @@ func (s *Snapshot) Analyze(ctx context.Context, pkgs map[PackageID]*metadata.Pac
 	ids := moremaps.KeySlice(pkgs)
 	handles, err := s.getPackageHandles(ctx, ids)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	batch.addHandles(handles)
 
@@ -241,7 +269,7 @@ func (s *Snapshot) Analyze(ctx context.Context, pkgs map[PackageID]*metadata.Pac
 	for id := range pkgs {
 		root, err := makeNode(nil, id)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		root.analyzers = enabledAnalyzers
 		roots = append(roots, root)
@@ -307,6 +335,10 @@ func (s *Snapshot) Analyze(ctx context.Context, pkgs map[PackageID]*metadata.Pac
 	// group to make progress: deadlock.
 	limiter := make(chan unit, runtime.GOMAXPROCS(0))
 	var completed atomic.Int64
+	var (
+		durationsMu sync.Mutex
+		durations   = make(map[string]time.Duration) // running time of each analyzer
+	)
 
 	var enqueue func(*analysisNode)
 	enqueue = func(an *analysisNode) {
@@ -349,6 +381,12 @@ func (s *Snapshot) Analyze(ctx context.Context, pkgs map[PackageID]*metadata.Pac
 			an.compiles = summary.Compiles
 			an.actions = summary.Actions
 
+			durationsMu.Lock()
+			for name, act := range summary.Actions {
+				durations[names[name]] += act.Duration
+			}
+			durationsMu.Unlock()
+
 			// Notify each waiting predecessor,
 			// and enqueue it when it becomes a leaf.
 			for _, pred := range an.preds {
@@ -371,7 +409,7 @@ func (s *Snapshot) Analyze(ctx context.Context, pkgs map[PackageID]*metadata.Pac
 		enqueue(leaf)
 	}
 	if err := g.Wait(); err != nil {
-		return nil, err // cancelled, or failed to produce a package
+		return nil, nil, err // cancelled, or failed to produce a package
 	}
 
 	// Inv: all root nodes now have a summary.
@@ -425,7 +463,7 @@ func (s *Snapshot) Analyze(ctx context.Context, pkgs map[PackageID]*metadata.Pac
 			}
 		}
 	}
-	return results, nil
+	return results, durations, nil
 }
 
 func (an *analysisNode) decrefPreds() {
@@ -510,7 +548,8 @@ type actionSummary struct {
 	Facts       []byte    // the encoded facts.Set
 	FactsHash   file.Hash // hash(Facts)
 	Diagnostics []gobDiagnostic
-	Err         string // "" => success
+	Duration    time.Duration // running time of the analyzer, when the summary was computed
+	Err         string        // "" => success
 }
 
 var (
@@ -1028,7 +1067,10 @@ func (act *action) exec(ctx context.Context) (any, *actionSummary, error) {
 
 	// Recover from panics (only) within the analyzer logic.
 	// (Use an anonymous function to limit the recover scope.)
-	var result any
+	var (
+		result   any
+		duration time.Duration
+	)
 	func() {
 		start := time.Now()
 		defer func() {
@@ -1055,8 +1097,9 @@ func (act *action) exec(ctx context.Context) (any, *actionSummary, error) {
 			}
 
 			// Accumulate running time for each checker.
+			duration = time.Since(start)
 			analyzerRunTimesMu.Lock()
-			analyzerRunTimes[analyzer] += time.Since(start)
+			analyzerRunTimes[analyzer] += duration
 			analyzerRunTimesMu.Unlock()
 		}()
 
@@ -1086,6 +1129,7 @@ func (act *action) exec(ctx context.Context) (any, *actionSummary, error) {
 		Diagnostics: diagnostics,
 		Facts:       factsdata,
 		FactsHash:   file.HashOf(factsdata),
+		Duration:    duration,
 	}, nil
 }
 
@@ -6,11 +6,13 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"slices"
 
 	"golang.org/x/tools/gopls/internal/protocol"
+	protocolcommand "golang.org/x/tools/gopls/internal/protocol/command"
 	"golang.org/x/tools/gopls/internal/settings"
 )
 
@@ -18,17 +20,33 @@ import (
 type check struct {
 	app      *application
 	Severity string `flag:"severity" help:"minimum diagnostic severity (hint, info, warning, or error)"`
+	Fix      bool   `flag:"fix" help:"apply all safe suggested fixes to the source files"`
+	Diff     bool   `flag:"diff" help:"display diffs of all safe suggested fixes"`
 }
 
 func (c *check) Name() string      { return "check" }
 func (c *check) Parent() string    { return c.app.Name() }
-func (c *check) Usage() string     { return "<filename>" }
+func (c *check) Usage() string     { return "[check-flags] <filename>" }
 func (c *check) ShortHelp() string { return "show diagnostic results for the specified file" }
 func (c *check) DetailedHelp(f *flag.FlagSet) {
 	fmt.Fprint(f.Output(), `
 Example: show the diagnostic results of this file:
 
 	$ gopls check internal/cmd/check.go
+
+The -fix flag causes check to apply all the suggested fixes that are
+safe to apply without review: those that an editor would apply as a
+"source.fixAll" code action, for example on save. The fixes apply to
+all the files of the packages of the specified files, whose
+diagnostics are reported too. The -diff flag prints these fixes as a
+diff. When two fixes conflict, only the first is applied; run the
+command again to apply the rest.
+
+Example: apply all safe fixes to the package in the current directory:
+
+	$ gopls check -fix main.go
+
+check-flags:
 `)
 	printFlagDefaults(f)
 }
@@ -72,14 +90,21 @@ func (c *check) Run(ctx context.Context, args ...string) error {
 	}
 	defer cli.terminate(ctx)
 
-	// Open and diagnose the requested files.
-	var (
-		uris     []protocol.DocumentURI
-		checking = make(map[protocol.DocumentURI]*cmdFile)
-	)
+	var uris []protocol.DocumentURI
 	for _, arg := range args {
-		uri := protocol.URIFromPath(arg)
-		uris = append(uris, uri)
+		uris = append(uris, protocol.URIFromPath(arg))
+	}
+	if c.Fix || c.Diff {
+		// Fixes apply to whole packages.
+		uris, err = packageFiles(ctx, cli, uris)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Open and diagnose the requested files.
+	checking := make(map[protocol.DocumentURI]*cmdFile)
+	for _, uri := range uris {
 		file, err := cli.openFile(ctx, uri)
 		if err != nil {
 			return err
@@ -124,5 +149,129 @@ func (c *check) Run(ctx context.Context, args ...string) error {
 
 		}
 	}
+
+	if c.Fix || c.Diff {
+		flags := &EditFlags{Write: c.Fix, Diff: c.Diff}
+		for _, uri := range uris {
+			if err := applySafeFixes(ctx, cli, checking[uri], flags); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
+
+// packageFiles returns the Go files of the packages of the specified
+// files, in order, without duplicates. The files of a test variant are
+// included only for files that belong to no other package, such as
+// _test.go files.
+func packageFiles(ctx context.Context, cli *client, files []protocol.DocumentURI) ([]protocol.DocumentURI, error) {
+	cmd := protocolcommand.NewPackagesCommand("", protocolcommand.PackagesArgs{
+		Files: files,
+		Mode:  protocolcommand.NeedFiles,
+	})
+	res, err := executeCommand(ctx, cli.server, cmd)
+	if err != nil {
+		return nil, err
+	}
+	// Round-trip through JSON so that this works in -remote mode too.
+	data, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	var result protocolcommand.PackagesResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	var (
+		uris []protocol.DocumentURI
+		seen = make(map[protocol.DocumentURI]bool)
+	)
+	add := func(pkg protocolcommand.Package) {
+		for _, uri := range pkg.GoFiles {
+			if !seen[uri] {
+				seen[uri] = true
+				uris = append(uris, uri)
+			}
+		}
+	}
+	for _, file := range files {
+		if seen[file] {
+			continue
+		}
+		found := false
+		for _, test := range []bool{false, true} {
+			for _, pkg := range result.Packages {
+				if (pkg.ForTest != "") == test && slices.Contains(pkg.GoFiles, file) {
+					add(pkg)
+					found = true
+				}
+			}
+			if found {
+				break
+			}
+		}
+		if !found {
+			// Not in any package: check the file alone.
+			seen[file] = true
+			uris = append(uris, file)
+		}
+	}
+	return uris, nil
+}
+
+// applySafeFixes applies to the file the edits of all the
+// "source.fixAll" code actions for its diagnostics, skipping any
+// action whose edits overlap those of an earlier one.
+func applySafeFixes(ctx context.Context, cli *client, file *cmdFile, flags *EditFlags) error {
+	file.diagnosticsMu.Lock()
+	diagnostics := slices.Clone(file.diagnostics)
+	file.diagnosticsMu.Unlock()
+	if len(diagnostics) == 0 {
+		return nil
+	}
+
+	rng, err := file.mapper.OffsetRange(0, len(file.mapper.Content))
+	if err != nil {
+		return err
+	}
+	actions, err := cli.server.CodeAction(ctx, &protocol.CodeActionParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: file.uri},
+		Range:        rng,
+		Context: protocol.CodeActionContext{
+			Only:        []protocol.CodeActionKind{protocol.SourceFixAll},
+			Diagnostics: diagnostics,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %v", file.uri.Path(), err)
+	}
+
+	// Fixes that span files, or that require a command,
+	// are not applied.
+	var edits []protocol.TextEdit
+nextAction:
+	for _, act := range actions {
+		if act.Disabled != nil || act.Command != nil || act.Edit == nil {
+			continue
+		}
+		var actEdits []protocol.TextEdit
+		for _, c := range act.Edit.DocumentChanges {
+			tde := c.TextDocumentEdit
+			if tde == nil || tde.TextDocument.URI != file.uri {
+				continue nextAction
+			}
+			actEdits = append(actEdits, protocol.AsTextEdits(tde.Edits)...)
+		}
+		for _, x := range actEdits {
+			for _, y := range edits {
+				if protocol.Intersect(x.Range, y.Range) {
+					continue nextAction // conflict
+				}
+			}
+		}
+		edits = append(edits, actEdits...)
+	}
+	return applyTextEdits(file.mapper, edits, flags)
+}
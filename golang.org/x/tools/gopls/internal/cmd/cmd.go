@@ -8,6 +8,7 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"flag"
 	"fmt"
@@ -22,6 +23,7 @@ import (
 	"time"
 
 	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/golang"
 	"golang.org/x/tools/gopls/internal/lsprpc"
 	"golang.org/x/tools/gopls/internal/protocol"
 	protocolcommand "golang.org/x/tools/gopls/internal/protocol/command"
@@ -85,6 +87,7 @@ type EditFlags struct {
 	Preserve bool `flag:"preserve" help:"with -write, make copies of original files"`
 	Diff     bool `flag:"d,diff" help:"display diffs instead of edited file content"`
 	List     bool `flag:"l,list" help:"display names of edited files"`
+	Confirm  bool `flag:"confirm" help:"with -write, display a summary of the edits and ask for confirmation before writing them"`
 }
 
 // RemoteFlags defines the set of flags for the forward mode.
@@ -299,8 +302,10 @@ func (app *application) featureCommands() []command {
 		&foldingRanges{app: app},
 		&format{app: app},
 		&headlessMCP{app: app},
+		&hiddenDiagnostics{app: app},
 		&highlight{app: app},
 		&implementation{app: app},
+		&importGraph{app: app},
 		&imports{app: app},
 		newRemote(app),
 		&links{app: app},
@@ -324,6 +329,11 @@ func (app *application) connect(ctx context.Context) (*client, *cache.Session, e
 		return nil, nil, fmt.Errorf("finding workdir: %v", err)
 	}
 	options := settings.DefaultOptions(app.options)
+	// A command-line session is too short-lived to benefit from
+	// the fallback polling file watcher.
+	if options.FileWatcher == settings.FileWatcherAuto {
+		options.FileWatcher = settings.FileWatcherOff
+	}
 	client := newClient(app)
 	var (
 		svr  protocol.Server
@@ -421,6 +431,8 @@ type client struct {
 
 	filesMu sync.Mutex // guards files map
 	files   map[protocol.DocumentURI]*cmdFile
+
+	stdin *bufio.Reader // answers to confirmEdit; buffered input must not be lost between calls
 }
 
 // cmdFile represents an open file in the gopls command LSP client.
@@ -437,6 +449,7 @@ func newClient(app *application) *client {
 		app:     app,
 		files:   make(map[protocol.DocumentURI]*cmdFile),
 		iwlDone: make(chan struct{}),
+		stdin:   bufio.NewReader(os.Stdin),
 	}
 }
 
@@ -539,6 +552,9 @@ func (cli *client) ApplyEdit(ctx context.Context, p *protocol.ApplyWorkspaceEdit
 //   - changedFiles in ../test/marker/marker_test.go for the golden-file capturing variant
 //   - applyWorkspaceEdit in ../test/integration/fake/editor.go for the Editor variant
 func (cli *client) applyWorkspaceEdit(wsedit *protocol.WorkspaceEdit) error {
+	if err := cli.confirmEdit(wsedit); err != nil {
+		return err
+	}
 
 	create := func(uri protocol.DocumentURI, content []byte) error {
 		edits := []diff.Edit{{Start: 0, End: 0, New: string(content)}}
@@ -597,6 +613,55 @@ func (cli *client) applyWorkspaceEdit(wsedit *protocol.WorkspaceEdit) error {
 	return nil
 }
 
+// confirmEdit displays a summary of the workspace edit, and asks the
+// user to confirm it, if the -confirm and -write flags are set. It
+// returns an error if the user does not confirm the edit.
+//
+// The summary is computed by the client, not by the gopls.preview_edit
+// command, as the edit may be that of an ApplyEdit downcall, during
+// which the server cannot be called.
+func (cli *client) confirmEdit(wsedit *protocol.WorkspaceEdit) error {
+	flags := cli.app.editFlags
+	if !(flags.Confirm && flags.Write) {
+		return nil
+	}
+	preview, err := golang.PreviewEdit(wsedit, func(uri protocol.DocumentURI) ([]byte, error) {
+		f := cli.getFile(uri)
+		if f.err != nil {
+			return nil, f.err
+		}
+		return f.mapper.Content, nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(preview.Files) == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
+	for _, file := range preview.Files {
+		name := file.URI.Path()
+		if file.NewURI != "" {
+			name += " -> " + file.NewURI.Path()
+		}
+		fmt.Fprintf(w, "%s\t%s\t+%d -%d\n", name, file.Kind, file.Added, file.Deleted)
+	}
+	w.Flush()
+	fmt.Printf("%d files changed, +%d -%d\n", len(preview.Files), preview.Added, preview.Deleted)
+	if !flags.Diff {
+		fmt.Print(preview.Diff)
+	}
+
+	fmt.Fprint(os.Stderr, "Apply these edits? [y/N] ")
+	answer, _ := cli.stdin.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("edits not applied")
+}
+
 // applyTextEdits applies a list of edits to the mapper file content,
 // using the preferred edit mode. It is a no-op if there are no edits.
 func applyTextEdits(mapper *protocol.Mapper, edits []protocol.TextEdit, flags *EditFlags) error {
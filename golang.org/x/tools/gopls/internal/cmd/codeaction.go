@@ -202,6 +202,9 @@ func (cmd *codeaction) Run(ctx context.Context, args ...string) error {
 						edits = append(edits, protocol.AsTextEdits(tde.Edits)...)
 					}
 				}
+				if err := cli.confirmEdit(&protocol.WorkspaceEdit{Changes: map[protocol.DocumentURI][]protocol.TextEdit{uri: edits}}); err != nil {
+					return err
+				}
 				return applyTextEdits(file.mapper, edits, cmd.app.editFlags)
 			}
 			return nil
@@ -9,7 +9,10 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"go/parser"
+	"go/token"
 	"os"
+	"slices"
 	"strings"
 
 	"golang.org/x/tools/gopls/internal/protocol"
@@ -18,17 +21,26 @@ import (
 
 // A definitionJSON is the result of a 'definition' query.
 type definitionJSON struct {
-	Span        span   `json:"span"`        // span of the definition
-	Description string `json:"description"` // description of the denoted object
+	Span        span              `json:"span"`            // span of the definition
+	Description string            `json:"description"`     // description of the denoted object
+	Stack       []declarationJSON `json:"stack,omitempty"` // enclosing declarations, innermost first (-stack only)
+}
+
+// A declarationJSON describes a declaration enclosing a definition.
+type declarationJSON struct {
+	Span   span   `json:"span"`             // span of the declared name
+	Kind   string `json:"kind"`             // symbol kind, such as "Function" or "Struct"
+	Name   string `json:"name"`             // name, such as "(T).M" for a method
+	Detail string `json:"detail,omitempty"` // signature or type, if any
 }
 
 // These constant is printed in the help, and then used in a test to verify the
 // help is still valid.
 // They refer to "Set" in "flag.FlagSet" from the DetailedHelp method below.
 const (
-	exampleLine   = 44
+	exampleLine   = 59
 	exampleColumn = 47
-	exampleOffset = 1270
+	exampleOffset = 2126
 )
 
 // definition implements the definition verb for gopls.
@@ -37,6 +49,7 @@ type definition struct {
 
 	JSON              bool `flag:"json" help:"emit output in JSON format"`
 	MarkdownSupported bool `flag:"markdown" help:"support markdown in responses"`
+	Stack             bool `flag:"stack" help:"also show the chain of declarations enclosing the definition"`
 }
 
 func (d *definition) Name() string      { return "definition" }
@@ -50,6 +63,12 @@ Example: show the definition of the identifier at syntax at offset %[1]v in this
 	$ gopls definition internal/cmd/definition.go:%[1]v:%[2]v
 	$ gopls definition internal/cmd/definition.go:#%[3]v
 
+With -stack, the definition is followed by the chain of declarations
+that enclose it, innermost first: for example, a field, its struct
+type, and its package; or a method, its receiver type, and its package.
+A local declaration has no entry of its own; the chain starts with
+its enclosing function.
+
 definition-flags:
 `, exampleLine, exampleColumn, exampleOffset)
 	printFlagDefaults(f)
@@ -122,6 +141,12 @@ func (d *definition) Run(ctx context.Context, args ...string) error {
 		Span:        definition,
 		Description: description,
 	}
+	if d.Stack {
+		result.Stack, err = declarationStack(ctx, cli, file, locs[0].Range)
+		if err != nil {
+			return fmt.Errorf("%v: %v", from, err)
+		}
+	}
 	if d.JSON {
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "\t")
@@ -132,5 +157,89 @@ func (d *definition) Run(ctx context.Context, args ...string) error {
 		fmt.Printf(": defined here as %s", result.Description)
 	}
 	fmt.Printf("\n")
+	for _, decl := range result.Stack {
+		fmt.Printf("\t%v: %s %s", decl.Span, decl.Kind, decl.Name)
+		if decl.Detail != "" {
+			fmt.Printf(" %s", decl.Detail)
+		}
+		fmt.Printf("\n")
+	}
 	return nil
 }
+
+// declarationStack returns the chain of declarations of file that
+// enclose rng, innermost first, ending with the package.
+func declarationStack(ctx context.Context, cli *client, file *cmdFile, rng protocol.Range) ([]declarationJSON, error) {
+	syms, err := cli.server.DocumentSymbol(ctx, &protocol.DocumentSymbolParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: file.uri},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var topLevel []protocol.DocumentSymbol
+	for _, s := range syms {
+		if m, ok := s.(map[string]any); ok {
+			if s, err = mapToSymbol(m); err != nil {
+				return nil, err
+			}
+		}
+		if s, ok := s.(protocol.DocumentSymbol); ok {
+			topLevel = append(topLevel, s)
+		}
+	}
+
+	// Find the symbols enclosing rng, outermost first.
+	var chain []protocol.DocumentSymbol
+	for children := topLevel; ; {
+		i := slices.IndexFunc(children, func(s protocol.DocumentSymbol) bool {
+			return protocol.ComparePosition(s.Range.Start, rng.Start) <= 0 &&
+				protocol.ComparePosition(rng.End, s.Range.End) <= 0
+		})
+		if i < 0 {
+			break
+		}
+		chain = append(chain, children[i])
+		children = children[i].Children
+	}
+
+	// A method is enclosed by its receiver type, if declared in the same file.
+	if len(chain) > 0 && chain[0].Kind == protocol.Method {
+		recv, _, _ := strings.Cut(strings.TrimPrefix(chain[0].Name, "("), ")")
+		recv = strings.TrimPrefix(recv, "*")
+		recv, _, _ = strings.Cut(recv, "[") // type parameters
+		if i := slices.IndexFunc(topLevel, func(s protocol.DocumentSymbol) bool { return s.Name == recv }); i >= 0 {
+			chain = slices.Insert(chain, 0, topLevel[i])
+		}
+	}
+
+	var stack []declarationJSON
+	for _, s := range slices.Backward(chain) {
+		sp, err := file.rangeSpan(s.SelectionRange)
+		if err != nil {
+			return nil, err
+		}
+		stack = append(stack, declarationJSON{
+			Span:   sp,
+			Kind:   fmt.Sprint(s.Kind),
+			Name:   s.Name,
+			Detail: s.Detail,
+		})
+	}
+
+	// The package clause encloses all declarations.
+	f, err := parser.ParseFile(token.NewFileSet(), file.uri.Path(), file.mapper.Content, parser.PackageClauseOnly)
+	if err != nil {
+		return nil, err
+	}
+	start := int(f.Name.Pos()) - 1 // file base is 1
+	sp, err := file.offsetSpan(start, start+len(f.Name.Name))
+	if err != nil {
+		return nil, err
+	}
+	stack = append(stack, declarationJSON{
+		Span: sp,
+		Kind: fmt.Sprint(protocol.Package),
+		Name: f.Name.Name,
+	})
+	return stack, nil
+}
@@ -67,6 +67,9 @@ func (c *format) Run(ctx context.Context, args ...string) error {
 		if err != nil {
 			return fmt.Errorf("%v: %v", spn, err)
 		}
+		if err := cli.confirmEdit(&protocol.WorkspaceEdit{Changes: map[protocol.DocumentURI][]protocol.TextEdit{loc.URI: edits}}); err != nil {
+			return err
+		}
 		if err := applyTextEdits(file.mapper, edits, c.app.editFlags); err != nil {
 			return err
 		}
@@ -0,0 +1,79 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"golang.org/x/tools/gopls/internal/protocol"
+	protocolcommand "golang.org/x/tools/gopls/internal/protocol/command"
+)
+
+// hiddenDiagnostics implements the hidden_diags verb for gopls.
+type hiddenDiagnostics struct {
+	app *application
+}
+
+func (h *hiddenDiagnostics) Name() string      { return "hidden_diags" }
+func (h *hiddenDiagnostics) Parent() string    { return h.app.Name() }
+func (h *hiddenDiagnostics) Usage() string     { return "<filename>" }
+func (h *hiddenDiagnostics) ShortHelp() string { return "show diagnostics that gopls hides for a file" }
+func (h *hiddenDiagnostics) DetailedHelp(f *flag.FlagSet) {
+	fmt.Fprint(f.Output(), `
+The hidden_diags command prints the diagnostics of the specified
+file that gopls computes but does not report to an editor in which the
+file is closed, each followed by the reason it is suppressed: for
+example, the file does not belong to a workspace package, analyzers do
+not run because no file of its package is open, or the file is
+generated and the analysisSkipGenerated setting suppresses its
+analysis diagnostics.
+
+Example: find out why gopls does not report a problem in a file:
+
+	$ gopls hidden_diags internal/cmd/check.go
+`)
+	printFlagDefaults(f)
+}
+
+func (h *hiddenDiagnostics) Run(ctx context.Context, args ...string) error {
+	if len(args) != 1 {
+		return commandLineErrorf("hidden_diags expects 1 argument")
+	}
+	cli, _, err := h.app.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer cli.terminate(ctx)
+
+	file := cli.getFile(protocol.URIFromPath(args[0]))
+	if file.err != nil {
+		return file.err
+	}
+	cmd := protocolcommand.NewHiddenDiagnosticsCommand("", protocolcommand.URIArg{URI: file.uri})
+	res, err := executeCommand(ctx, cli.server, cmd)
+	if err != nil {
+		return err
+	}
+	// Round-trip through JSON so that this works in -remote mode too.
+	data, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	var result protocolcommand.HiddenDiagnosticsResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	for _, diag := range result.Diagnostics {
+		spn, err := file.rangeSpan(diag.Range)
+		if err != nil {
+			return fmt.Errorf("could not convert position %v for %q", diag.Range, diag.Message)
+		}
+		fmt.Printf("%v: %v\n\t(%s)\n", spn, diag.Message, diag.Reason)
+	}
+	return nil
+}
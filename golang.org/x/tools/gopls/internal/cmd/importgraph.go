@@ -0,0 +1,95 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/tools/gopls/internal/protocol"
+	protocolcommand "golang.org/x/tools/gopls/internal/protocol/command"
+)
+
+// importGraph implements the importgraph verb for gopls.
+type importGraph struct {
+	app *application
+
+	Format string `flag:"format" help:"output format: dot or json"`
+}
+
+func (g *importGraph) Name() string      { return "importgraph" }
+func (g *importGraph) Parent() string    { return g.app.Name() }
+func (g *importGraph) Usage() string     { return "[importgraph-flags] [<file or directory>]" }
+func (g *importGraph) ShortHelp() string { return "print the import graph of the workspace" }
+func (g *importGraph) DetailedHelp(f *flag.FlagSet) {
+	fmt.Fprint(f.Output(), `
+The importgraph command prints the import graph of the workspace
+packages of the view containing the given file or directory (by
+default, the go.mod file of the current directory).
+
+Packages that participate in import cycles are highlighted, and
+each node is sized by the number of lines in its package.
+
+Example: render the graph of the current module as SVG:
+
+	$ gopls importgraph | dot -Tsvg > imports.svg
+
+importgraph-flags:
+`)
+	printFlagDefaults(f)
+}
+
+func (g *importGraph) Run(ctx context.Context, args ...string) error {
+	if len(args) > 1 {
+		return commandLineErrorf("importgraph expects at most 1 argument")
+	}
+	switch g.Format {
+	case "", "dot", "json":
+	default:
+		return commandLineErrorf("invalid -format %q (want dot or json)", g.Format)
+	}
+
+	filename := "."
+	if len(args) == 1 {
+		filename = args[0]
+	}
+	if info, err := os.Stat(filename); err == nil && info.IsDir() {
+		filename = filepath.Join(filename, "go.mod")
+	}
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return err
+	}
+
+	cli, _, err := g.app.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer cli.terminate(ctx)
+
+	cmd := protocolcommand.NewImportGraphCommand("", protocolcommand.ImportGraphArgs{
+		URI:    protocol.URIFromPath(abs),
+		Format: g.Format,
+	})
+	res, err := executeCommand(ctx, cli.server, cmd)
+	if err != nil {
+		return err
+	}
+	// Round-trip through JSON so that this works in -remote mode too.
+	data, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	var result protocolcommand.ImportGraphResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	fmt.Println(result.Output)
+	return nil
+}
@@ -75,5 +75,8 @@ func (t *imports) Run(ctx context.Context, args ...string) error {
 			}
 		}
 	}
+	if err := cli.confirmEdit(&protocol.WorkspaceEdit{Changes: map[protocol.DocumentURI][]protocol.TextEdit{uri: edits}}); err != nil {
+		return err
+	}
 	return applyTextEdits(file.mapper, edits, t.app.editFlags)
 }
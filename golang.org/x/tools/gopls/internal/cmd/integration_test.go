@@ -34,12 +34,14 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
 
 	"golang.org/x/tools/gopls/internal/cmd"
 	"golang.org/x/tools/gopls/internal/debug"
 	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
 	"golang.org/x/tools/gopls/internal/util/bug"
 	"golang.org/x/tools/gopls/internal/version"
 	"golang.org/x/tools/internal/testenv"
@@ -174,6 +176,74 @@ var _ = ioutil.ReadFile
 	}
 }
 
+// TestCheckFix tests the -fix and -diff flags of the 'check' subcommand (check.go).
+func TestCheckFix(t *testing.T) {
+	t.Parallel()
+
+	tree := writeTree(t, `
+-- go.mod --
+module example.com
+go 1.18
+
+-- a.go --
+package a
+
+type T struct{ x int }
+
+var _ = []T{T{1}, T{2}}
+
+func f(s []int) []int { return s[1:len(s)] }
+-- b.go --
+package a
+
+func g(s []int) []int { return s[2:len(s)] }
+`)
+	// -diff prints the fixes of the whole package without applying them.
+	{
+		res := gopls(t, tree, "check", "-diff", "./a.go")
+		res.checkExit(true)
+		res.checkStdout(`-var _ = \[\]T\{T\{1\}, T\{2\}\}`)
+		res.checkStdout(`\+var _ = \[\]T\{\{1\}, \{2\}\}`)
+		res.checkStdout(`\+func f\(s \[\]int\) \[\]int \{ return s\[1:\] \}`)
+		res.checkStdout(`\+func g\(s \[\]int\) \[\]int \{ return s\[2:\] \}`)
+		got, err := os.ReadFile(filepath.Join(tree, "a.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Contains(got, []byte("T{1}")) {
+			t.Errorf("check -diff modified a.go:\n%s", got)
+		}
+	}
+
+	// -fix applies them to all the files of the package.
+	{
+		res := gopls(t, tree, "check", "-fix", "./a.go")
+		res.checkExit(true)
+		for _, test := range []struct{ file, want string }{
+			{"a.go", `package a
+
+type T struct{ x int }
+
+var _ = []T{{1}, {2}}
+
+func f(s []int) []int { return s[1:] }
+`},
+			{"b.go", `package a
+
+func g(s []int) []int { return s[2:] }
+`},
+		} {
+			got, err := os.ReadFile(filepath.Join(tree, test.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.want {
+				t.Errorf("check -fix produced %s:\n%s\nwant:\n%s", test.file, got, test.want)
+			}
+		}
+	}
+}
+
 // TestCallHierarchy tests the 'call_hierarchy' subcommand (call_hierarchy.go).
 func TestCallHierarchy(t *testing.T) {
 	t.Parallel()
@@ -287,6 +357,12 @@ func f() {
 func g() {
 	f()
 }
+type T struct {
+	x int
+}
+func (t T) m() int {
+	return t.x
+}
 `)
 	// missing position
 	{
@@ -318,6 +394,29 @@ func g() {
 			}
 		}
 	}
+	// -stack
+	{
+		res := gopls(t, tree, "definition", "-stack", "a.go:13:11") // "x"
+		res.checkExit(true)
+		res.checkStdout("a.go:10:2-3: defined here as field x int")
+		res.checkStdout(`a.go:10:2-3: Field x int\n\t.*a.go:9:6-7: Struct T struct\{...\}\n\t.*a.go:1:9-10: Package a\n`)
+	}
+	// -stack, method
+	{
+		res := gopls(t, tree, "definition", "-stack", "-json", "a.go:12:12") // "m"
+		res.checkExit(true)
+		var defn cmd.DefinitionJSON
+		if res.toJSON(&defn) {
+			var got []string
+			for _, decl := range defn.Stack {
+				got = append(got, decl.Kind+" "+decl.Name)
+			}
+			want := []string{"Method (T).m", "Struct T", "Package a"}
+			if !slices.Equal(got, want) {
+				t.Errorf("Stack = %q, want %q", got, want)
+			}
+		}
+	}
 }
 
 // TestExecute tests the 'execute' subcommand (execute.go).
@@ -484,6 +583,28 @@ func f() {}
 	}
 }
 
+// TestHiddenDiags tests the 'hidden_diags' subcommand (hidden_diags.go).
+func TestHiddenDiags(t *testing.T) {
+	t.Parallel()
+
+	tree := writeTree(t, `
+-- go.mod --
+module example.com
+go 1.18
+
+-- a.go --
+package a
+
+import "fmt"
+
+var _ = fmt.Sprintf("%d", "s")
+`)
+	res := gopls(t, tree, "hidden_diags", "a.go")
+	res.checkExit(true)
+	// The printf diagnostic is hidden, since a.go is not open.
+	res.checkStdout(`a.go:5:22-24: fmt.Sprintf format %d has arg "s" of wrong type string\n\t\(analyzers run only on packages with an open file\)`)
+}
+
 // TestHighlight tests the 'highlight' subcommand (highlight.go).
 func TestHighlight(t *testing.T) {
 	t.Parallel()
@@ -591,6 +712,64 @@ func _() {
 	}
 }
 
+// TestImportGraph tests the 'importgraph' subcommand (importgraph.go).
+func TestImportGraph(t *testing.T) {
+	t.Parallel()
+
+	tree := writeTree(t, `
+-- go.mod --
+module example.com
+go 1.18
+
+-- a/a.go --
+package a
+
+import "example.com/b"
+
+var _ = b.B
+
+-- b/b.go --
+package b
+
+const B = 0
+`)
+	// bad format
+	{
+		res := gopls(t, tree, "importgraph", "-format=svg")
+		res.checkExit(false)
+		res.checkStderr("invalid -format")
+	}
+	// default: dot
+	{
+		res := gopls(t, tree, "importgraph")
+		res.checkExit(true)
+		res.checkStdout(`^digraph "imports" {`)
+		res.checkStdout(`label="example.com/a"`)
+		res.checkStdout(`n0 -> n1;`)
+	}
+	// -format=json
+	{
+		res := gopls(t, tree, "importgraph", "-format=json")
+		res.checkExit(true)
+		var graph struct {
+			Packages []struct {
+				Path    string
+				Lines   int
+				Imports []string
+			}
+		}
+		if res.toJSON(&graph) {
+			if got, want := len(graph.Packages), 2; got != want {
+				t.Fatalf("got %d packages, want %d", got, want)
+			}
+			a := graph.Packages[0]
+			if a.Path != "example.com/a" || a.Lines != 6 || !slices.Equal(a.Imports, []string{"example.com/b"}) {
+				t.Errorf("got package %+v, want example.com/a with 6 lines importing example.com/b", a)
+			}
+		}
+	}
+}
+
 // TestLinks tests the 'links' subcommand (links.go).
 func TestLinks(t *testing.T) {
 	t.Parallel()
@@ -788,6 +967,21 @@ func oldname() {}
 		res.checkStdout(regexp.QuoteMeta("-func oldname() {}"))
 		res.checkStdout(regexp.QuoteMeta("+func newname() {}"))
 	}
+	// -confirm without an answer: summary, but no edit
+	{
+		res := gopls(t, tree, "rename", "-w", "-confirm", "a.go:2:9", "newname")
+		res.checkExit(false)
+		res.checkStdout(`a.go +edit +\+1 -1`)
+		res.checkStdout(regexp.QuoteMeta("1 files changed, +1 -1"))
+		res.checkStderr("edits not applied")
+		data, err := os.ReadFile(filepath.Join(tree, "a.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), "oldname") {
+			t.Errorf("a.go was edited without confirmation:\n%s", data)
+		}
+	}
 }
 
 // TestSymbols tests the 'symbols' subcommand (symbols.go).
@@ -869,6 +1063,8 @@ go 1.18
 
 -- a.go --
 package a
+
+func _(x int) { x = x } // self-assignment
 -- b/b.go --
 package b
 -- testdata/foo.go --
@@ -956,6 +1152,45 @@ package foo
 		}
 	}
 
+	// Check that -analysis reports the diagnostics of each analyzer.
+	{
+		res4 := gopls(t, tree, "stats", "-analysis")
+		res4.checkExit(true)
+
+		var stats4 cmd.StatsJSON
+		if err := json.Unmarshal([]byte(res4.stdout), &stats4); err != nil {
+			t.Fatalf("failed to unmarshal JSON output of stats command: %v", err)
+		}
+		i := slices.IndexFunc(stats4.Analyzers, func(a command.AnalyzerStats) bool { return a.Name == "assign" })
+		if i < 0 {
+			t.Errorf("Analyzers does not contain assign. Got: %+v", stats4.Analyzers)
+		} else if got := stats4.Analyzers[i].Diagnostics; got != 1 {
+			t.Errorf("assign analyzer reported %d diagnostics, want 1", got)
+		}
+		if len(stats.Analyzers) > 0 {
+			t.Errorf("Got Analyzers without -analysis: %+v", stats.Analyzers)
+		}
+
+		res5 := gopls(t, tree, "stats", "-analysis", "-table")
+		res5.checkExit(true)
+		res5.checkStdout(`ANALYZER +TIME +DIAGNOSTICS`)
+		res5.checkStdout(`assign +\S+ +1\n`)
+
+		// The results of the first run are now in the file cache,
+		// but the running times are those recorded when computing them.
+		res6 := gopls(t, tree, "stats", "-analysis")
+		res6.checkExit(true)
+		var stats6 cmd.StatsJSON
+		if err := json.Unmarshal([]byte(res6.stdout), &stats6); err != nil {
+			t.Fatalf("failed to unmarshal JSON output of stats command: %v", err)
+		}
+		for _, a := range stats6.Analyzers {
+			if a.Name == "assign" && a.Duration == "0s" {
+				t.Errorf("assign analyzer took 0s with a warm file cache")
+			}
+		}
+	}
+
 	// Check that -anon suppresses fields containing non-zero user information.
 	{
 		res3 := goplsWithEnv(t, tree, []string{"GOPACKAGESDRIVER=off"}, "stats", "-anon")
@@ -1024,7 +1259,9 @@ type C struct{}
 		res := gopls(t, tree, "codeaction", "-kind=source", "a/a.go")
 		res.checkExit(true)
 		got := res.stdout
-		want := `command	"Browse documentation for package a" [source.doc]` +
+		want := `command	"Add doc.go for package a" [source.addPackageDoc]` +
+			"\n" +
+			`command	"Browse documentation for package a" [source.doc]` +
 			"\n" +
 			`command	"Split package \"a\"" [source.splitPackage]` +
 			"\n" +
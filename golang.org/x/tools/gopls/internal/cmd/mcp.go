@@ -11,6 +11,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -23,10 +24,12 @@ import (
 type headlessMCP struct {
 	app *application
 
-	Address      string `flag:"listen" help:"the address on which to run the mcp server"`
-	Logfile      string `flag:"logfile" help:"filename to log to; if unset, logs to stderr"`
-	RPCTrace     bool   `flag:"rpc.trace" help:"print MCP rpc traces; cannot be used with -listen"`
-	Instructions bool   `flag:"instructions" help:"if set, print gopls' MCP instructions and exit"`
+	Address        string `flag:"listen" help:"the address on which to run the mcp server"`
+	AllowedOrigins string `flag:"allowed-origins" help:"with -listen, comma-separated list of browser origins (such as https://example.com), other than localhost, that may connect, or '*' for any"`
+	MaxRequestSize int64  `flag:"max-request-size" help:"maximum size in bytes of a request body with -listen, or of a message over stdio (default 4MiB)"`
+	Logfile        string `flag:"logfile" help:"filename to log to; if unset, logs to stderr"`
+	RPCTrace       bool   `flag:"rpc.trace" help:"print MCP rpc traces; cannot be used with -listen"`
+	Instructions   bool   `flag:"instructions" help:"if set, print gopls' MCP instructions and exit"`
 }
 
 func (m *headlessMCP) Name() string      { return "mcp" }
@@ -116,7 +119,7 @@ func (m *headlessMCP) Run(ctx context.Context, args ...string) error {
 	}
 
 	// TODO(hxjiang): enable file watcher based on the gopls setting.
-	w, err := filewatcher.New("fsnotify", nil, func(events []protocol.FileEvent) {
+	w, err := filewatcher.New("fsnotify", 0, nil, func(events []protocol.FileEvent) {
 		if len(events) == 0 {
 			return
 		}
@@ -208,7 +211,7 @@ func (m *headlessMCP) Run(ctx context.Context, args ...string) error {
 
 	if m.Address != "" {
 		countHeadlessMCPSSE.Inc()
-		return internalmcp.Serve(ctx, m.Address, &staticSessions{sess, cli.server}, false, watchRoots)
+		return internalmcp.Serve(ctx, m.Address, splitOrigins(m.AllowedOrigins), m.MaxRequestSize, &staticSessions{sess, cli.server}, false, watchRoots)
 	} else {
 		countHeadlessMCPStdIO.Inc()
 		var rpcLog io.Writer
@@ -216,7 +219,7 @@ func (m *headlessMCP) Run(ctx context.Context, args ...string) error {
 			rpcLog = log.Writer() // possibly redirected by -logfile above
 		}
 		log.Printf("Listening for MCP messages on stdin...")
-		return internalmcp.StartStdIO(ctx, sess, cli.server, rpcLog, watchRoots)
+		return internalmcp.StartStdIO(ctx, sess, cli.server, rpcLog, m.MaxRequestSize, watchRoots)
 	}
 }
 
@@ -239,3 +242,15 @@ func (s *staticSessions) Session(id string) (*cache.Session, protocol.Server) {
 	}
 	return nil, nil
 }
+
+// splitOrigins splits a comma-separated list of origins,
+// as accepted by the -allowed-origins flags.
+func splitOrigins(list string) []string {
+	var origins []string
+	for origin := range strings.SplitSeq(list, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
@@ -35,7 +35,9 @@ type serve struct {
 	Debug       string        `flag:"debug" help:"serve debug information on the supplied address"`
 
 	// MCP Server related configurations.
-	MCPAddress string `flag:"mcp.listen" help:"experimental: address on which to listen for model context protocol connections. If port is localhost:0, pick a random port in localhost instead."`
+	MCPAddress        string `flag:"mcp.listen" help:"experimental: address on which to listen for model context protocol connections. If port is localhost:0, pick a random port in localhost instead."`
+	MCPAllowedOrigins string `flag:"mcp.allowed-origins" help:"experimental: comma-separated list of browser origins (such as https://example.com), other than localhost, that may connect to the model context protocol server, or '*' for any"`
+	MCPMaxRequestSize int64  `flag:"mcp.max-request-size" help:"experimental: maximum size in bytes of a request body to the model context protocol server (default 4MiB)"`
 
 	app *application
 }
@@ -109,7 +111,7 @@ func (s *serve) Run(ctx context.Context, args ...string) error {
 				}
 			}()
 
-			return mcp.Serve(ctx, s.MCPAddress, sessions, isDaemon, nil)
+			return mcp.Serve(ctx, s.MCPAddress, splitOrigins(s.MCPAllowedOrigins), s.MCPMaxRequestSize, sessions, isDaemon, nil)
 		})
 	}
 
@@ -16,6 +16,7 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"golang.org/x/tools/gopls/internal/filecache"
@@ -30,7 +31,9 @@ import (
 type stats struct {
 	app *application
 
-	Anon bool `flag:"anon" help:"hide any fields that may contain user names, file names, or source code"`
+	Anon     bool `flag:"anon" help:"hide any fields that may contain user names, file names, or source code"`
+	Analysis bool `flag:"analysis" help:"report the running time and number of diagnostics of each analyzer"`
+	Table    bool `flag:"table" help:"with -analysis, print the analyzers as a table instead of the JSON summary"`
 }
 
 func (s *stats) Name() string      { return "stats" }
@@ -48,8 +51,16 @@ By default, this command may include output that refers to the location or
 content of user code. When the -anon flag is set, fields that may refer to user
 code are hidden.
 
+When the -analysis flag is set, this command also runs the enabled analyzers,
+including any custom ones, over the workspace, and reports the total running
+time and number of diagnostics of each, to help identify slow analyzers. The
+running time of results already in the file cache is the time it took to compute
+them. With the -table flag, this breakdown is printed as a table in place of the JSON
+summary.
+
 Example:
   $ gopls stats -anon
+  $ gopls stats -analysis -table
 `)
 	printFlagDefaults(f)
 }
@@ -155,6 +166,29 @@ func (s *stats) Run(ctx context.Context, args ...string) error {
 		return err
 	}
 
+	if s.Analysis {
+		if _, err := do("Running analyzers", func() error {
+			analysisStats, err := executeCommand(ctx, cli.server, &protocol.Command{
+				Command: protocolcommand.AnalysisStats.String(),
+			})
+			if err != nil {
+				return err
+			}
+			stats.Analyzers = analysisStats.(protocolcommand.AnalysisStatsResult).Analyzers
+			return nil
+		}); err != nil {
+			return err
+		}
+		if s.Table {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "ANALYZER\tTIME\tDIAGNOSTICS\n")
+			for _, a := range stats.Analyzers {
+				fmt.Fprintf(w, "%s\t%s\t%d\n", a.Name, a.Duration, a.Diagnostics)
+			}
+			return w.Flush()
+		}
+	}
+
 	if _, err := do("Collecting directory info", func() error {
 		var err error
 		stats.DirStats, err = findDirStats()
@@ -177,6 +211,9 @@ func (s *stats) Run(ctx context.Context, args ...string) error {
 				continue
 			}
 			vf := v.FieldByName(f.Name)
+			if f.Name == "Analyzers" && !s.Analysis {
+				continue
+			}
 			if s.Anon && f.Tag.Get("anon") != "ok" && !vf.IsZero() {
 				// Fields that can be served with -anon must be explicitly marked as OK.
 				// But, if it's zero value, it's ok to print.
@@ -213,6 +250,7 @@ type statsJSON struct {
 	MemStats                     protocolcommand.MemStatsResult       `anon:"ok"`
 	WorkspaceStats               protocolcommand.WorkspaceStatsResult `anon:"ok"`
 	DirStats                     dirStats                             `anon:"ok"`
+	Analyzers                    []protocolcommand.AnalyzerStats      `anon:"ok"` // only with -analysis
 }
 
 type dirStats struct {
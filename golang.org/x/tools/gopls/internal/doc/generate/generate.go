@@ -8,6 +8,7 @@
 //	gopls/doc/analyzers.md  -- from linking gopls/internal/settings.DefaultAnalyzers
 //	gopls/doc/inlayHints.md -- from loading gopls/internal/settings.InlayHint
 //	gopls/internal/doc/api.json -- all of the above in a single value, for 'gopls api-json'
+//	gopls/doc/settings.schema.json -- a JSON schema of the settings, for editors
 //
 // Run it with this command:
 //
@@ -83,6 +84,7 @@ func doMain(write bool) (bool, error) {
 		{"doc/codelenses.md", rewriteCodeLenses},
 		{"doc/analyzers.md", rewriteAnalyzers},
 		{"doc/inlayHints.md", rewriteInlayHints},
+		{"doc/settings.schema.json", rewriteSettingsSchema},
 	} {
 		file := filepath.Join(goplsDir, f.name)
 		old, err := os.ReadFile(file)
@@ -564,6 +566,134 @@ func rewriteAPI(_ []byte, api *doc.API) ([]byte, error) {
 	return json.MarshalIndent(api, "", "\t")
 }
 
+// rewriteSettingsSchema generates a JSON schema (draft 2020-12) of the
+// user settings, which editors may use to validate and complete a
+// gopls configuration. Setting names are flat: the hierarchy used to
+// organize settings.md is for documentation only.
+func rewriteSettingsSchema(_ []byte, api *doc.API) ([]byte, error) {
+	properties := make(map[string]any)
+	for _, opt := range api.Options["User"] {
+		prop, err := optionSchema(opt.Type, opt)
+		if err != nil {
+			return nil, fmt.Errorf("option %s: %v", opt.Name, err)
+		}
+		prop["description"] = opt.Doc
+		if opt.Default != "" {
+			var def any
+			if err := json.Unmarshal([]byte(opt.Default), &def); err != nil {
+				return nil, fmt.Errorf("option %s: invalid default %s: %v", opt.Name, opt.Default, err)
+			}
+			prop["default"] = def
+		}
+		if opt.DeprecationMessage != "" || opt.Status == "deprecated" {
+			prop["deprecated"] = true
+			if opt.DeprecationMessage != "" {
+				prop["deprecationMessage"] = opt.DeprecationMessage
+			}
+		}
+		properties[opt.Name] = prop
+	}
+	schema := map[string]any{
+		"$schema":    "https://json-schema.org/draft/2020-12/schema",
+		"title":      "gopls settings",
+		"type":       "object",
+		"properties": properties,
+	}
+	data, err := json.MarshalIndent(schema, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// optionSchema returns the JSON schema for a value of the specified
+// type, using the syntax of [doc.Option.Type]. The option provides
+// the enum values and keys, if any.
+func optionSchema(typ string, opt *doc.Option) (map[string]any, error) {
+	switch typ {
+	case "bool":
+		return map[string]any{"type": "boolean"}, nil
+	case "string", "time.Duration":
+		return map[string]any{"type": "string"}, nil
+	case "int", "int64":
+		return map[string]any{"type": "integer"}, nil
+	case "any":
+		return map[string]any{}, nil
+	case "enum":
+		// Enum values need not be strings (e.g. linksInHover),
+		// so the type is implied by the values alone.
+		var (
+			values = []any{}
+			docs   = []string{}
+		)
+		for _, v := range opt.EnumValues {
+			var value any
+			if err := json.Unmarshal([]byte(v.Value), &value); err != nil {
+				return nil, fmt.Errorf("invalid enum value %s: %v", v.Value, err)
+			}
+			values = append(values, value)
+			docs = append(docs, v.Doc)
+		}
+		schema := map[string]any{"enum": values}
+		if slices.ContainsFunc(docs, func(doc string) bool { return doc != "" }) {
+			schema["enumDescriptions"] = docs
+		}
+		return schema, nil
+	}
+	if elem, ok := strings.CutPrefix(typ, "[]"); ok {
+		items, err := optionSchema(elem, opt)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	}
+	if rest, ok := strings.CutPrefix(typ, "map["); ok {
+		key, value, ok := strings.Cut(rest, "]")
+		if !ok {
+			return nil, fmt.Errorf("invalid map type %q", typ)
+		}
+		valueSchema, err := optionSchema(value, opt)
+		if err != nil {
+			return nil, err
+		}
+		schema := map[string]any{"type": "object"}
+		if len(opt.EnumKeys.Keys) > 0 {
+			properties := make(map[string]any)
+			for _, k := range opt.EnumKeys.Keys {
+				name, err := strconv.Unquote(k.Name)
+				if err != nil {
+					return nil, fmt.Errorf("invalid enum key %s: %v", k.Name, err)
+				}
+				prop, err := optionSchema(value, opt)
+				if err != nil {
+					return nil, err
+				}
+				prop["description"] = k.Doc
+				if k.Default != "" {
+					var def any
+					if err := json.Unmarshal([]byte(k.Default), &def); err != nil {
+						return nil, fmt.Errorf("invalid default %s for key %s: %v", k.Default, k.Name, err)
+					}
+					prop["default"] = def
+				}
+				if k.Status == "deprecated" {
+					prop["deprecated"] = true
+				}
+				properties[name] = prop
+			}
+			schema["properties"] = properties
+		}
+		if key == "enum" {
+			// Keys of an enum map must be among the listed ones.
+			schema["additionalProperties"] = false
+		} else {
+			schema["additionalProperties"] = valueSchema
+		}
+		return schema, nil
+	}
+	return nil, fmt.Errorf("unsupported type %q", typ)
+}
+
 type optionsGroup struct {
 	title   string // dotted path (e.g. "ui.documentation")
 	final   string // final segment of title (e.g. "documentation")
@@ -11,3 +11,9 @@ package filewatcher
 func SetAfterAddHook(f func(string, error)) {
 	afterAddHook = f
 }
+
+// MatchGitignore reports whether the .gitignore file with the given contents,
+// in directory root, ignores the specified file.
+func MatchGitignore(gitignore, root, file string, isDir bool) bool {
+	return parseGitignore([]byte(gitignore)).match(root, file, isDir)
+}
@@ -7,6 +7,7 @@ package filewatcher
 import (
 	"fmt"
 	"log/slog"
+	"time"
 
 	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/gopls/internal/settings"
@@ -34,13 +35,16 @@ type Watcher interface {
 // The provided event handler is called sequentially with a batch of file events,
 // but the error handler is called concurrently. The watcher blocks until the
 // handler returns, so the handlers should be fast and non-blocking.
-func New(mode settings.FileWatcherMode, logger *slog.Logger, onEvents func([]protocol.FileEvent), onError func(error)) (Watcher, error) {
+//
+// The pollInterval applies only to the "poll" mode; if it is non-positive,
+// a default interval is used. The "auto" mode must be resolved by the
+// caller, since it depends on the capabilities of the client.
+func New(mode settings.FileWatcherMode, pollInterval time.Duration, logger *slog.Logger, onEvents func([]protocol.FileEvent), onError func(error)) (Watcher, error) {
 	switch mode {
 	case settings.FileWatcherPoll:
-		return NewPollWatcher(logger, onEvents, onError), nil
+		return NewPollWatcher(logger, pollInterval, onEvents, onError), nil
 	case settings.FileWatcherFSNotify:
 		return NewFSNotifyWatcher(logger, onEvents, onError)
 	}
-	// TODO(hxjiang): support "auto" mode.
 	return nil, fmt.Errorf("unknown FileWatcher mode: %q", mode)
 }
@@ -212,7 +212,7 @@ package foo
 						t.Errorf("error from watcher: %v", err)
 					}
 
-					w, err := filewatcher.New(mode, nil, eventsHandler, errHandler)
+					w, err := filewatcher.New(mode, 0, nil, eventsHandler, errHandler)
 					if err != nil {
 						t.Fatal(err)
 					}
@@ -341,7 +341,7 @@ func TestBrokenSymlink(t *testing.T) {
 	errHandler := func(err error) {
 		t.Errorf("error from watcher: %v", err)
 	}
-	w, err := filewatcher.New(settings.FileWatcherFSNotify, nil, eventsHandler, errHandler)
+	w, err := filewatcher.New(settings.FileWatcherFSNotify, 0, nil, eventsHandler, errHandler)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -542,7 +542,7 @@ func TestStress(t *testing.T) {
 
 				t.Errorf("error from watcher: %v", err)
 			}
-			w, err := filewatcher.New(mode, nil, eventsHandler, errHandler)
+			w, err := filewatcher.New(mode, 0, nil, eventsHandler, errHandler)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -590,3 +590,77 @@ func TestStress(t *testing.T) {
 		})
 	}
 }
+
+func TestGitignore(t *testing.T) {
+	const gitignore = `
+# build outputs
+/bin/
+*.pb.go
+*.txt
+!keep.txt
+**/node_modules/
+**/vendor/cache
+docs/*.md
+`
+	root := filepath.FromSlash("/root")
+	for _, tt := range []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"bin", true, true},
+		{"bin", false, false},  // directory-only pattern
+		{"a/bin", true, false}, // anchored pattern
+		{"x.txt", false, true},
+		{"a/b/x.txt", false, true},
+		{"a/keep.txt", false, false}, // negated
+		{"x.pb.go", false, false},    // Go files are never ignored
+		{"node_modules", true, true},
+		{"a/node_modules", true, true},
+		{"vendor/cache", true, true},
+		{"a/b/vendor/cache", true, true},
+		{"a/vendor", true, false},
+		{"docs/d.md", false, true},
+		{"a/docs/d.md", false, false},
+		{"main.go", false, false},
+	} {
+		file := filepath.Join(root, filepath.FromSlash(tt.path))
+		if got := filewatcher.MatchGitignore(gitignore, root, file, tt.isDir); got != tt.want {
+			t.Errorf("match(%q, isDir=%t) = %t, want %t", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestGitignoreBuildFiles(t *testing.T) {
+	const gitignore = `
+/gen/
+/out/
+`
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"gen/api/api.pb.go": "package api",
+		"gen/README":        "generated",
+		"out/a.txt":         "output",
+		"out/.cache/x.go":   "package x", // beneath a skipped directory
+	} {
+		file := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tt := range []struct {
+		path string
+		want bool
+	}{
+		{"gen", false}, // contains Go files
+		{"out", true},
+	} {
+		file := filepath.Join(root, tt.path)
+		if got := filewatcher.MatchGitignore(gitignore, root, file, true); got != tt.want {
+			t.Errorf("match(%q, isDir=true) = %t, want %t", tt.path, got, tt.want)
+		}
+	}
+}
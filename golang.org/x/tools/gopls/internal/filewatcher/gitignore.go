@@ -0,0 +1,166 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filewatcher
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// A gitignore holds the patterns of a .gitignore file.
+//
+// It supports the commonly used subset of the gitignore syntax: comments,
+// negation ("!"), directory-only patterns (trailing "/"), patterns anchored
+// to the root (containing a "/"), a leading "**/", and the wildcards of
+// [path.Match]. Nested .gitignore files, and "**" elsewhere in a pattern,
+// are not supported.
+//
+// Files that matter to the build (see [skipFile]) are never ignored, nor
+// are directories that contain them, since generated Go files are often
+// listed in .gitignore yet must still invalidate gopls' snapshots when
+// they change.
+//
+// The nil *gitignore matches nothing.
+type gitignore struct {
+	patterns []ignorePattern
+}
+
+type ignorePattern struct {
+	glob     string // pattern for path.Match
+	negate   bool   // pattern began with "!"
+	dirOnly  bool   // pattern ended with "/"
+	anchored bool   // glob is matched against the root-relative path, not the base name
+	anyDepth bool   // glob is matched against any trailing sequence of path segments
+}
+
+// readGitignore reads the .gitignore file in the root directory.
+// It returns nil if there is no such file or it cannot be read.
+func readGitignore(root string) *gitignore {
+	data, err := os.ReadFile(filepath.Join(root, ".gitignore"))
+	if err != nil {
+		return nil
+	}
+	return parseGitignore(data)
+}
+
+func parseGitignore(data []byte) *gitignore {
+	var ign gitignore
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var p ignorePattern
+		if rest, ok := strings.CutPrefix(line, "!"); ok {
+			p.negate = true
+			line = rest
+		}
+		if rest, ok := strings.CutSuffix(line, "/"); ok {
+			p.dirOnly = true
+			line = rest
+		}
+		if rest, ok := strings.CutPrefix(line, "**/"); ok {
+			line = rest
+			p.anyDepth = strings.Contains(line, "/")
+		} else if strings.Contains(line, "/") {
+			p.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		if _, err := path.Match(line, ""); err != nil {
+			continue // malformed pattern
+		}
+		p.glob = line
+		ign.patterns = append(ign.patterns, p)
+	}
+	if len(ign.patterns) == 0 {
+		return nil
+	}
+	return &ign
+}
+
+// match reports whether the file at the given absolute path, beneath
+// root, is ignored. As in git, the last matching pattern wins.
+//
+// An ignored directory is read to find out whether it contains files
+// that matter to the build, in which case it is not ignored.
+func (ign *gitignore) match(root, file string, isDir bool) bool {
+	if ign == nil || !isDir && !skipFile(filepath.Base(file)) {
+		return false
+	}
+	rel, err := filepath.Rel(root, file)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	base := path.Base(rel)
+
+	ignored := false
+	for _, p := range ign.patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		var ok bool
+		switch {
+		case p.anchored:
+			ok, _ = path.Match(p.glob, rel)
+		case p.anyDepth:
+			ok = matchAnyDepth(p.glob, rel)
+		default:
+			ok, _ = path.Match(p.glob, base)
+		}
+		if ok {
+			ignored = !p.negate
+		}
+	}
+	return ignored && !(isDir && containsBuildFiles(file))
+}
+
+// containsBuildFiles reports whether the directory dir, or any of its
+// subdirectories that the watchers do not skip (see [skipDir]),
+// contains a file that matters to the build.
+func containsBuildFiles(dir string) bool {
+	found := false
+	filepath.WalkDir(dir, func(path string, dirent fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // ignore unreadable entries, as the watchers do
+		}
+		if dirent.IsDir() {
+			if path != dir && skipDir(dirent.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !skipFile(dirent.Name()) {
+			found = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return found
+}
+
+// matchAnyDepth reports whether glob matches rel or any
+// suffix of rel that begins after a "/".
+func matchAnyDepth(glob, rel string) bool {
+	for {
+		if ok, _ := path.Match(glob, rel); ok {
+			return true
+		}
+		_, rest, ok := strings.Cut(rel, "/")
+		if !ok {
+			return false
+		}
+		rel = rest
+	}
+}
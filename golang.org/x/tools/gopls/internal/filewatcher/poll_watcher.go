@@ -41,17 +41,28 @@ Key lessons and design decisions from its implementation:
    state difference between scans.
 5. Multi-root: The watcher supports multiple independent root directories,
    each with its own independent state and persistence.
+6. Ignores: Each scan honors the .gitignore file at the root directory,
+   so that build outputs and vendored trees don't cost a stat per file.
+   Ignored directories that contain Go files, such as generated ones,
+   are scanned nonetheless.
 */
 
 // NewPollWatcher creates a new watcher that actively polls the file tree to
 // detect changes. It uses an adaptive back-off strategy to reduce scans of the
 // file tree and save battery; it is thus only eventually consistent.
-func NewPollWatcher(log *slog.Logger, onEvents func([]protocol.FileEvent), onError func(error)) *pollWatcher {
+//
+// The interval is the baseline polling frequency; if it is non-positive,
+// [pollInterval] is used.
+func NewPollWatcher(log *slog.Logger, interval time.Duration, onEvents func([]protocol.FileEvent), onError func(error)) *pollWatcher {
 	if log != nil {
 		log = log.With("watcher", "poll")
 	}
+	if interval <= 0 {
+		interval = pollInterval
+	}
 	w := &pollWatcher{
 		log:      log,
+		interval: interval,
 		onEvents: onEvents,
 		onError:  onError,
 		ctx:      context.Background(),
@@ -63,7 +74,8 @@ func NewPollWatcher(log *slog.Logger, onEvents func([]protocol.FileEvent), onErr
 	return w
 }
 
-// pollInterval is the baseline polling frequency for the fallback file watcher.
+// pollInterval is the default baseline polling frequency for the fallback
+// file watcher.
 //
 // A 1-second interval prioritizes Developer Experience by ensuring the editor
 // feels highly responsive to external file system changes (like branch
@@ -73,6 +85,7 @@ const pollInterval = time.Second
 
 type pollWatcher struct {
 	log      *slog.Logger
+	interval time.Duration // baseline polling frequency
 	onEvents func([]protocol.FileEvent)
 	onError  func(error)
 
@@ -198,7 +211,7 @@ func (w *pollWatcher) Poke() {
 // A call to [pollWatcher.Poke] interrupts any long sleep and resets the timer
 // to the fast polling interval.
 func (w *pollWatcher) loop() {
-	delay := pollInterval
+	delay := w.interval
 	timer := time.NewTimer(delay)
 	defer timer.Stop()
 
@@ -208,7 +221,7 @@ func (w *pollWatcher) loop() {
 			return
 
 		case <-w.poke:
-			delay = pollInterval
+			delay = w.interval
 			timer.Reset(delay)
 
 		case <-timer.C:
@@ -258,7 +271,7 @@ func (w *pollWatcher) loop() {
 
 			if changed {
 				// If changes found, keep polling fast for a bit.
-				delay = pollInterval
+				delay = w.interval
 			} else {
 				// No changes, backoff.
 				delay = min(delay*2, 2*time.Hour)
@@ -277,10 +290,16 @@ func (w *pollWatcher) loop() {
 //
 // To prevent triggering massive workspace reloads in the LSP, scan explicitly
 // ignores modification time changes on the root directory itself.
+//
+// Files and directories matched by the root's .gitignore file are skipped,
+// unless they matter to the build or contain files that do.
+// The .gitignore file is re-read on each scan, so edits to it take effect
+// without restarting the watcher.
 func scan(root string, oldState fileState) ([]protocol.FileEvent, fileState, error) {
 	var (
 		newState = make(fileState)
 		events   []protocol.FileEvent
+		ignore   = readGitignore(root)
 	)
 	addEvent := func(typ protocol.FileChangeType, path string) {
 		events = append(events, protocol.FileEvent{
@@ -305,6 +324,12 @@ func scan(root string, oldState fileState) ([]protocol.FileEvent, fileState, err
 		if !dirent.IsDir() && skipFile(dirent.Name()) {
 			return nil
 		}
+		if ignore.match(root, path, dirent.IsDir()) {
+			if dirent.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 
 		info, err := dirent.Info()
 		if err != nil {
@@ -0,0 +1,269 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+// This file defines the computation of the tests affected by the
+// unsaved changes to a file, used by the affected_tests code lens.
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/metadata"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
+	"golang.org/x/tools/internal/astutil"
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+// AffectedTests returns the tests and benchmarks of the package of fh
+// that may be affected by the unsaved changes to fh: those that were
+// changed, or that refer to a changed package-level declaration of
+// the file, either directly or from the body of a function of the
+// package that they call.
+//
+// A declaration is changed if its text differs from that of the
+// declaration of the same name in the file on disk, or if it has no
+// such counterpart. Changes to doc comments are ignored.
+func AffectedTests(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle) (command.AffectedTestsResult, error) {
+	var result command.AffectedTestsResult
+	if fh.SameContentsOnDisk() {
+		return result, nil
+	}
+	mp, err := snapshot.NarrowestMetadataForFile(ctx, fh.URI())
+	if err != nil {
+		return result, err
+	}
+	changed, err := changedDecls(ctx, snapshot, fh, mp.PkgPath)
+	if err != nil || len(changed) == 0 {
+		return result, err
+	}
+
+	// Type-check the test variants of the package, including any
+	// external test package, to find their tests.
+	pkgPath := mp.PkgPath
+	if mp.ForTest != "" {
+		pkgPath = mp.ForTest
+	}
+	md, err := snapshot.LoadMetadataGraph(ctx)
+	if err != nil {
+		return result, err
+	}
+	var ids []PackageID
+	for _, path := range []PackagePath{pkgPath, pkgPath + "_test"} {
+		for _, mp := range md.ForPackagePath[path] {
+			if mp.ForTest == pkgPath && !mp.IsIntermediateTestVariant() {
+				ids = append(ids, mp.ID)
+			}
+		}
+	}
+	pkgs, err := snapshot.TypeCheck(ctx, ids...)
+	if err != nil {
+		return result, err
+	}
+
+	// Index the function declarations of the test packages,
+	// so that the references of a call can be followed.
+	type funcDecl struct {
+		decl *ast.FuncDecl
+		info *types.Info
+	}
+	funcs := make(map[string]funcDecl)
+	for _, pkg := range pkgs {
+		for _, pgf := range pkg.CompiledGoFiles() {
+			for _, decl := range pgf.File.Decls {
+				if decl, ok := decl.(*ast.FuncDecl); ok && decl.Body != nil {
+					if obj, ok := pkg.TypesInfo().Defs[decl.Name].(*types.Func); ok {
+						funcs[objectKey(obj)] = funcDecl{decl, pkg.TypesInfo()}
+					}
+				}
+			}
+		}
+	}
+
+	// refersToChange reports whether the body of fn refers to
+	// a changed declaration, either directly or, if indirect,
+	// through one of the functions it refers to.
+	var refersToChange func(fn funcDecl, indirect bool) bool
+	refersToChange = func(fn funcDecl, indirect bool) bool {
+		found := false
+		ast.Inspect(fn.decl.Body, func(n ast.Node) bool {
+			id, ok := n.(*ast.Ident)
+			if !ok || found {
+				return !found
+			}
+			obj := fn.info.Uses[id]
+			if obj == nil || obj.Pkg() == nil {
+				return true
+			}
+			key := objectKey(obj)
+			if changed[key] {
+				found = true
+			} else if callee, ok := funcs[key]; ok && indirect && callee.decl != fn.decl {
+				found = refersToChange(callee, false)
+			}
+			return !found
+		})
+		return found
+	}
+
+	for _, pkg := range pkgs {
+		for _, pgf := range pkg.CompiledGoFiles() {
+			if !strings.HasSuffix(pgf.URI.Path(), "_test.go") {
+				continue
+			}
+			for _, decl := range pgf.File.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv != nil || fn.Body == nil {
+					continue
+				}
+				var names *[]string
+				if matchTestFunc(fn, pkg.TypesInfo(), testRe, "T") {
+					names = &result.Tests
+				} else if matchTestFunc(fn, pkg.TypesInfo(), benchmarkRe, "B") {
+					names = &result.Benchmarks
+				} else {
+					continue
+				}
+				obj := pkg.TypesInfo().Defs[fn.Name]
+				if changed[objectKey(obj)] || refersToChange(funcDecl{fn, pkg.TypesInfo()}, true) {
+					*names = append(*names, fn.Name.Name)
+					if result.TestFile == "" {
+						result.TestFile = pgf.URI
+					}
+				}
+			}
+		}
+	}
+	slices.Sort(result.Tests)
+	result.Tests = slices.Compact(result.Tests)
+	slices.Sort(result.Benchmarks)
+	result.Benchmarks = slices.Compact(result.Benchmarks)
+	return result, nil
+}
+
+// changedDecls returns the keys (see [objectKey]) of the
+// package-level declarations of fh whose text differs from that of
+// the file on disk.
+func changedDecls(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, pkgPath metadata.PackagePath) (map[string]bool, error) {
+	pgf, err := snapshot.ParseGo(ctx, fh, parsego.Full)
+	if err != nil {
+		return nil, err
+	}
+	var saved map[string][]byte
+	content, err := os.ReadFile(fh.URI().Path())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		savedFile, _ := parsego.Parse(ctx, token.NewFileSet(), fh.URI(), content, parsego.Full, false)
+		saved = declTexts(savedFile, pkgPath)
+	}
+	changed := make(map[string]bool)
+	for key, text := range declTexts(pgf, pkgPath) {
+		if old, ok := saved[key]; !ok || !bytes.Equal(old, text) {
+			changed[key] = true
+		}
+	}
+	return changed, nil
+}
+
+// declTexts returns the text of each package-level declaration of a
+// file, excluding its doc comment, indexed by its key.
+func declTexts(pgf *parsego.File, pkgPath metadata.PackagePath) map[string][]byte {
+	texts := make(map[string][]byte)
+	add := func(name string, node ast.Node) {
+		if name == "_" {
+			return
+		}
+		if text, err := pgf.PosText(node.Pos(), node.End()); err == nil {
+			texts[string(pkgPath)+"."+name] = text
+		}
+	}
+	for _, decl := range pgf.File.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			name := decl.Name.Name
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				_, rname, _ := astutil.UnpackRecv(decl.Recv.List[0].Type)
+				if rname == nil {
+					continue
+				}
+				name = rname.Name + "." + name
+			}
+			add(name, decl)
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					add(spec.Name.Name, spec)
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						add(name.Name, spec)
+					}
+				}
+			}
+		}
+	}
+	return texts
+}
+
+// objectKey returns a key that identifies a package-level object, or
+// a method, across the variants of its package: its package path and
+// name, qualified by the name of its receiver type for a method.
+// It returns "" for other objects.
+func objectKey(obj types.Object) string {
+	if obj.Pkg() == nil {
+		return ""
+	}
+	name := obj.Name()
+	if fn, ok := obj.(*types.Func); ok && fn.Signature().Recv() != nil {
+		_, named := typesinternal.ReceiverNamed(fn.Signature().Recv())
+		if named == nil {
+			return ""
+		}
+		name = named.Origin().Obj().Name() + "." + name
+	} else if obj.Parent() != obj.Pkg().Scope() {
+		return ""
+	}
+	return obj.Pkg().Path() + "." + name
+}
+
+// affectedTestsCodeLens returns a code lens, at the package clause of the
+// file, that runs the tests affected by its unsaved changes.
+func affectedTestsCodeLens(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle) ([]protocol.CodeLens, error) {
+	affected, err := AffectedTests(ctx, snapshot, fh)
+	if err != nil || affected.TestFile == "" {
+		return nil, err
+	}
+	pgf, err := snapshot.ParseGo(ctx, fh, parsego.Header)
+	if err != nil {
+		return nil, err
+	}
+	rng, err := pgf.PosRange(pgf.File.Package, pgf.File.Package)
+	if err != nil {
+		return nil, err
+	}
+	title := "run affected tests"
+	if len(affected.Tests) == 0 {
+		title = "run affected benchmarks"
+	}
+	cmd := command.NewRunTestsCommand(title, command.RunTestsArgs{
+		URI:        affected.TestFile,
+		Tests:      affected.Tests,
+		Benchmarks: affected.Benchmarks,
+	})
+	return []protocol.CodeLens{{Range: rng, Command: cmd}}, nil
+}
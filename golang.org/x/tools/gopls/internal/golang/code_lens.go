@@ -23,9 +23,11 @@ import (
 // CodeLensSources returns the supported sources of code lenses for Go files.
 func CodeLensSources() map[settings.CodeLensSource]cache.CodeLensSourceFunc {
 	return map[settings.CodeLensSource]cache.CodeLensSourceFunc{
-		settings.CodeLensGenerate:      goGenerateCodeLens, // commands: Generate
-		settings.CodeLensTest:          runTestCodeLens,    // commands: Test
-		settings.CodeLensRegenerateCgo: regenerateCgoLens,  // commands: RegenerateCgo
+		settings.CodeLensGenerate:      goGenerateCodeLens,    // commands: Generate
+		settings.CodeLensTest:          runTestCodeLens,       // commands: Test
+		settings.CodeLensRegenerateCgo: regenerateCgoLens,     // commands: RegenerateCgo
+		settings.CodeLensStructLayout:  structLayoutCodeLens,  // commands: StructLayout
+		settings.CodeLensAffectedTests: affectedTestsCodeLens, // commands: Test
 	}
 }
 
@@ -241,6 +241,7 @@ var codeActionProducers = [...]codeActionProducer{
 	{kind: protocol.QuickFix, fn: quickFix, needPkg: true},
 	{kind: protocol.SourceOrganizeImports, fn: sourceOrganizeImports},
 	{kind: settings.AddTest, fn: addTest, needPkg: true},
+	{kind: settings.AddPackageDoc, fn: addPackageDoc, needPkg: true},
 	{kind: settings.GoAssembly, fn: goAssembly, needPkg: true},
 	{kind: settings.GoDoc, fn: goDoc, needPkg: true},
 	{kind: settings.GoFreeSymbols, fn: goFreeSymbols},
@@ -254,10 +255,13 @@ var codeActionProducers = [...]codeActionProducer{
 	{kind: settings.RefactorExtractVariable, fn: refactorExtractVariable, needPkg: true},
 	{kind: settings.RefactorExtractConstantAll, fn: refactorExtractVariableAll, needPkg: true},
 	{kind: settings.RefactorExtractVariableAll, fn: refactorExtractVariableAll, needPkg: true},
+	{kind: settings.RefactorExtractPackageConstant, fn: refactorExtractToPackageLevel, needPkg: true},
+	{kind: settings.RefactorExtractPackageVariable, fn: refactorExtractToPackageLevel, needPkg: true},
 	{kind: settings.RefactorInlineCall, fn: refactorInlineCall, needPkg: true},
 	{kind: settings.RefactorInlineVariable, fn: refactorInlineVariable, needPkg: true},
 	{kind: settings.RefactorMoveType, fn: refactorMoveType, needPkg: true},
 	{kind: settings.RefactorMoveDeclaration, fn: refactorMoveDeclaration, needPkg: true},
+	{kind: settings.RefactorMoveSymbol, fn: refactorMoveSymbol, needPkg: true},
 	{kind: settings.RefactorRewriteChangeQuote, fn: refactorRewriteChangeQuote},
 	{kind: settings.RefactorRewriteFillStruct, fn: refactorRewriteFillStruct, needPkg: true},
 	{kind: settings.RefactorRewriteFillSwitch, fn: refactorRewriteFillSwitch, needPkg: true},
@@ -271,6 +275,7 @@ var codeActionProducers = [...]codeActionProducer{
 	{kind: settings.RefactorRewriteEliminateDotImport, fn: refactorRewriteEliminateDotImport, needPkg: true},
 	{kind: settings.RefactorRewriteAddTags, fn: refactorRewriteAddStructTags, needPkg: true},
 	{kind: settings.RefactorRewriteRemoveTags, fn: refactorRewriteRemoveStructTags, needPkg: true},
+	{kind: settings.RefactorRewriteReorderFields, fn: refactorRewriteReorderFields, needPkg: true},
 	{kind: settings.GoplsDocFeatures, fn: goplsDocFeatures}, // offer this one last (#72742)
 
 	// Note: don't forget to update the allow-list in Server.CodeAction
@@ -558,6 +563,25 @@ func refactorExtractVariableAll(ctx context.Context, req *codeActionsRequest) er
 	return nil
 }
 
+// refactorExtractToPackageLevel produces "Extract constant|variable to
+// package level" code actions.
+// See [extractToPackageLevel] for command implementation.
+func refactorExtractToPackageLevel(ctx context.Context, req *codeActionsRequest) error {
+	info := req.pkg.TypesInfo()
+	if curExprs, err := canExtractToPackageLevel(req.pkg, req.pgf.Cursor(), req.start, req.end); err == nil {
+		// As with [refactorExtractVariable], the kinds are complementary.
+		constant := info.Types[curExprs[0].Node().(ast.Expr)].Value != nil
+		if (req.kind == settings.RefactorExtractPackageConstant) == constant {
+			title := "Extract variable to package level"
+			if constant {
+				title = "Extract constant to package level"
+			}
+			req.addApplyFixAction(title, fixExtractToPackageLevel, req.loc)
+		}
+	}
+	return nil
+}
+
 // refactorExtractToNewFile produces "Extract declarations to new file" code actions.
 // See [server.commandHandler.ExtractToNewFile] for command implementation.
 func refactorExtractToNewFile(ctx context.Context, req *codeActionsRequest) error {
@@ -568,6 +592,18 @@ func refactorExtractToNewFile(ctx context.Context, req *codeActionsRequest) erro
 	return nil
 }
 
+// addPackageDoc produces "Add doc.go for package P" code actions.
+// See [server.commandHandler.AddPackageDoc] for command implementation.
+func addPackageDoc(ctx context.Context, req *codeActionsRequest) error {
+	if !canAddPackageDoc(req.pkg, req.pgf, req.start, req.end) {
+		return nil
+	}
+	name := req.pkg.Metadata().Name
+	cmd := command.NewAddPackageDocCommand(fmt.Sprintf("Add doc.go for package %s", name), req.loc)
+	req.addCommandAction(cmd, false)
+	return nil
+}
+
 // addTest produces "Add test for FUNC" code actions.
 // See [server.commandHandler.AddTest] for command implementation.
 func addTest(ctx context.Context, req *codeActionsRequest) error {
@@ -698,6 +734,15 @@ func refactorRewriteInvertIf(ctx context.Context, req *codeActionsRequest) error
 	return nil
 }
 
+// refactorRewriteReorderFields produces "Reorder fields for minimal padding" code actions.
+// See [reorderFields] for command implementation.
+func refactorRewriteReorderFields(ctx context.Context, req *codeActionsRequest) error {
+	if canReorderFields(req.pkg, req.pgf, req.start, req.end) {
+		req.addApplyFixAction("Reorder fields for minimal padding", fixReorderFields, req.loc)
+	}
+	return nil
+}
+
 // refactorRewriteSplitLines produces "Split ITEMS into separate lines" code actions.
 // See [splitLines] for command implementation.
 func refactorRewriteSplitLines(ctx context.Context, req *codeActionsRequest) error {
@@ -1261,6 +1306,31 @@ func refactorMoveType(_ context.Context, req *codeActionsRequest) error {
 	return nil
 }
 
+// refactorMoveSymbol produces "Move symbol to another file" code
+// actions, one for each other file of the package.
+// See [server.commandHandler.MoveSymbol] for command implementation.
+func refactorMoveSymbol(_ context.Context, req *codeActionsRequest) error {
+	if !req.snapshot.Options().MoveSymbol {
+		return nil
+	}
+	if _, name, ok := selectedSymbolDecl(req.pgf, req.start, req.end); ok {
+		for _, pgf := range req.pkg.CompiledGoFiles() {
+			if pgf.URI == req.pgf.URI || !sameBuildConstraint(pgf, req.pgf) {
+				continue
+			}
+			cmd := command.NewMoveSymbolCommand(fmt.Sprintf("Move %s to %s", name, pgf.URI.Base()), command.MoveSymbolArgs{
+				Location: req.loc,
+				Dest:     pgf.URI,
+			})
+			req.addCommandAction(cmd, false)
+		}
+	}
+	return nil
+}
+
+// refactorMoveDeclaration produces "Move X to another file..." code
+// actions, which prompt for the destination file.
+// See [server.commandHandler.MoveDeclaration] for command implementation.
 func refactorMoveDeclaration(_ context.Context, req *codeActionsRequest) error {
 	if !req.snapshot.Options().MoveDeclaration {
 		return nil
@@ -1268,8 +1338,9 @@ func refactorMoveDeclaration(_ context.Context, req *codeActionsRequest) error {
 	if !supportsDialog(req.snapshot.Options().ClientOptions, moveDeclarationFormFile, moveDeclarationFormString) {
 		return nil
 	}
-	curSel, _ := req.pgf.Cursor().FindByPos(req.start, req.end)
-	cmd := command.NewMoveDeclarationCommand(fmt.Sprintf("Move declaration %s", curSel.Node()), command.MoveDeclarationArgs{Location: req.loc})
-	req.addCommandAction(cmd, false)
+	if _, name, ok := selectedSymbolDecl(req.pgf, req.start, req.end); ok {
+		cmd := command.NewMoveDeclarationCommand(fmt.Sprintf("Move %s to another file...", name), command.MoveDeclarationArgs{Location: req.loc})
+		req.addCommandAction(cmd, false)
+	}
 	return nil
 }
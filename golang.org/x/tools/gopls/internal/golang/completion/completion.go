@@ -16,6 +16,7 @@ import (
 	"go/types"
 	"iter"
 	"math"
+	"path/filepath"
 	"slices"
 	"sort"
 	"strconv"
@@ -28,6 +29,7 @@ import (
 	goastutil "golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/gopls/internal/cache"
 	"golang.org/x/tools/gopls/internal/cache/metadata"
+	"golang.org/x/tools/gopls/internal/cache/methodsets"
 	"golang.org/x/tools/gopls/internal/cache/parsego"
 	"golang.org/x/tools/gopls/internal/file"
 	"golang.org/x/tools/gopls/internal/fuzzy"
@@ -84,6 +86,11 @@ type CompletionItem struct {
 	// A higher score indicates that this completion item is more relevant.
 	Score float64
 
+	// fieldIndex, if positive, is the one-based declaration order of the
+	// struct field named by this item within a composite literal.
+	// It is set only when fields are ordered by declaration.
+	fieldIndex int
+
 	// snippet is the LSP snippet for the completion item. The LSP
 	// specification contains details about LSP snippets. For example, a
 	// snippet for a function with the following signature:
@@ -120,6 +127,7 @@ type completionOptions struct {
 	matcher               settings.Matcher
 	budget                time.Duration
 	completeFunctionCalls bool
+	fieldOrder            settings.FieldOrder
 }
 
 // Snippet is a convenience returns the snippet if available, otherwise
@@ -232,6 +240,11 @@ type completer struct {
 	// seen is the map that ensures we do not return duplicate results.
 	seen map[types.Object]bool
 
+	// fieldIndex records the one-based declaration order of struct field
+	// candidates within a composite literal, if fields are ordered by
+	// declaration.
+	fieldIndex map[types.Object]int
+
 	// items is the list of completion items returned.
 	items []CompletionItem
 
@@ -259,6 +272,10 @@ type completer struct {
 	// enclosing the position.
 	enclosingCompositeLiteral *compLitInfo
 
+	// presentMapKeys records the exact values of the constant keys of
+	// the enclosing map literal, if completing the key of one.
+	presentMapKeys map[string]bool
+
 	// deepState contains the current state of our deep completion search.
 	deepState deepCompletionState
 
@@ -277,6 +294,10 @@ type completer struct {
 	// the version of Go in force in the completion file.
 	tooNewSymbolsCache map[*types.Package]map[types.Object]stdlib.Symbol
 
+	// siblingFileFactors caches the result of [completer.siblingFileFactor]
+	// for each file of the package.
+	siblingFileFactors map[string]float64
+
 	// mapper converts the positions in the file from which the completion originated.
 	mapper *protocol.Mapper
 
@@ -559,10 +580,16 @@ func Completion(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, p
 	switch n := path[0].(type) {
 	case *ast.BasicLit:
 		// Skip completion inside literals except for ImportSpec
+		// and struct field tags.
 		if len(path) > 1 {
 			if _, ok := path[1].(*ast.ImportSpec); ok {
 				break
 			}
+			if field, ok := path[1].(*ast.Field); ok && field.Tag == n {
+				snippets := snapshot.Options().InsertTextFormat == protocol.SnippetTextFormat
+				items, sel := structTagCompletions(field, pos, pgf, snippets)
+				return items, sel, nil
+			}
 		}
 		return nil, nil, nil
 	case *ast.CallExpr:
@@ -582,7 +609,7 @@ func Completion(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, p
 			} else {
 				// Check if we have special completion for this definition, such as
 				// test function name completion.
-				ans, sel := definition(path, obj, pgf)
+				ans, sel := definition(path, obj, pgf, typesinternal.FileQualifier(pgf.File, pkg.Types()))
 				if ans != nil {
 					sort.Slice(ans, func(i, j int) bool {
 						return ans[i].Score > ans[j].Score
@@ -641,11 +668,13 @@ func Completion(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, p
 			snippets:              opts.InsertTextFormat == protocol.SnippetTextFormat,
 			postfix:               opts.ExperimentalPostfixCompletions,
 			completeFunctionCalls: opts.CompleteFunctionCalls,
+			fieldOrder:            opts.FieldOrder,
 		},
 		// default to a matcher that always matches
 		matcher:            prefixMatcher(""),
 		methodSetCache:     make(map[methodSetKey]*types.MethodSet),
 		tooNewSymbolsCache: make(map[*types.Package]map[types.Object]stdlib.Symbol),
+		siblingFileFactors: make(map[string]float64),
 		mapper:             pgf.Mapper,
 		startTime:          startTime,
 		scopes:             scopes,
@@ -714,7 +743,7 @@ func Completion(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, p
 	// depend on other candidates having already been collected.
 	c.addStatementCandidates()
 
-	sortItems(c.items)
+	sortItems(c.items, c.opts.fieldOrder)
 	return c.items, c.getSurrounding(), nil
 }
 
@@ -752,6 +781,8 @@ func (c *completer) collectCompletions(ctx context.Context) error {
 		return nil
 	}
 
+	c.mapKeyCompletions()
+
 	if c.emptySwitchStmt() {
 		// Empty switch statements only admit "default" and "case" keywords.
 		c.addKeywordItems(map[string]bool{}, highScore, CASE, DEFAULT)
@@ -941,8 +972,21 @@ func (c *completer) inferSelector(src []byte) (bool, string) {
 	return true, lhs
 }
 
-func sortItems(items []CompletionItem) {
+// sortItems sorts completion items by decreasing relevance. If order
+// is [settings.DeclarationFieldOrder], the struct field items of a
+// composite literal come first, in declaration order.
+func sortItems(items []CompletionItem, order settings.FieldOrder) {
 	sort.SliceStable(items, func(i, j int) bool {
+		if order == settings.DeclarationFieldOrder {
+			fi, fj := items[i].fieldIndex, items[j].fieldIndex
+			if (fi > 0) != (fj > 0) {
+				return fi > 0
+			}
+			if fi != fj {
+				return fi < fj
+			}
+		}
+
 		// Sort by score first.
 		if items[i].Score != items[j].Score {
 			return items[i].Score > items[j].Score
@@ -1556,6 +1600,12 @@ func (c *completer) lexical(ctx context.Context) error {
 				score /= 2
 			}
 
+			// Prefer unexported package-level objects of closely
+			// related files.
+			if scope == c.pkg.Types().Scope() && !obj.Exported() {
+				score *= c.siblingFileFactor(obj)
+			}
+
 			// If we haven't already added a candidate for an object with this name.
 			if _, ok := seen[obj.Name()]; !ok {
 				seen[obj.Name()] = struct{}{}
@@ -1599,6 +1649,10 @@ func (c *completer) lexical(ctx context.Context) error {
 		}
 	}
 
+	if err := c.assertableTypes(ctx, seen); err != nil {
+		return err
+	}
+
 	if c.opts.unimported {
 		if err := c.unimportedPackages(ctx, seen); err != nil {
 			return err
@@ -1626,6 +1680,185 @@ func (c *completer) lexical(ctx context.Context) error {
 	return nil
 }
 
+// siblingFileFactor returns the factor by which to boost the score of
+// an unexported package-level object, according to how closely its
+// file relates to the current one. Files whose names share a stem
+// with the current file (such as foo.go, foo_test.go, and
+// foo_linux.go) and files open in the editor, which are likely being
+// edited together, are preferred over other files of the package.
+//
+// The factor never exceeds 1/0.99, so that the object still ranks
+// below equally good candidates of inner scopes.
+func (c *completer) siblingFileFactor(obj types.Object) float64 {
+	const (
+		stemFactor = 0.006
+		openFactor = 0.003
+	)
+	tokFile := c.pkg.FileSet().File(obj.Pos())
+	if tokFile == nil {
+		return 1
+	}
+	filename := tokFile.Name()
+	if filename == c.filename {
+		return 1 + stemFactor + openFactor // the closest file
+	}
+	if factor, ok := c.siblingFileFactors[filename]; ok {
+		return factor
+	}
+	factor := 1.0
+	if sameFileStem(filename, c.filename) {
+		factor += stemFactor
+	}
+	for _, o := range c.snapshot.Overlays() {
+		if o.URI().Path() == filename {
+			factor += openFactor
+			break
+		}
+	}
+	c.siblingFileFactors[filename] = factor
+	return factor
+}
+
+// sameFileStem reports whether the base names of two Go files share a
+// stem, ignoring suffixes introduced by an underscore: for example,
+// foo.go, foo_test.go, and foo_unix.go all have the stem foo.
+func sameFileStem(x, y string) bool {
+	stem := func(filename string) string {
+		base := strings.TrimSuffix(filepath.Base(filename), ".go")
+		if i := strings.IndexByte(base, '_'); i > 0 {
+			base = base[:i]
+		}
+		return base
+	}
+	return stem(x) == stem(y)
+}
+
+// assertableTypes adds candidates for the concrete types that
+// implement the interface type from which a type assertion or type
+// switch case converts, for example:
+//
+//	switch x := y.(type) {
+//	case <>
+//
+// Types declared in packages imported by the current file, and in the
+// current package, are found by the lexical and deep searches. This
+// method finds the implementing types in the other packages imported
+// by the current package, and, using the method-set index, in the
+// other workspace packages; either would need a new import, so they
+// are offered only if completion of unimported packages is enabled.
+// Empty interfaces are ignored, since every type implements them.
+func (c *completer) assertableTypes(ctx context.Context, seen map[string]struct{}) error {
+	if !c.opts.unimported {
+		return nil
+	}
+	from := c.inference.typeName.assertableFrom
+	if from == nil {
+		return nil
+	}
+	intf, _ := from.Underlying().(*types.Interface)
+	if intf == nil || intf.NumMethods() == 0 {
+		return nil
+	}
+
+	// Search the dependencies of the current package,
+	// whose types belong to the same type-checking realm.
+	searched := make(map[metadata.PackagePath]bool)
+	for _, pkg := range c.pkg.Types().Imports() {
+		searched[metadata.PackagePath(pkg.Path())] = true
+		if _, ok := seen[pkg.Name()]; ok || alreadyImports(c.pgf.File, golang.ImportPath(pkg.Path())) {
+			continue
+		}
+		var pkgName *types.PkgName // created lazily
+		scope := pkg.Scope()
+		for _, name := range scope.Names() {
+			tname, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || !tname.Exported() || types.IsInterface(tname.Type()) {
+				continue
+			}
+			if named, ok := types.Unalias(tname.Type()).(*types.Named); ok && named.TypeParams().Len() > 0 {
+				continue // would need instantiation
+			}
+			if !types.AssertableTo(intf, tname.Type()) && !types.AssertableTo(intf, types.NewPointer(tname.Type())) {
+				continue
+			}
+			if pkgName == nil {
+				seen[pkg.Name()] = struct{}{}
+				pkgName = types.NewPkgName(0, nil, pkg.Name(), pkg)
+			}
+			imp := &importInfo{importPath: pkg.Path()}
+			if imports.ImportPathToAssumedName(pkg.Path()) != pkg.Name() {
+				imp.name = pkg.Name()
+			}
+			c.deepState.enqueue(candidate{
+				obj:   tname,
+				score: stdScore,
+				path:  []types.Object{pkgName},
+				imp:   imp,
+			})
+		}
+	}
+
+	// Search the other workspace packages using the method-set
+	// index, as the Implementations query does, skipping those
+	// that cannot be imported without creating a cycle.
+	key, ok := methodsets.KeyOf(intf)
+	if !ok {
+		return nil
+	}
+	mps, err := c.snapshot.WorkspaceMetadata(ctx)
+	if err != nil {
+		return err
+	}
+	rdeps, err := c.snapshot.ReverseDependencies(ctx, c.pkg.Metadata().ID, true)
+	if err != nil {
+		return err
+	}
+	var ids []metadata.PackageID
+	for _, mp := range mps {
+		if mp.ForTest != "" || mp.Name == "main" || len(mp.CompiledGoFiles) == 0 ||
+			mp.PkgPath == c.pkg.Metadata().PkgPath || searched[mp.PkgPath] || rdeps[mp.ID] != nil ||
+			alreadyImports(c.pgf.File, golang.ImportPath(mp.PkgPath)) ||
+			!imports.CanUse(c.filename, mp.CompiledGoFiles[0].Path()) {
+			continue
+		}
+		if _, ok := seen[string(mp.Name)]; ok {
+			continue
+		}
+		ids = append(ids, mp.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	indexes, err := c.snapshot.MethodSets(ctx, ids...)
+	if err != nil {
+		return err
+	}
+	for i, index := range indexes {
+		mp := c.snapshot.Metadata(ids[i])
+		if mp == nil {
+			continue
+		}
+		for _, res := range index.Search(key, methodsets.Subtype, nil) {
+			if res.IsInterface || !token.IsExported(res.TypeName) {
+				continue
+			}
+			label := string(mp.Name) + "." + res.TypeName
+			score := c.matcher.Score(label)
+			if score <= 0 {
+				continue
+			}
+			item := c.appendNewItem(nil, label,
+				fmt.Sprintf("type (from %q)", mp.PkgPath),
+				mp.PkgPath,
+				protocol.ClassCompletion,
+				mp.Name, nil)[0]
+			item.Score = stdScore * float64(score)
+			c.items = append(c.items, item)
+		}
+	}
+	return nil
+}
+
 // injectType manufactures candidates based on the given type. This is
 // intended for types not discoverable via lexical search, such as
 // composite and/or generic types. For example, if the type is "[]int",
@@ -1879,6 +2112,12 @@ func (c *completer) structLiteralFieldName(ctx context.Context) error {
 			const depthPenalty = 0.01
 			depth := len(seln.Index())
 			fieldIdx := seln.Index()[depth-1]
+			if c.opts.fieldOrder == settings.DeclarationFieldOrder {
+				if c.fieldIndex == nil {
+					c.fieldIndex = make(map[types.Object]int)
+				}
+				c.fieldIndex[seln.Obj()] = len(c.fieldIndex) + 1
+			}
 			c.deepState.enqueue(candidate{
 				obj:   seln.Obj(),
 				score: highScore - float64(depth-1)*depthPenalty - float64(fieldIdx)*deltaScore,
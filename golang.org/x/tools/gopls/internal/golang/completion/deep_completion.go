@@ -42,8 +42,17 @@ type deepCompletionState struct {
 	// candidateCount is the count of unique deep candidates encountered
 	// so far.
 	candidateCount int
+
+	// pathArena holds the storage of the paths of enqueued candidates,
+	// which are carved out of large chunks rather than allocated one
+	// by one, as the search may visit many thousands of objects.
+	pathArena []types.Object
 }
 
+// pathArenaChunk is the minimum number of objects allocated at once by
+// newPath.
+const pathArenaChunk = 512
+
 // enqueue adds a candidate to the search queue.
 func (s *deepCompletionState) enqueue(cand candidate) {
 	s.nextQueue = append(s.nextQueue, cand)
@@ -98,12 +107,19 @@ func (s *deepCompletionState) isHighScore(score float64) bool {
 
 // newPath returns path from search root for an object following a given
 // candidate.
+// The result has no spare capacity, so appending to it never modifies
+// the paths of other candidates.
 func (s *deepCompletionState) newPath(cand candidate, obj types.Object) []types.Object {
-	path := make([]types.Object, len(cand.path)+1)
-	copy(path, cand.path)
-	path[len(path)-1] = obj
+	n := len(cand.path) + 1
+	if cap(s.pathArena)-len(s.pathArena) < n {
+		// Start a new chunk. Paths already returned keep the old one alive.
+		s.pathArena = make([]types.Object, 0, max(n, pathArenaChunk))
+	}
+	start := len(s.pathArena)
+	s.pathArena = append(s.pathArena, cand.path...)
+	s.pathArena = append(s.pathArena, obj)
 
-	return path
+	return s.pathArena[start : start+n : start+n]
 }
 
 // deepSearch searches a candidate and its subordinate objects for completion
@@ -131,6 +147,20 @@ func (c *completer) deepSearch(ctx context.Context, minDepth int, deadline *time
 		return depth > minDepth && deadline != nil && time.Now().After(*deadline)
 	}
 
+	// enqueue adds the members of the current candidate to the queue.
+	// It is created once, rather than for each candidate, to save
+	// allocations; the path and invoke mask of the members are
+	// passed through the variables it captures.
+	var (
+		memberPath []types.Object
+		memberMask uint16
+	)
+	enqueue := func(newCand candidate) {
+		newCand.pathInvokeMask = memberMask
+		newCand.path = memberPath
+		c.deepState.enqueue(newCand)
+	}
+
 	for len(c.deepState.nextQueue) > 0 {
 		depth++
 		if stop() {
@@ -219,34 +249,26 @@ func (c *completer) deepSearch(ctx context.Context, minDepth int, deadline *time
 				continue
 			}
 
+			// The members of obj, and of the result of calling it,
+			// share the same path.
+			memberPath = c.deepState.newPath(cand, obj)
+
 			if sig, ok := obj.Type().Underlying().(*types.Signature); ok {
 				// If obj is a function that takes no arguments and returns one
 				// value, keep searching across the function call.
 				if sig.Params().Len() == 0 && sig.Results().Len() == 1 {
-					path := c.deepState.newPath(cand, obj)
+					memberMask = cand.pathInvokeMask | (1 << uint64(len(cand.path)))
 					// The result of a function call is not addressable.
-					c.methodsAndFields(sig.Results().At(0).Type(), false, cand.imp, func(newCand candidate) {
-						newCand.pathInvokeMask = cand.pathInvokeMask | (1 << uint64(len(cand.path)))
-						newCand.path = path
-						c.deepState.enqueue(newCand)
-					})
+					c.methodsAndFields(sig.Results().At(0).Type(), false, cand.imp, enqueue)
 				}
 			}
 
-			path := c.deepState.newPath(cand, obj)
+			memberMask = cand.pathInvokeMask
 			switch obj := obj.(type) {
 			case *types.PkgName:
-				c.packageMembers(obj.Imported(), stdScore, cand.imp, func(newCand candidate) {
-					newCand.pathInvokeMask = cand.pathInvokeMask
-					newCand.path = path
-					c.deepState.enqueue(newCand)
-				})
+				c.packageMembers(obj.Imported(), stdScore, cand.imp, enqueue)
 			default:
-				c.methodsAndFields(obj.Type(), cand.addressable, cand.imp, func(newCand candidate) {
-					newCand.pathInvokeMask = cand.pathInvokeMask
-					newCand.path = path
-					c.deepState.enqueue(newCand)
-				})
+				c.methodsAndFields(obj.Type(), cand.addressable, cand.imp, enqueue)
 			}
 		}
 	}
@@ -259,6 +281,9 @@ func (c *completer) addCandidate(ctx context.Context, cand *candidate) {
 	if obj != nil && obj.Name() == "_" {
 		return
 	}
+	if c.isPresentMapKey(obj) {
+		return // would be a duplicate key
+	}
 	if c.matchingCandidate(cand) {
 		cand.score *= highScore
 
@@ -5,6 +5,8 @@
 package completion
 
 import (
+	"go/types"
+	"slices"
 	"testing"
 )
 
@@ -31,3 +33,54 @@ func TestDeepCompletionIsHighScore(t *testing.T) {
 		t.Error("2 shouldn't be high score")
 	}
 }
+
+func TestDeepCompletionNewPath(t *testing.T) {
+	// Test that the paths returned by deepCompletionState.newPath,
+	// which share the storage of an arena, do not alias each other.
+
+	var s deepCompletionState
+	a, b, c := types.NewVar(0, nil, "a", nil), types.NewVar(0, nil, "b", nil), types.NewVar(0, nil, "c", nil)
+
+	ab := s.newPath(candidate{path: []types.Object{a}}, b)
+	ac := s.newPath(candidate{path: []types.Object{a}}, c)
+	if got := append(ab, c); !slices.Equal(got, []types.Object{a, b, c}) {
+		t.Errorf("append to path a.b = %v", got)
+	}
+	if !slices.Equal(ac, []types.Object{a, c}) {
+		t.Errorf("path a.c = %v, modified by append to path a.b", ac)
+	}
+
+	// Paths longer than a chunk get a chunk of their own.
+	long := make([]types.Object, pathArenaChunk)
+	if got := s.newPath(candidate{path: long}, a); len(got) != pathArenaChunk+1 || got[pathArenaChunk] != a {
+		t.Errorf("long path has length %d, last element %v", len(got), got[len(got)-1])
+	}
+}
+
+func TestDeepCompletionNewPathAllocs(t *testing.T) {
+	// Test that newPath allocates only when a chunk of the arena is
+	// used up, not once per path.
+	a, b := types.NewVar(0, nil, "a", nil), types.NewVar(0, nil, "b", nil)
+	cand := candidate{path: []types.Object{a, a}}
+	const paths = 1000
+	allocs := testing.AllocsPerRun(10, func() {
+		var s deepCompletionState
+		for range paths {
+			s.newPath(cand, b)
+		}
+	})
+	// Each path has 3 objects, so a chunk holds 170 paths.
+	if limit := float64(paths*3/pathArenaChunk + 1); allocs > limit {
+		t.Errorf("%d calls of newPath made %v allocations, want at most %v", paths, allocs, limit)
+	}
+}
+
+func BenchmarkDeepCompletionNewPath(b *testing.B) {
+	x, y := types.NewVar(0, nil, "x", nil), types.NewVar(0, nil, "y", nil)
+	cand := candidate{path: []types.Object{x, x}}
+	var s deepCompletionState
+	b.ReportAllocs()
+	for b.Loop() {
+		s.newPath(cand, y)
+	}
+}
@@ -5,6 +5,7 @@
 package completion
 
 import (
+	"bytes"
 	"go/ast"
 	"go/types"
 	"strings"
@@ -14,17 +15,25 @@ import (
 	"golang.org/x/tools/gopls/internal/cache/parsego"
 	"golang.org/x/tools/gopls/internal/golang/completion/snippet"
 	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/util/safetoken"
 )
 
 // some function definitions in test files can be completed
 // So far, TestFoo(t *testing.T), TestMain(m *testing.M)
 // BenchmarkFoo(b *testing.B), FuzzFoo(f *testing.F)
 
+// Methods can be completed too: the methods of the interfaces embedded
+// in the receiver's struct type that it does not declare itself.
+
 // path[0] is known to be *ast.Ident
-func definition(path []ast.Node, obj types.Object, pgf *parsego.File) ([]CompletionItem, *Selection) {
-	if _, ok := obj.(*types.Func); !ok {
+func definition(path []ast.Node, obj types.Object, pgf *parsego.File, qual types.Qualifier) ([]CompletionItem, *Selection) {
+	fn, ok := obj.(*types.Func)
+	if !ok {
 		return nil, nil // not a function at all
 	}
+	if fn.Signature().Recv() != nil {
+		return methodDefinition(path, fn, pgf, qual)
+	}
 	if !strings.HasSuffix(pgf.URI.Path(), "_test.go") {
 		return nil, nil // not a test file
 	}
@@ -34,17 +43,7 @@ func definition(path []ast.Node, obj types.Object, pgf *parsego.File) ([]Complet
 		// can't happen
 		return nil, nil
 	}
-	start := path[0].Pos()
-	end := path[0].End()
-	sel := &Selection{
-		content: "", // why isn't this name?
-		cursor:  start,
-		tokFile: pgf.Tok,
-		start:   start,
-		end:     end,
-		mapper:  pgf.Mapper,
-	}
-	sel.check()
+	sel := definitionSelection(path, pgf)
 	var ans []CompletionItem
 	var hasParens bool
 	n, ok := path[1].(*ast.FuncDecl)
@@ -159,3 +158,97 @@ func defItem(val string, obj types.Object) CompletionItem {
 		isSlice:       isSlice(obj),
 	}
 }
+
+// definitionSelection returns the selection of the name of a function
+// declaration, path[0].
+func definitionSelection(path []ast.Node, pgf *parsego.File) *Selection {
+	start := path[0].Pos()
+	end := path[0].End()
+	sel := &Selection{
+		content: "", // why isn't this name?
+		cursor:  start,
+		tokFile: pgf.Tok,
+		start:   start,
+		end:     end,
+		mapper:  pgf.Mapper,
+	}
+	sel.check()
+	return sel
+}
+
+// methodDefinition returns the completions of the name of the method
+// fn, being declared: the methods of the interfaces embedded in its
+// receiver's struct type that the type does not declare itself, with
+// their signatures and bodies, unless the parameters are already there.
+func methodDefinition(path []ast.Node, fn *types.Func, pgf *parsego.File, qual types.Qualifier) ([]CompletionItem, *Selection) {
+	recv := fn.Signature().Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	named, ok := types.Unalias(recv).(*types.Named)
+	if !ok {
+		return nil, nil
+	}
+	strct, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return nil, nil
+	}
+
+	// Methods declared on the type, other than fn, are implemented.
+	declared := make(map[string]bool)
+	for m := range named.Origin().Methods() {
+		if m != fn.Origin() {
+			declared[m.Name()] = true
+		}
+	}
+
+	name := path[0].(*ast.Ident).Name
+	// The parser's recovery from a missing parameter list varies,
+	// so look for the parenthesis after the name in the source.
+	hasParens := false
+	if end, err := safetoken.Offset(pgf.Tok, path[0].End()); err == nil {
+		rest := bytes.TrimLeft(pgf.Src[end:], " \t")
+		hasParens = len(rest) > 0 && rest[0] == '('
+	}
+	var ans []CompletionItem
+	for field := range strct.Fields() {
+		if !field.Embedded() {
+			continue
+		}
+		iface, ok := field.Type().Underlying().(*types.Interface)
+		if !ok {
+			continue
+		}
+		for m := range iface.Methods() {
+			if declared[m.Name()] || !strings.HasPrefix(m.Name(), name) ||
+				!m.Exported() && m.Pkg() != fn.Pkg() {
+				continue
+			}
+			declared[m.Name()] = true // offer each method once
+
+			item := CompletionItem{
+				Label:         m.Name(),
+				InsertText:    m.Name(),
+				Detail:        "method of " + types.TypeString(field.Type(), qual),
+				Kind:          protocol.MethodCompletion,
+				Score:         10,
+				Documentation: "implement the method of the embedded interface",
+			}
+			if !hasParens {
+				sig := strings.TrimPrefix(types.TypeString(m.Signature(), qual), "func")
+				var sn snippet.Builder
+				sn.WriteText(m.Name() + sig + " {\n\t")
+				sn.WriteFinalTabstop()
+				sn.WriteText("\n}")
+				item.Label += sig
+				item.InsertText += sig
+				item.snippet = &sn
+			}
+			ans = append(ans, item)
+		}
+	}
+	if ans == nil {
+		return nil, nil
+	}
+	return ans, definitionSelection(path, pgf)
+}
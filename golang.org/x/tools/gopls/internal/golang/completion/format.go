@@ -253,6 +253,9 @@ Suffixes:
 		snippet:             &snip,
 		isSlice:             isSlice(obj),
 	}
+	if len(cand.path) == 0 {
+		item.fieldIndex = c.fieldIndex[obj]
+	}
 	// If the user doesn't want documentation for completion items.
 	if !c.opts.documentation {
 		return item, nil
@@ -377,9 +377,17 @@ func (c *completer) functionLiteral(ctx context.Context, sig *types.Signature, m
 		snip.WriteText(")")
 	}
 
-	snip.WriteText(" {")
-	snip.WriteFinalTabstop()
-	snip.WriteText("}")
+	// If the function has results, start its body with a return
+	// statement, for example "func(i, j int) bool {\n\t$0\n\treturn false\n}".
+	if ret, ok := c.returnSkeleton(results, resultHasTypeParams); ok {
+		snip.WriteText(" {\n\t")
+		snip.WriteFinalTabstop()
+		snip.WriteText("\n\t" + ret + "\n}")
+	} else {
+		snip.WriteText(" {")
+		snip.WriteFinalTabstop()
+		snip.WriteText("}")
+	}
 
 	return CompletionItem{
 		Label:   "func(...) {}",
@@ -389,6 +397,28 @@ func (c *completer) functionLiteral(ctx context.Context, sig *types.Signature, m
 	}, true
 }
 
+// returnSkeleton returns a return statement of zero values for a
+// function literal with the specified results: a bare return if the
+// results are named. It reports false if the function has no results,
+// or if their zero values cannot be expressed.
+func (c *completer) returnSkeleton(results *types.Tuple, hasTypeParams bool) (string, bool) {
+	if results.Len() == 0 || hasTypeParams {
+		return "", false
+	}
+	if results.At(0).Name() != "" {
+		return "return", true
+	}
+	zeros := make([]string, results.Len())
+	for i := range results.Len() {
+		zero, ok := typesinternal.ZeroString(results.At(i).Type(), c.qual)
+		if !ok {
+			return "", false
+		}
+		zeros[i] = zero
+	}
+	return "return " + strings.Join(zeros, ", "), true
+}
+
 // conventionalAcronyms contains conventional acronyms for type names
 // in lower case. For example, "ctx" for "context" and "err" for "error".
 //
@@ -0,0 +1,111 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package completion
+
+// This file defines the completion of the keys of map literals.
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/gopls/internal/protocol"
+)
+
+// mapKeyCompletions records the constant keys of the enclosing map
+// literal, if the position is on the key side of an element of one,
+// so that candidates whose value would be a duplicate key are not
+// offered (see [completer.isPresentMapKey]). The constants of a
+// defined key type are found by lexical and unimported completion.
+//
+// If the key type is a basic string or integer type, it also offers
+// the literal keys of the other literals of the same map type in the
+// package, which often form a finite set.
+func (c *completer) mapKeyCompletions() {
+	clInfo := c.enclosingCompositeLiteral
+	if clInfo == nil || !clInfo.inKey {
+		return
+	}
+	mapType, ok := clInfo.clType.(*types.Map)
+	if !ok {
+		return
+	}
+	info := c.pkg.TypesInfo()
+
+	c.presentMapKeys = make(map[string]bool)
+	for _, elt := range clInfo.cl.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok && kv != clInfo.kv {
+			if tv, ok := info.Types[kv.Key]; ok && tv.Value != nil {
+				c.presentMapKeys[tv.Value.ExactString()] = true
+			}
+		}
+	}
+
+	key, ok := mapType.Key().(*types.Basic)
+	if !ok || key.Info()&(types.IsString|types.IsInteger) == 0 {
+		return
+	}
+	// Offer each literal key of the other literals
+	// of the same map type in the package, once.
+	seen := make(map[string]bool)
+	for _, pgf := range c.pkg.CompiledGoFiles() {
+		ast.Inspect(pgf.File, func(n ast.Node) bool {
+			cl, ok := n.(*ast.CompositeLit)
+			if !ok || cl == clInfo.cl {
+				return true
+			}
+			if tv, ok := info.Types[cl]; !ok || !types.Identical(tv.Type.Underlying(), mapType) {
+				return true
+			}
+			for _, elt := range cl.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				lit, ok := kv.Key.(*ast.BasicLit)
+				if !ok || (lit.Kind != token.STRING && lit.Kind != token.INT) {
+					continue
+				}
+				value := constant.MakeFromLiteral(lit.Value, lit.Kind, 0)
+				if value.Kind() == constant.Unknown || seen[value.ExactString()] || c.presentMapKeys[value.ExactString()] {
+					continue
+				}
+				seen[value.ExactString()] = true
+				c.addMapKeyItem(value)
+			}
+			return true
+		})
+	}
+}
+
+// addMapKeyItem adds a completion item for a literal map key.
+func (c *completer) addMapKeyItem(value constant.Value) {
+	// Match the prefix against the value of a string,
+	// as the user may not yet have typed its quote.
+	text := value.ExactString()
+	if value.Kind() == constant.String {
+		text = constant.StringVal(value)
+	}
+	matchScore := c.matcher.Score(text)
+	if matchScore <= 0 {
+		return
+	}
+	label := value.ExactString()
+	c.items = append(c.items, CompletionItem{
+		Label:      label,
+		InsertText: label,
+		Kind:       protocol.ConstantCompletion,
+		Score:      highScore * float64(matchScore),
+	})
+}
+
+// isPresentMapKey reports whether obj is a constant whose value is
+// already a key of the enclosing map literal, and so would be a
+// duplicate key.
+func (c *completer) isPresentMapKey(obj types.Object) bool {
+	k, ok := obj.(*types.Const)
+	return ok && c.presentMapKeys[k.Val().ExactString()]
+}
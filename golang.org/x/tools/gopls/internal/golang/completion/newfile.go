@@ -8,12 +8,18 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
 
 	"golang.org/x/tools/gopls/internal/cache"
 	"golang.org/x/tools/gopls/internal/cache/parsego"
 	"golang.org/x/tools/gopls/internal/file"
 	"golang.org/x/tools/gopls/internal/golang"
 	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/internal/event"
 )
 
 // NewFile returns a document change to complete an empty Go source file. Document change may be nil.
@@ -32,36 +38,45 @@ func NewFile(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle) (*pr
 	if err != nil {
 		return nil, err
 	}
+	pkgName, err := bestPackage(ctx, snapshot, fh.URI())
+	if err != nil {
+		return nil, err
+	}
+
 	var buf bytes.Buffer
-	// Copy the copyright header from the first existing file that has one.
-	for _, fileURI := range meta.GoFiles {
-		if fileURI == fh.URI() {
-			continue
-		}
-		fh, err := snapshot.ReadFile(ctx, fileURI)
-		if err != nil {
-			continue
-		}
-		pgf, err := snapshot.ParseGo(ctx, fh, parsego.Header)
+	if template := snapshot.Options().NewGoFileHeaderTemplate; template != "" {
+		header, err := expandHeaderTemplate(snapshot.Folder().Path(), template, fh.URI().Base(), pkgName)
 		if err != nil {
-			continue
+			// Insert the package declaration anyway.
+			event.Error(ctx, "expanding newGoFileHeaderTemplate", err)
 		}
-		if group := golang.CopyrightComment(pgf.File); group != nil {
-			text, err := pgf.NodeText(group)
+		buf.WriteString(header)
+	} else {
+		// Copy the copyright header from the first existing file that has one.
+		for _, fileURI := range meta.GoFiles {
+			if fileURI == fh.URI() {
+				continue
+			}
+			fh, err := snapshot.ReadFile(ctx, fileURI)
+			if err != nil {
+				continue
+			}
+			pgf, err := snapshot.ParseGo(ctx, fh, parsego.Header)
 			if err != nil {
 				continue
 			}
-			buf.Write(text)
-			buf.WriteString("\n\n")
-			break
+			if group := golang.CopyrightComment(pgf.File); group != nil {
+				text, err := pgf.NodeText(group)
+				if err != nil {
+					continue
+				}
+				buf.Write(text)
+				buf.WriteString("\n\n")
+				break
+			}
 		}
 	}
 
-	pkgName, err := bestPackage(ctx, snapshot, fh.URI())
-	if err != nil {
-		return nil, err
-	}
-
 	fmt.Fprintf(&buf, "package %s\n", pkgName)
 	change := protocol.DocumentChangeEdit(fh, []protocol.TextEdit{{
 		Range:   protocol.Range{}, // insert at start of file
@@ -70,3 +85,42 @@ func NewFile(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle) (*pr
 
 	return &change, nil
 }
+
+// expandHeaderTemplate returns the header of a new Go file named
+// filename in package pkgName, followed by a blank line, from the named
+// template file, which is relative to dir if not absolute. The template
+// may refer to the fields of [headerData].
+func expandHeaderTemplate(dir, template, filename, pkgName string) (string, error) {
+	if !filepath.IsAbs(template) {
+		template = filepath.Join(dir, template)
+	}
+	data, err := os.ReadFile(template)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := texttemplate.New(filepath.Base(template)).Parse(string(data))
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, headerData{
+		Year:     time.Now().Year(),
+		Package:  pkgName,
+		Filename: filename,
+	}); err != nil {
+		return "", err
+	}
+	header := strings.TrimRight(buf.String(), "\n")
+	if header == "" {
+		return "", nil
+	}
+	return header + "\n\n", nil
+}
+
+// headerData holds the fields of the template of the header of a new
+// Go file.
+type headerData struct {
+	Year     int    // current year
+	Package  string // name of the package
+	Filename string // base name of the file
+}
@@ -25,6 +25,7 @@ import (
 	"golang.org/x/tools/gopls/internal/fuzzy"
 	"golang.org/x/tools/gopls/internal/golang"
 	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/settings"
 	"golang.org/x/tools/gopls/internal/util/safetoken"
 )
 
@@ -81,7 +82,7 @@ func packageClauseCompletions(ctx context.Context, snapshot *cache.Snapshot, fh
 			Score:      pkg.score,
 		})
 	}
-	sortItems(items)
+	sortItems(items, settings.RankFieldOrder)
 	return items, surrounding, nil
 }
 
@@ -0,0 +1,245 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package completion
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/golang/completion/snippet"
+	"golang.org/x/tools/gopls/internal/protocol"
+)
+
+// This file defines completion within struct field tags, such as
+// `json:"name,omitempty"`. It offers, depending on the cursor position:
+//
+//   - well-known tag keys (json, yaml, xml, validate);
+//   - names derived from the field name, as the first element of a value;
+//   - option keywords (e.g. omitempty), as subsequent elements of a value.
+
+// structTagKeys lists the well-known tag keys, and the options
+// permitted after the name in their values.
+var structTagKeys = []struct {
+	key     string
+	options []string
+}{
+	{"json", []string{"omitempty", "omitzero", "string"}},
+	{"yaml", []string{"omitempty", "flow", "inline"}},
+	{"xml", []string{"attr", "chardata", "cdata", "innerxml", "comment", "omitempty", "any"}},
+	{"validate", nil},
+}
+
+// validateRules lists common rules of the github.com/go-playground/validator
+// package. Unlike other tags, the value of a validate tag has no name
+// element: all its comma-separated elements are rules.
+var validateRules = []string{
+	"required", "omitempty", "dive", "email", "url", "uuid",
+	"min=", "max=", "len=", "eq=", "ne=", "gt=", "gte=", "lt=", "lte=", "oneof=",
+}
+
+// structTagCompletions returns completion candidates for the cursor
+// position pos within the tag of the specified struct field.
+// Only raw string literals are supported, since the quotation marks
+// of an interpreted string literal tag would need escaping.
+func structTagCompletions(field *ast.Field, pos token.Pos, pgf *parsego.File, snippets bool) ([]CompletionItem, *Selection) {
+	lit := field.Tag
+	if !strings.HasPrefix(lit.Value, "`") || pos <= lit.Pos() || pos >= lit.End() {
+		return nil, nil
+	}
+	text := lit.Value[1 : pos-lit.Pos()]
+
+	key, value, inValue, ok := parseStructTagPrefix(text)
+	if !ok {
+		return nil, nil
+	}
+
+	var (
+		prefix string
+		items  []CompletionItem
+	)
+	add := func(label string, kind protocol.CompletionItemKind, sn *snippet.Builder) {
+		if !strings.HasPrefix(label, prefix) {
+			return
+		}
+		items = append(items, CompletionItem{
+			Label:      label,
+			InsertText: label,
+			Kind:       kind,
+			Score:      highScore - 0.01*float64(len(items)), // preserve order
+			snippet:    sn,
+		})
+	}
+
+	switch {
+	case !inValue:
+		// Complete a key, omitting those already present.
+		prefix = key
+		present := make(map[string]bool)
+		for k := range strings.SplitSeq(strings.TrimSpace(text), " ") {
+			if k, _, ok := strings.Cut(k, ":"); ok {
+				present[k] = true
+			}
+		}
+		for _, k := range structTagKeys {
+			if present[k.key] {
+				continue
+			}
+			var sn *snippet.Builder
+			if snippets {
+				sn = &snippet.Builder{}
+				sn.WriteText(k.key + `:"`)
+				sn.WriteFinalTabstop()
+				sn.WriteText(`"`)
+			}
+			add(k.key, protocol.KeywordCompletion, sn)
+		}
+
+	case key == "validate":
+		// Complete a rule.
+		prefix = value[strings.LastIndexAny(value, ",|")+1:]
+		for _, rule := range validateRules {
+			add(rule, protocol.KeywordCompletion, nil)
+		}
+
+	default:
+		comma := strings.LastIndexByte(value, ',')
+		prefix = value[comma+1:]
+		if comma < 0 {
+			// Complete the name element.
+			var names []string
+			for _, id := range field.Names {
+				names = append(names, lowerCamelCase(id.Name), snakeCase(id.Name))
+			}
+			slices.Sort(names)
+			for _, name := range slices.Compact(names) {
+				add(name, protocol.ValueCompletion, nil)
+			}
+			add("-", protocol.ValueCompletion, nil)
+		} else {
+			// Complete an option, omitting those already present.
+			present := strings.Split(value[:comma], ",")[1:]
+			for _, k := range structTagKeys {
+				if k.key != key {
+					continue
+				}
+				for _, opt := range k.options {
+					if !slices.Contains(present, opt) {
+						add(opt, protocol.KeywordCompletion, nil)
+					}
+				}
+			}
+		}
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	sel := &Selection{
+		content: prefix,
+		cursor:  pos,
+		tokFile: pgf.Tok,
+		start:   pos - token.Pos(len(prefix)),
+		end:     pos,
+		mapper:  pgf.Mapper,
+	}
+	sel.check()
+	return items, sel
+}
+
+// parseStructTagPrefix parses text, the portion of a struct tag
+// preceding the cursor, according to the conventional syntax of
+// [reflect.StructTag]. If the cursor is within a quoted value, it
+// returns the key and the portion of the value preceding the cursor,
+// and inValue is set; otherwise it returns the partial key (if any)
+// preceding the cursor. It reports false if text is malformed.
+func parseStructTagPrefix(text string) (key, value string, inValue, ok bool) {
+	for {
+		text = strings.TrimLeft(text, " ")
+		i := 0
+		for i < len(text) && text[i] > ' ' && text[i] != ':' && text[i] != '"' && text[i] != 0x7f {
+			i++
+		}
+		key, text = text[:i], text[i:]
+		if text == "" {
+			return key, "", false, true // cursor within (or before) a key
+		}
+		if i == 0 || !strings.HasPrefix(text, `:"`) {
+			return "", "", false, false
+		}
+		text = text[len(`:"`):]
+
+		// Find the closing quote of the value.
+		i = 0
+		for i < len(text) && text[i] != '"' {
+			if text[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(text) {
+			return key, text, true, true // cursor within the value
+		}
+		text = text[i+1:]
+	}
+}
+
+// lowerCamelCase returns name with its leading upper-case word
+// converted to lower case, for example "userID" for "UserID" and
+// "urlPath" for "URLPath".
+func lowerCamelCase(name string) string {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return name
+	}
+	words[0] = strings.ToLower(words[0])
+	return strings.Join(words, "")
+}
+
+// snakeCase returns name converted to lower-case words separated by
+// underscores, for example "user_id" for "UserID".
+func snakeCase(name string) string {
+	words := splitWords(name)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return strings.Join(words, "_")
+}
+
+// splitWords splits a Go identifier in mixed case into words, treating
+// a run of upper-case letters as an initialism: "URLPath" becomes
+// ["URL", "Path"], and "UserID" becomes ["User", "ID"]. Underscores
+// separate words and are discarded.
+func splitWords(name string) []string {
+	var (
+		words []string
+		runes = []rune(name)
+		start = 0
+	)
+	flush := func(end int) {
+		if end > start {
+			words = append(words, string(runes[start:end]))
+		}
+		start = end
+	}
+	for i, r := range runes {
+		switch {
+		case r == '_':
+			flush(i)
+			start = i + 1
+		case i > start && unicode.IsUpper(r):
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextLower {
+				flush(i)
+			}
+		}
+	}
+	flush(len(runes))
+	return words
+}
@@ -10,10 +10,11 @@ import "golang.org/x/telemetry/counter"
 // increment when the user attempts to perform one of these operations,
 // regardless of whether it succeeds.
 var (
-	countExtractFunction    = counter.New("gopls/extract:func")
-	countExtractMethod      = counter.New("gopls/extract:method")
-	countExtractVariable    = counter.New("gopls/extract:variable")
-	countExtractVariableAll = counter.New("gopls/extract:variable-all")
+	countExtractFunction       = counter.New("gopls/extract:func")
+	countExtractMethod         = counter.New("gopls/extract:method")
+	countExtractVariable       = counter.New("gopls/extract:variable")
+	countExtractVariableAll    = counter.New("gopls/extract:variable-all")
+	countExtractToPackageLevel = counter.New("gopls/extract:package-level")
 
 	countInlineCall     = counter.New("gopls/inline:call")
 	countInlineVariable = counter.New("gopls/inline:variable")
@@ -6,9 +6,13 @@ package golang
 
 import (
 	"context"
+	"fmt"
+	"go/ast"
+	"slices"
 
 	"golang.org/x/tools/gopls/internal/cache"
 	"golang.org/x/tools/gopls/internal/cache/metadata"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
 	"golang.org/x/tools/gopls/internal/progress"
 	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/internal/moremaps"
@@ -36,6 +40,10 @@ func DiagnoseFile(ctx context.Context, snapshot *cache.Snapshot, uri protocol.Do
 	if err != nil {
 		return nil, err
 	}
+	pkgAnalysisDiags, err = dropSkipped(ctx, snapshot, pkgAnalysisDiags)
+	if err != nil {
+		return nil, err
+	}
 	analysisDiags := moremaps.Group(pkgAnalysisDiags, byURI)[uri]
 
 	// Return the merged set of file diagnostics, combining type error analyses
@@ -60,9 +68,80 @@ func Analyze(ctx context.Context, snapshot *cache.Snapshot, pkgIDs map[PackageID
 	if err != nil {
 		return nil, err
 	}
+	analysisDiagnostics, err = dropSkipped(ctx, snapshot, analysisDiagnostics)
+	if err != nil {
+		return nil, err
+	}
+	if maxLines := snapshot.Options().InlineMaxLines; maxLines > 0 {
+		analysisDiagnostics = limitInlineFixes(ctx, snapshot, analysisDiagnostics, maxLines)
+	}
 	return moremaps.Group(analysisDiagnostics, byURI), nil
 }
 
+// AnalysisSkipped returns the reason why the analysis diagnostics in
+// the file uri are not reported, according to the analysisMaxFileSize
+// and analysisSkipGenerated settings, or "" if they are reported.
+//
+// Analyzers examine the file regardless, as their diagnostics in the
+// other files of its package may depend on it.
+func AnalysisSkipped(ctx context.Context, snapshot *cache.Snapshot, uri protocol.DocumentURI) (string, error) {
+	opts := snapshot.Options()
+	if opts.AnalysisMaxFileSize <= 0 && !opts.AnalysisSkipGenerated {
+		return "", nil
+	}
+	fh, err := snapshot.ReadFile(ctx, uri)
+	if err != nil {
+		return "", err
+	}
+	content, err := fh.Content()
+	if err != nil {
+		return "", err
+	}
+	if size := opts.AnalysisMaxFileSize; size > 0 && int64(len(content)) > size {
+		return fmt.Sprintf("the file is larger than %d bytes (see the analysisMaxFileSize setting)", size), nil
+	}
+	if opts.AnalysisSkipGenerated {
+		pgf, err := snapshot.ParseGo(ctx, fh, parsego.Header)
+		if err != nil {
+			return "", err
+		}
+		if ast.IsGenerated(pgf.File) {
+			return "the file is generated (see the analysisSkipGenerated setting)", nil
+		}
+	}
+	return "", nil
+}
+
+// dropSkipped returns the analysis diagnostics that are not located
+// in files whose diagnostics AnalysisSkipped suppresses, except for
+// those of the analyzers of the analysisSkipExempt setting.
+func dropSkipped(ctx context.Context, snapshot *cache.Snapshot, diags []*cache.Diagnostic) ([]*cache.Diagnostic, error) {
+	opts := snapshot.Options()
+	if opts.AnalysisMaxFileSize <= 0 && !opts.AnalysisSkipGenerated {
+		return diags, nil
+	}
+	skipped := make(map[protocol.DocumentURI]bool)
+	var kept []*cache.Diagnostic
+	for _, diag := range diags {
+		if !slices.Contains(opts.AnalysisSkipExempt, string(diag.Source)) {
+			skip, ok := skipped[diag.URI]
+			if !ok {
+				reason, err := AnalysisSkipped(ctx, snapshot, diag.URI)
+				if err != nil {
+					return nil, err
+				}
+				skip = reason != ""
+				skipped[diag.URI] = skip
+			}
+			if skip {
+				continue
+			}
+		}
+		kept = append(kept, diag)
+	}
+	return kept, nil
+}
+
 // byURI is used for grouping diagnostics.
 func byURI(d *cache.Diagnostic) protocol.DocumentURI { return d.URI }
 
@@ -22,21 +22,38 @@ import (
 // As such it indicates that other definitions could be worth checking.
 var ErrNoEmbed = errors.New("no embed directive found")
 
-// embedDefinition finds a file matching the embed directive at pos in the mapped file.
+// embedDefinition finds the files matching the embed directive at pos in the mapped file.
 // If there is no embed directive at pos, returns [ErrNoEmbed].
-// If multiple files match the embed pattern, one is picked at random.
+// If multiple files match the embed pattern, they are all returned, in lexical order.
 func embedDefinition(m *protocol.Mapper, rng protocol.Range) ([]protocol.Location, error) {
 	pattern, _ := parseEmbedDirective(m, rng)
 	if pattern == "" {
 		return nil, ErrNoEmbed
 	}
-	pattern = filepath.FromSlash(pattern) // Match requires OS separators
+	dir := m.URI.DirPath()
+	matches, err := embedMatches(dir, pattern)
+	if err != nil {
+		return nil, err // bad pattern or I/O error
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%q does not match any files in %q", pattern, dir)
+	}
+	var locs []protocol.Location
+	for _, rel := range matches {
+		locs = append(locs, protocol.Location{URI: protocol.URIFromPath(filepath.Join(dir, rel))})
+	}
+	return locs, nil
+}
 
-	errFound := errors.New("found")
+// embedMatches returns the paths, relative to dir and in lexical
+// order, of the files in dir or its subdirectories that match the
+// go:embed pattern. Directories are never matched, even if their names
+// match the pattern.
+func embedMatches(dir, pattern string) ([]string, error) {
+	pattern = strings.TrimPrefix(pattern, "all:")
+	pattern = filepath.FromSlash(pattern) // Match requires OS separators
 
-	// Find the first matching file.
-	var match string
-	dir := m.URI.DirPath()
+	var matches []string
 	err := filepath.WalkDir(dir, func(abs string, d fs.DirEntry, e error) error {
 		if e != nil {
 			return e
@@ -50,18 +67,14 @@ func embedDefinition(m *protocol.Mapper, rng protocol.Range) ([]protocol.Locatio
 			return err
 		}
 		if ok && !d.IsDir() {
-			match = abs
-			return errFound
+			matches = append(matches, rel)
 		}
 		return nil
 	})
-	if err != nil && !errors.Is(err, errFound) {
-		return nil, err // bad pattern or I/O error
-	}
-	if match == "" {
-		return nil, fmt.Errorf("%q does not match any files in %q", pattern, dir)
+	if err != nil {
+		return nil, err
 	}
-	return []protocol.Location{{URI: protocol.URIFromPath(match)}}, nil
+	return matches, nil
 }
 
 // parseEmbedDirective attempts to parse a go:embed directive argument that
@@ -16,6 +16,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/ast/edge"
@@ -264,6 +265,166 @@ Outer:
 	}, nil
 }
 
+// extractToPackageLevel implements the
+// refactor.extract.{packageConstant,packageVariable} CodeAction command.
+//
+// It replaces the selected expression, within a function, by a new
+// package-level constant or variable, declared before the enclosing
+// function and named after the context of the expression when possible.
+// The expression must not refer to local declarations.
+func extractToPackageLevel(pkg *cache.Package, pgf *parsego.File, start, end token.Pos) (*token.FileSet, *analysis.SuggestedFix, error) {
+	countExtractToPackageLevel.Inc()
+	var (
+		fset = pkg.FileSet()
+		info = pkg.TypesInfo()
+		file = pgf.File
+	)
+	curExprs, err := canExtractToPackageLevel(pkg, pgf.Cursor(), start, end)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot extract: %v", err)
+	}
+	curExpr := curExprs[0]
+	expr := curExpr.Node().(ast.Expr)
+	constant := info.Types[expr].Value != nil
+
+	// Choose a name that neither collides with a package-level
+	// declaration or an import of any file, nor is shadowed where
+	// the expression appears.
+	scope := info.Scopes[file].Innermost(expr.Pos())
+	hasCollision := func(name string) bool {
+		if _, obj := scope.LookupParent(name, expr.Pos()); obj != nil {
+			return true
+		}
+		for _, f := range pkg.Syntax() {
+			if info.Scopes[f].Lookup(name) != nil {
+				return true
+			}
+		}
+		return false
+	}
+	name, _ := generateName(0, contextualName(info, curExpr, cond(constant, "newConst", "newVar")), hasCollision)
+
+	// Insert the declaration before the enclosing function, and its doc comment.
+	decl, _ := cursorutil.FirstEnclosing[*ast.FuncDecl](curExpr)
+	insertPos := decl.Pos()
+	if decl.Doc != nil {
+		insertPos = decl.Doc.Pos()
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, &ast.GenDecl{
+		Tok: cond(constant, token.CONST, token.VAR),
+		Specs: []ast.Spec{
+			&ast.ValueSpec{
+				Names:  []*ast.Ident{ast.NewIdent(name)},
+				Values: []ast.Expr{expr},
+			},
+		},
+	}); err != nil {
+		return nil, nil, err
+	}
+	buf.WriteString("\n\n")
+	return fset, &analysis.SuggestedFix{
+		TextEdits: []analysis.TextEdit{
+			{
+				Pos:     insertPos,
+				End:     insertPos,
+				NewText: buf.Bytes(),
+			},
+			{
+				Pos:     expr.Pos(),
+				End:     expr.End(),
+				NewText: []byte(name),
+			},
+		},
+	}, nil
+}
+
+// canExtractToPackageLevel reports whether the expression in the given
+// range can be extracted to a package-level constant or variable. It
+// returns (a singleton slice of the cursor for) the selected
+// expression, which must be a single-valued expression within the body
+// of a function that refers to no local declarations.
+func canExtractToPackageLevel(pkg *cache.Package, curFile inspector.Cursor, start, end token.Pos) ([]inspector.Cursor, error) {
+	info := pkg.TypesInfo()
+	curExprs, err := canExtractVariable(info, curFile, start, end, false)
+	if err != nil {
+		return nil, err
+	}
+	curExpr := curExprs[0]
+	expr := curExpr.Node().(ast.Expr)
+	if decl, _ := cursorutil.FirstEnclosing[*ast.FuncDecl](curExpr); decl == nil || decl.Body == nil ||
+		!astutil.NodeContainsPos(decl.Body, expr.Pos()) {
+		return nil, fmt.Errorf("expression is not within a function body")
+	}
+	if _, ok := info.TypeOf(expr).(*types.Tuple); ok {
+		return nil, fmt.Errorf("expression has multiple values")
+	}
+	for cur := range curExpr.Preorder((*ast.Ident)(nil)) {
+		obj := info.Uses[cur.Node().(*ast.Ident)]
+		if obj == nil || obj.Pkg() != pkg.Types() || obj.Parent() == nil || astutil.NodeContainsPos(expr, obj.Pos()) {
+			continue // e.g. an imported or universal object, field, method, or declaration within the expression
+		}
+		if obj.Parent() != pkg.Types().Scope() && obj.Parent().Parent() != pkg.Types().Scope() { // not a package or file scope
+			return nil, fmt.Errorf("expression refers to local %s", obj.Name())
+		}
+	}
+	return curExprs, nil
+}
+
+// contextualName returns a name for the value of the expression at
+// cur, derived from its context: the parameter to which it is passed,
+// the field it initializes, or (prefixed by "default", as the name
+// itself is taken) the variable to which it is assigned. The name is
+// unexported. It returns def if the context suggests no name.
+func contextualName(info *types.Info, cur inspector.Cursor, def string) string {
+	var name, prefix string
+	switch parent := cur.Parent().Node().(type) {
+	case *ast.CallExpr:
+		if sig, ok := info.TypeOf(parent.Fun).Underlying().(*types.Signature); ok && cur.ParentEdgeKind() == edge.CallExpr_Args {
+			i := cur.ParentEdgeIndex()
+			if sig.Variadic() && i >= sig.Params().Len()-1 {
+				i = sig.Params().Len() - 1
+			}
+			if i < sig.Params().Len() {
+				name = sig.Params().At(i).Name()
+			}
+		}
+	case *ast.KeyValueExpr:
+		if key, ok := parent.Key.(*ast.Ident); ok && cur.ParentEdgeKind() == edge.KeyValueExpr_Value {
+			if v, ok := info.Uses[key].(*types.Var); ok && v.IsField() {
+				name = key.Name
+			}
+		}
+	case *ast.AssignStmt:
+		if cur.ParentEdgeKind() == edge.AssignStmt_Rhs && len(parent.Lhs) == len(parent.Rhs) {
+			if id, ok := parent.Lhs[cur.ParentEdgeIndex()].(*ast.Ident); ok {
+				name, prefix = id.Name, "default"
+			}
+		}
+	case *ast.ValueSpec:
+		if cur.ParentEdgeKind() == edge.ValueSpec_Values && len(parent.Names) == len(parent.Values) {
+			name, prefix = parent.Names[cur.ParentEdgeIndex()].Name, "default"
+		}
+	}
+	if name == "" || name == "_" || !('A' <= name[0] && name[0] <= 'Z' || 'a' <= name[0] && name[0] <= 'z') {
+		return def
+	}
+	if prefix != "" {
+		return prefix + strings.ToUpper(name[:1]) + name[1:]
+	}
+	// Unexport the name, including an initialism: "URLPath" -> "urlPath".
+	upper := strings.IndexFunc(name, unicode.IsLower)
+	switch {
+	case upper < 0:
+		name = strings.ToLower(name)
+	case upper > 1:
+		name = strings.ToLower(name[:upper-1]) + name[upper-1:]
+	default:
+		name = strings.ToLower(name[:1]) + name[1:]
+	}
+	return name
+}
+
 // stmtToInsertVarBefore returns the ast.Stmt before which we can safely insert a new variable,
 // and ensures that the new declaration is inserted at a point where all free variables are declared before.
 // Some examples:
@@ -38,6 +38,15 @@ func canExtractToNewFile(pgf *parsego.File, start, end token.Pos) bool {
 //
 // TODO: handle dot imports.
 func findImportEdits(file *ast.File, info *types.Info, start, end token.Pos) (adds, deletes []*ast.ImportSpec, _ error) {
+	return findImportEditsFunc(file, info, func(id *ast.Ident) bool {
+		return posRangeContains(start, end, id.Pos(), id.End())
+	})
+}
+
+// findImportEditsFunc is like [findImportEdits], but the extracted
+// portion of the file is defined by the selected predicate, which
+// reports whether an identifier is extracted.
+func findImportEditsFunc(file *ast.File, info *types.Info, selected func(*ast.Ident) bool) (adds, deletes []*ast.ImportSpec, _ error) {
 	// make a map from a pkgName to its references
 	pkgNameReferences := make(map[*types.PkgName][]*ast.Ident)
 	for ident, use := range info.Uses {
@@ -62,7 +71,7 @@ func findImportEdits(file *ast.File, info *types.Info, start, end token.Pos) (ad
 		usedInSelection := false
 		usedInNonSelection := false
 		for _, ident := range pkgNameReferences[pkgName] {
-			if posRangeContains(start, end, ident.Pos(), ident.End()) {
+			if selected(ident) {
 				usedInSelection = true
 			} else {
 				usedInNonSelection = true
@@ -8,6 +8,8 @@ import (
 	"context"
 	"fmt"
 	"go/token"
+	"maps"
+	"slices"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/gopls/internal/analysis/fillstruct"
@@ -52,6 +54,7 @@ func singleFile(fixer1 singleFileFixer) fixer {
 const (
 	fixExtractVariable         = "extract_variable" // (or constant)
 	fixExtractVariableAll      = "extract_variable_all"
+	fixExtractToPackageLevel   = "extract_to_package_level" // (constant or variable)
 	fixExtractFunction         = "extract_function"
 	fixExtractMethod           = "extract_method"
 	fixInlineCall              = "inline_call" // keep consistent with go/analysis/passes/inline Diagnostic.Category
@@ -62,6 +65,7 @@ const (
 	fixCreateUndeclared        = "create_undeclared"
 	fixMissingInterfaceMethods = "stub_missing_interface_method"
 	fixMissingCalledFunction   = "stub_missing_called_function"
+	fixReorderFields           = "reorder_fields"
 )
 
 // ApplyFix applies the specified kind of suggested fix to the given
@@ -100,12 +104,14 @@ func ApplyFix(ctx context.Context, fix string, snapshot *cache.Snapshot, fh file
 		fixExtractMethod:           singleFile(extractMethod),
 		fixExtractVariable:         singleFile(extractVariableOne),
 		fixExtractVariableAll:      singleFile(extractVariableAll),
+		fixExtractToPackageLevel:   singleFile(extractToPackageLevel),
 		fixInlineCall:              inlineCall,
 		fixInlineVariable:          singleFile(inlineVariableOne),
 		fixInvertIfCondition:       singleFile(invertIfCondition),
 		fixSplitLines:              singleFile(splitLines),
 		fixJoinLines:               singleFile(joinLines),
 		fixCreateUndeclared:        singleFile(createUndeclared),
+		fixReorderFields:           singleFile(reorderFields),
 		fixMissingInterfaceMethods: stubMissingInterfaceMethodsFixer,
 		fixMissingCalledFunction:   stubMissingCalledFunctionFixer,
 	}
@@ -144,6 +150,8 @@ func ApplyFix(ctx context.Context, fix string, snapshot *cache.Snapshot, fh file
 				bug.Report("no token.File for TextEdit.Pos (#68818)")
 			case fixExtractVariableAll:
 				bug.Report("no token.File for TextEdit.Pos (#68818)")
+			case fixExtractToPackageLevel:
+				bug.Report("no token.File for TextEdit.Pos (#68818)")
 			case fixInlineCall:
 				bug.Report("no token.File for TextEdit.Pos (#68818)")
 			case fixInlineVariable:
@@ -170,50 +178,30 @@ func ApplyFix(ctx context.Context, fix string, snapshot *cache.Snapshot, fh file
 
 // suggestedFixToDocumentChange converts the suggestion's edits from analysis form into protocol form.
 func suggestedFixToDocumentChange(ctx context.Context, snapshot *cache.Snapshot, fset *token.FileSet, suggestion *analysis.SuggestedFix) ([]protocol.DocumentChange, error) {
-	type fileInfo struct {
-		fh     file.Handle
-		mapper *protocol.Mapper
-		edits  []protocol.TextEdit
-	}
-	files := make(map[protocol.DocumentURI]*fileInfo)
 	for _, edit := range suggestion.TextEdits {
-		tokFile := fset.File(edit.Pos)
-		if tokFile == nil {
+		if fset.File(edit.Pos) == nil {
 			return nil, bug.Errorf("no file for edit position (#68818)")
 		}
-		end := edit.End
-		if !end.IsValid() {
-			end = edit.Pos
-		}
-		uri := protocol.URIFromPath(tokFile.Name())
-		info, ok := files[uri]
-		if !ok {
-			// First edit: create a mapper.
-			fh, err := snapshot.ReadFile(ctx, uri)
-			if err != nil {
-				return nil, err
-			}
-			content, err := fh.Content()
-			if err != nil {
-				return nil, err
-			}
-			mapper := protocol.NewMapper(uri, content)
-			info = &fileInfo{fh, mapper, nil}
-			files[uri] = info
+	}
+	fhs := make(map[protocol.DocumentURI]file.Handle)
+	edits, err := protocol.FixEdits(fset, suggestion.TextEdits, func(uri protocol.DocumentURI) (*protocol.Mapper, error) {
+		fh, err := snapshot.ReadFile(ctx, uri)
+		if err != nil {
+			return nil, err
 		}
-		rng, err := info.mapper.PosRange(tokFile, edit.Pos, end)
+		content, err := fh.Content()
 		if err != nil {
 			return nil, err
 		}
-		info.edits = append(info.edits, protocol.TextEdit{
-			Range:   rng,
-			NewText: string(edit.NewText),
-		})
+		fhs[uri] = fh
+		return protocol.NewMapper(uri, content), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid fix %q: %v", suggestion.Message, err)
 	}
 	var changes []protocol.DocumentChange
-	for _, info := range files {
-		change := protocol.DocumentChangeEdit(info.fh, info.edits)
-		changes = append(changes, change)
+	for _, uri := range slices.Sorted(maps.Keys(edits)) {
+		changes = append(changes, protocol.DocumentChangeEdit(fhs[uri], edits[uri]))
 	}
 	return changes, nil
 }
@@ -18,7 +18,7 @@ import (
 	"go/token"
 	"go/types"
 	"go/version"
-	"io/fs"
+	"os"
 	"path/filepath"
 	"slices"
 	"sort"
@@ -28,10 +28,12 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"golang.org/x/mod/modfile"
 	"golang.org/x/text/unicode/runenames"
 	goastutil "golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/ast/edge"
 	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/go/types/typeutil"
 	"golang.org/x/tools/gopls/internal/cache"
 	"golang.org/x/tools/gopls/internal/cache/metadata"
@@ -316,6 +318,11 @@ func hover(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, rng pr
 	// from which the request originated.
 	qual := typesinternal.FileQualifier(pgf.File, pkg.Types())
 
+	// Handle hovering over the func keyword of a function literal.
+	if ek, _ := cur.ParentEdge(); ek == edge.FuncLit_Type {
+		return hoverFuncLit(pkg, pgf, cur.Parent(), qual)
+	}
+
 	// Handle hovering over various special kinds of syntax node.
 	switch node := cur.Node().(type) {
 	// (import paths were handled above)
@@ -941,13 +948,120 @@ func hoverPackageRef(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.P
 	}
 
 	docText := comment.Text()
+	var footer string
+	if m := impMetadata.Module; snapshot.Options().ImportModuleInHover && m != nil && !m.Main {
+		footer = moduleFooter(ctx, snapshot, m)
+	}
 	return &hoverResult{
 		Signature:         "package " + string(impMetadata.Name),
 		Synopsis:          doc.Synopsis(docText),
 		FullDocumentation: docText,
+		footer:            footer,
 	}, nil
 }
 
+// moduleFooter returns a description of the module mod, for the hover
+// of an import path: its path and version, its replacement if any, the
+// license found in its directory, and its deprecation notice.
+func moduleFooter(ctx context.Context, snapshot *cache.Snapshot, mod *packages.Module) string {
+	var footer strings.Builder
+	fmt.Fprintf(&footer, " - Module: %s", mod.Path)
+	if mod.Version != "" {
+		fmt.Fprintf(&footer, "@%s", mod.Version)
+	}
+	if r := mod.Replace; r != nil {
+		fmt.Fprintf(&footer, " (replaced by %s", r.Path)
+		if r.Version != "" {
+			fmt.Fprintf(&footer, "@%s", r.Version)
+		}
+		footer.WriteString(")")
+		mod = r // the replacement provides the files
+	}
+
+	if mod.Dir != "" {
+		if name, license := detectLicense(mod.Dir); name != "" {
+			fmt.Fprintf(&footer, "\n - License: %s (%s)", license, name)
+		}
+	}
+
+	if mod.GoMod != "" {
+		if fh, err := snapshot.ReadFile(ctx, protocol.URIFromPath(mod.GoMod)); err == nil {
+			if content, err := fh.Content(); err == nil {
+				if f, err := modfile.ParseLax(mod.GoMod, content, nil); err == nil && f.Module != nil && f.Module.Deprecated != "" {
+					fmt.Fprintf(&footer, "\n - Deprecated: %s", f.Module.Deprecated)
+				}
+			}
+		}
+	}
+	return footer.String()
+}
+
+// detectLicense returns the name of the license file in dir, if any,
+// and the identifier of the license it contains, or "unknown".
+//
+// Only the most common licenses are recognized, by phrases of their
+// text; the result is a hint, not a legal determination.
+func detectLicense(dir string) (filename, license string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", ""
+	}
+	for _, e := range entries {
+		name := strings.ToUpper(e.Name())
+		if e.IsDir() || !(strings.HasPrefix(name, "LICENSE") || strings.HasPrefix(name, "LICENCE") || strings.HasPrefix(name, "COPYING")) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		return e.Name(), classifyLicense(string(data))
+	}
+	return "", ""
+}
+
+// classifyLicense returns the SPDX identifier of the license text, or
+// "unknown".
+func classifyLicense(text string) string {
+	text = strings.Join(strings.Fields(text), " ") // normalize line breaks
+	has := func(phrases ...string) bool {
+		for _, phrase := range phrases {
+			if !strings.Contains(text, phrase) {
+				return false
+			}
+		}
+		return true
+	}
+	switch {
+	case has("Apache License", "Version 2.0"):
+		return "Apache-2.0"
+	case has("Mozilla Public License", "2.0"):
+		return "MPL-2.0"
+	case has("GNU LESSER GENERAL PUBLIC LICENSE", "Version 3"):
+		return "LGPL-3.0"
+	case has("GNU LESSER GENERAL PUBLIC LICENSE"):
+		return "LGPL-2.1"
+	case has("GNU AFFERO GENERAL PUBLIC LICENSE"):
+		return "AGPL-3.0"
+	case has("GNU GENERAL PUBLIC LICENSE", "Version 3"):
+		return "GPL-3.0"
+	case has("GNU GENERAL PUBLIC LICENSE", "Version 2"):
+		return "GPL-2.0"
+	case has("Permission is hereby granted, free of charge"):
+		return "MIT"
+	case has("Permission to use, copy, modify, and/or distribute this software for any purpose"),
+		has("Permission to use, copy, modify, and distribute this software for any purpose"):
+		return "ISC"
+	case has("Redistribution and use in source and binary forms", "Neither the name"):
+		return "BSD-3-Clause"
+	case has("Redistribution and use in source and binary forms"):
+		return "BSD-2-Clause"
+	case has("This is free and unencumbered software released into the public domain"):
+		return "Unlicense"
+	}
+	return "unknown"
+}
+
 // hoverPackageName computes hover information for the package name of the file
 // pgf in pkg.
 func hoverPackageName(pkg *cache.Package, pgf *parsego.File) (protocol.Range, *hoverResult, error) {
@@ -1189,39 +1303,143 @@ func hoverReturnStatement(pgf *parsego.File, curReturn inspector.Cursor) (protoc
 	}, nil
 }
 
-// hoverEmbed computes hover information for a filepath.Match pattern.
-// Assumes that the pattern is relative to the location of fh.
-func hoverEmbed(fh file.Handle, rng protocol.Range, pattern string) (protocol.Range, *hoverResult, error) {
-	s := &strings.Builder{}
+// hoverFuncLit computes hover information for a function literal:
+// its type, and the local variables it captures, with their sizes and
+// whether the gc compiler captures them by value or by reference.
+func hoverFuncLit(pkg *cache.Package, pgf *parsego.File, curLit inspector.Cursor, qual types.Qualifier) (protocol.Range, *hoverResult, error) {
+	var (
+		lit   = curLit.Node().(*ast.FuncLit)
+		info  = pkg.TypesInfo()
+		sizes = pkg.TypesSizes()
+	)
+	rng, err := pgf.PosRange(lit.Type.Func, lit.Type.Func+token.Pos(len("func")))
+	if err != nil {
+		return protocol.Range{}, nil, err
+	}
 
-	dir := fh.URI().DirPath()
-	var matches []string
-	err := filepath.WalkDir(dir, func(abs string, d fs.DirEntry, e error) error {
-		if e != nil {
-			return e
+	// The captured variables are the free local variables of the literal.
+	var captured []*types.Var
+	for _, ref := range freeRefs(pkg.Types(), info, pgf.File, lit.Pos(), lit.End()) {
+		if v, ok := ref.objects[0].(*types.Var); ok && ref.scope == "local" && !slices.Contains(captured, v) {
+			captured = append(captured, v)
 		}
-		rel, err := filepath.Rel(dir, abs)
-		if err != nil {
-			return err
+	}
+
+	// Like the compiler, treat a variable as modified if it is assigned
+	// (other than by its declaration) or has its address taken anywhere,
+	// even through its fields or array elements.
+	modified := make(map[*types.Var]bool)
+	for curId := range pgf.Cursor().Preorder((*ast.Ident)(nil)) {
+		if v, ok := info.Uses[curId.Node().(*ast.Ident)].(*types.Var); ok && slices.Contains(captured, v) && !modified[v] {
+			modified[v] = isModifiedRef(info, curId)
 		}
-		ok, err := filepath.Match(pattern, rel)
-		if err != nil {
-			return err
+	}
+
+	// The closure object holds a pointer to the code of the
+	// function, followed by the captured variables, or pointers
+	// to them if they are captured by reference.
+	fields := []*types.Var{types.NewField(token.NoPos, nil, "F", types.Typ[types.Uintptr], false)}
+	var buf strings.Builder
+	for _, v := range captured {
+		size := sizes.Sizeof(v.Type())
+		var reason string
+		switch {
+		case modified[v]:
+			reason = "it is modified"
+		case typesinternal.GetVarKind(v) == typesinternal.ResultVar:
+			reason = "it is a named result"
+		case size > 128:
+			reason = "it is larger than 128 bytes"
+		}
+		fmt.Fprintf(&buf, "  - %s %s (%d bytes): ", v.Name(), types.TypeString(v.Type(), qual), size)
+		if reason == "" {
+			buf.WriteString("by value\n")
+			fields = append(fields, types.NewField(token.NoPos, nil, v.Name(), v.Type(), false))
+		} else {
+			fmt.Fprintf(&buf, "by reference, as %s; it is heap-allocated if the closure escapes\n", reason)
+			fields = append(fields, types.NewField(token.NoPos, nil, v.Name(), types.NewPointer(v.Type()), false))
 		}
-		if ok && !d.IsDir() {
-			matches = append(matches, rel)
+	}
+
+	synopsis := "function literal"
+	doc := synopsis
+	if len(captured) > 0 {
+		closureSize := sizes.Sizeof(types.NewStruct(fields, nil))
+		synopsis = fmt.Sprintf("function literal capturing %d variable%s (closure size %d bytes)",
+			len(captured), cond(len(captured) == 1, "", "s"), closureSize)
+		doc = synopsis + ":\n\n" + buf.String()
+	}
+	return rng, &hoverResult{
+		Synopsis:          synopsis,
+		FullDocumentation: doc,
+		Signature:         types.TypeString(info.TypeOf(lit), qual),
+	}, nil
+}
+
+// isModifiedRef reports whether the reference to a variable at
+// curId assigns it, or takes its address, directly or through its
+// fields or array elements.
+func isModifiedRef(info *types.Info, curId inspector.Cursor) bool {
+	for cur := curId; ; cur = cur.Parent() {
+		switch ek, _ := cur.ParentEdge(); ek {
+		case edge.ParenExpr_X:
+			// continue
+		case edge.SelectorExpr_X:
+			sel, ok := info.Selections[cur.Parent().Node().(*ast.SelectorExpr)]
+			if !ok || sel.Indirect() {
+				return false // qualified identifier, or indirection
+			}
+			if sel.Kind() == types.MethodVal {
+				// A call of a pointer method takes the operand's address.
+				return is[*types.Pointer](sel.Obj().(*types.Func).Signature().Recv().Type()) &&
+					!is[*types.Pointer](types.Unalias(sel.Recv()))
+			}
+		case edge.IndexExpr_X, edge.SliceExpr_X:
+			if _, ok := info.TypeOf(cur.Node().(ast.Expr)).Underlying().(*types.Array); !ok {
+				return false // elements of slices and maps are not part of the variable
+			}
+			if ek == edge.SliceExpr_X {
+				return true // slicing an array takes its address
+			}
+		case edge.UnaryExpr_X:
+			return cur.Parent().Node().(*ast.UnaryExpr).Op == token.AND
+		case edge.AssignStmt_Lhs, edge.IncDecStmt_X, edge.RangeStmt_Key, edge.RangeStmt_Value:
+			return true
+		default:
+			return false
 		}
-		return nil
-	})
+	}
+}
+
+// hoverEmbed computes hover information for a filepath.Match pattern:
+// the list of matched files, and their total size.
+// Assumes that the pattern is relative to the location of fh.
+func hoverEmbed(fh file.Handle, rng protocol.Range, pattern string) (protocol.Range, *hoverResult, error) {
+	s := &strings.Builder{}
+
+	dir := fh.URI().DirPath()
+	matches, err := embedMatches(dir, pattern)
 	if err != nil {
 		return protocol.Range{}, nil, err
 	}
 
+	var total int64
 	for _, m := range matches {
+		if info, err := os.Stat(filepath.Join(dir, m)); err == nil {
+			total += info.Size()
+		}
 		// TODO: Renders each file as separate markdown paragraphs.
 		// If forcing (a single) newline is possible it might be more clear.
 		fmt.Fprintf(s, "%s\n\n", m)
 	}
+	switch len(matches) {
+	case 0:
+		s.WriteString("No matching files")
+	case 1:
+		fmt.Fprintf(s, "1 file, %d bytes", total)
+	default:
+		fmt.Fprintf(s, "%d files, %d bytes", len(matches), total)
+	}
 
 	res := &hoverResult{
 		Signature:         fmt.Sprintf("Embedding %q", pattern),
@@ -107,3 +107,20 @@ func TestFindDeclInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestClassifyLicense(t *testing.T) {
+	for _, test := range []struct{ text, want string }{
+		{"Apache License\n   Version 2.0, January 2004", "Apache-2.0"},
+		{"Permission is hereby granted, free of charge,\nto any person", "MIT"},
+		{"Redistribution and use in source and binary forms ...\nNeither the name of Google Inc. nor the names", "BSD-3-Clause"},
+		{"Redistribution and use in source and binary forms ...", "BSD-2-Clause"},
+		{"GNU GENERAL PUBLIC LICENSE\nVersion 3, 29 June 2007", "GPL-3.0"},
+		{"GNU LESSER GENERAL PUBLIC LICENSE\nVersion 3, 29 June 2007", "LGPL-3.0"},
+		{"This is free and unencumbered software released into the public domain.", "Unlicense"},
+		{"All rights reserved.", "unknown"},
+	} {
+		if got := classifyLicense(test.text); got != test.want {
+			t.Errorf("classifyLicense(%q) = %s, want %s", test.text, got, test.want)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/tools/go/ast/edge"
 	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/objectpath"
 	"golang.org/x/tools/go/types/typeutil"
 	"golang.org/x/tools/gopls/internal/cache"
 	"golang.org/x/tools/gopls/internal/cache/metadata"
@@ -84,6 +85,11 @@ func implementations(ctx context.Context, snapshot *cache.Snapshot, fh file.Hand
 		return locs, err
 	}
 
+	// Find functions assigned to a variable or type of function type.
+	if locs, err := implAssigned(ctx, snapshot, pkg, cur); err != errNotHandled {
+		return locs, err
+	}
+
 	// Find implementations based on method sets.
 	var (
 		locsMu sync.Mutex
@@ -1116,3 +1122,300 @@ func dynamicFuncCallType(info *types.Info, call *ast.CallExpr) types.Type {
 func inToken(tokPos token.Pos, tokStr string, start, end token.Pos) bool {
 	return tokPos <= start && end <= tokPos+token.Pos(len(tokStr))
 }
+
+// implAssigned finds Implementations of a variable (or struct field)
+// of function type, or of a named function type without methods,
+// based on assignments.
+//
+// Whereas implFuncs relates function types to all functions whose
+// signatures match, implAssigned reports only the functions that are
+// actually assigned to the selected variable or type: the "func"
+// token of each function literal, and the declaring identifier of
+// each named function or method, that is assigned, passed, returned,
+// converted, or used in a composite literal where a value of the
+// variable or type is expected. For example, a query on the
+// identifier OnClick in
+//
+//	type Button struct { OnClick func() }
+//
+// reports the functions in Button{OnClick: ...} literals and in
+// assignments b.OnClick = .... The search extends to reverse
+// dependencies unless the variable is local to a function.
+//
+// A named function type that has methods, such as http.HandlerFunc,
+// is not handled here, nor are variables of such a type; queries on
+// them use method sets.
+//
+// implAssigned returns errNotHandled to indicate that the selected
+// identifier is not such a variable or type.
+func implAssigned(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Package, cur inspector.Cursor) ([]protocol.Location, error) {
+	id, ok := cur.Node().(*ast.Ident)
+	if !ok {
+		return nil, errNotHandled
+	}
+	info := pkg.TypesInfo()
+	obj := info.Uses[id]
+	if obj == nil {
+		obj = info.Defs[id]
+	}
+	switch obj := obj.(type) {
+	case *types.Var:
+		if named, ok := types.Unalias(obj.Type()).(*types.Named); ok && named.NumMethods() > 0 {
+			return nil, errNotHandled // e.g. an http.HandlerFunc: use method sets
+		}
+		if _, ok := obj.Type().Underlying().(*types.Signature); !ok {
+			return nil, errNotHandled
+		}
+	case *types.TypeName:
+		named, ok := obj.Type().(*types.Named)
+		if !ok || named.NumMethods() > 0 || obj.IsAlias() {
+			return nil, errNotHandled
+		}
+		if _, ok := named.Underlying().(*types.Signature); !ok {
+			return nil, errNotHandled
+		}
+	default:
+		return nil, errNotHandled
+	}
+	if obj.Pkg() == nil || !obj.Pos().IsValid() {
+		return nil, errNotHandled
+	}
+
+	// Type-check the declaring package and, unless the object is
+	// local to a function, its reverse dependencies.
+	var (
+		declPosn   = safetoken.StartPosition(pkg.FileSet(), obj.Pos())
+		declURI    = protocol.URIFromPath(declPosn.Filename)
+		declPath   = PackagePath(obj.Pkg().Path())
+		objPath, _ = objectpath.For(obj)
+	)
+	pkgs, err := typeCheckReverseDependencies(ctx, snapshot, declURI, objPath != "")
+	if err != nil {
+		return nil, err
+	}
+
+	var locs []protocol.Location
+	for _, pkg := range pkgs {
+		// Find the query object in this package.
+		var target types.Object
+		if pkg.Metadata().PkgPath == declPath {
+			declFile, err := pkg.File(declURI)
+			if err != nil {
+				continue // a variant that does not contain the file
+			}
+			pos, err := safetoken.Pos(declFile.Tok, declPosn.Offset)
+			if err != nil {
+				return nil, err
+			}
+			curIdent, ok := declFile.Cursor().FindByPos(pos, pos)
+			if !ok {
+				continue
+			}
+			if id, ok := curIdent.Node().(*ast.Ident); ok {
+				target = pkg.TypesInfo().Defs[id]
+			}
+		} else if objPath != "" {
+			if declPkg := pkg.DependencyTypes(declPath); declPkg != nil {
+				target, _ = objectpath.Object(declPkg, objPath)
+			}
+		}
+		if target == nil {
+			continue // package does not refer to the object
+		}
+
+		for _, pgf := range pkg.CompiledGoFiles() {
+			for dest, src := range assignedFuncs(pkg.TypesInfo(), pgf.Cursor()) {
+				if !assignsTo(dest, target) {
+					continue
+				}
+				var loc protocol.Location
+				switch src := src.(type) {
+				case *ast.FuncLit:
+					loc, err = pgf.PosLocation(src.Type.Func, src.Type.Func+token.Pos(len("func")))
+				case *types.Func:
+					loc, err = ObjectLocation(ctx, pkg.FileSet(), snapshot, src)
+				}
+				if err != nil {
+					return nil, err
+				}
+				locs = append(locs, loc)
+			}
+		}
+	}
+	return locs, nil
+}
+
+// A destination is a variable or type to which a value is assigned.
+type destination struct {
+	v *types.Var // variable, field, or parameter, if any
+	t types.Type // type of the destination
+}
+
+// assignsTo reports whether dest is the variable target, or has the
+// named type target.
+func assignsTo(dest destination, target types.Object) bool {
+	switch target := target.(type) {
+	case *types.Var:
+		return dest.v != nil && dest.v.Origin() == target
+	case *types.TypeName:
+		named, ok := types.Unalias(dest.t).(*types.Named)
+		return ok && named.Origin().Obj() == target
+	}
+	return false
+}
+
+// assignedFuncs returns an iterator over the functions assigned
+// within the file at curFile, and their destinations. Each function is
+// an *ast.FuncLit or the *types.Func of a named function or method.
+func assignedFuncs(info *types.Info, curFile inspector.Cursor) iter.Seq2[destination, any] {
+	return func(yield func(destination, any) bool) {
+		// visit yields the function value of expression e, if any.
+		visit := func(dest destination, e ast.Expr) bool {
+			switch e := ast.Unparen(e).(type) {
+			case *ast.FuncLit:
+				return yield(dest, e)
+			case *ast.Ident, *ast.SelectorExpr, *ast.IndexExpr, *ast.IndexListExpr:
+				if fn, ok := typeutil.Callee(info, &ast.CallExpr{Fun: e}).(*types.Func); ok {
+					return yield(dest, fn.Origin())
+				}
+			}
+			return true
+		}
+		// varOf returns the variable denoted by the assigned expression e.
+		varOf := func(e ast.Expr) *types.Var {
+			switch e := ast.Unparen(e).(type) {
+			case *ast.Ident:
+				v, _ := info.ObjectOf(e).(*types.Var)
+				return v
+			case *ast.SelectorExpr:
+				v, _ := info.Uses[e.Sel].(*types.Var)
+				return v
+			}
+			return nil
+		}
+		// params yields the arguments of a call and their parameters.
+		params := func(sig *types.Signature, fn *types.Func, call *ast.CallExpr) bool {
+			if fn != nil {
+				sig = fn.Origin().Signature() // for identity of parameters
+			}
+			n := sig.Params().Len()
+			for i, arg := range call.Args {
+				if n == 0 {
+					break
+				}
+				param := sig.Params().At(min(i, n-1))
+				t := param.Type()
+				if sig.Variadic() && i >= n-1 && !call.Ellipsis.IsValid() {
+					if s, ok := t.Underlying().(*types.Slice); ok {
+						t = s.Elem()
+					}
+				} else if i >= n {
+					break
+				}
+				if !visit(destination{param, t}, arg) {
+					return false
+				}
+			}
+			return true
+		}
+
+		for cur := range curFile.Preorder(
+			(*ast.AssignStmt)(nil),
+			(*ast.ValueSpec)(nil),
+			(*ast.CompositeLit)(nil),
+			(*ast.CallExpr)(nil),
+			(*ast.ReturnStmt)(nil),
+		) {
+			switch n := cur.Node().(type) {
+			case *ast.AssignStmt:
+				if len(n.Lhs) == len(n.Rhs) {
+					for i, rhs := range n.Rhs {
+						if !visit(destination{varOf(n.Lhs[i]), info.TypeOf(n.Lhs[i])}, rhs) {
+							return
+						}
+					}
+				}
+
+			case *ast.ValueSpec:
+				if len(n.Names) == len(n.Values) {
+					for i, rhs := range n.Values {
+						v, _ := info.Defs[n.Names[i]].(*types.Var)
+						if v != nil && !visit(destination{v, v.Type()}, rhs) {
+							return
+						}
+					}
+				}
+
+			case *ast.CompositeLit:
+				t := info.TypeOf(n)
+				if t == nil {
+					continue
+				}
+				switch u := typesinternal.Unpointer(t).Underlying().(type) {
+				case *types.Struct:
+					for i, elt := range n.Elts {
+						var field *types.Var
+						if kv, ok := elt.(*ast.KeyValueExpr); ok {
+							if key, ok := kv.Key.(*ast.Ident); ok {
+								field, _ = info.Uses[key].(*types.Var)
+							}
+							elt = kv.Value
+						} else if i < u.NumFields() {
+							field = u.Field(i)
+						}
+						if field != nil && !visit(destination{field, field.Type()}, elt) {
+							return
+						}
+					}
+				case interface{ Elem() types.Type }: // array, slice, map
+					for _, elt := range n.Elts {
+						if kv, ok := elt.(*ast.KeyValueExpr); ok {
+							elt = kv.Value
+						}
+						if !visit(destination{nil, u.Elem()}, elt) {
+							return
+						}
+					}
+				}
+
+			case *ast.CallExpr:
+				tv, ok := info.Types[n.Fun]
+				if !ok {
+					continue
+				}
+				if tv.IsType() {
+					// conversion T(f)
+					if len(n.Args) == 1 && !visit(destination{nil, tv.Type}, n.Args[0]) {
+						return
+					}
+				} else if sig, ok := tv.Type.Underlying().(*types.Signature); ok {
+					fn, _ := typeutil.Callee(info, n).(*types.Func)
+					if !params(sig, fn, n) {
+						return
+					}
+				}
+
+			case *ast.ReturnStmt:
+				var sig *types.Signature
+				if curFn, ok := moreiters.First(cur.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil))); ok {
+					switch fn := curFn.Node().(type) {
+					case *ast.FuncDecl:
+						if f, ok := info.Defs[fn.Name].(*types.Func); ok {
+							sig = f.Signature()
+						}
+					case *ast.FuncLit:
+						sig, _ = info.TypeOf(fn).(*types.Signature)
+					}
+				}
+				if sig != nil && sig.Results().Len() == len(n.Results) {
+					for i, res := range n.Results {
+						v := sig.Results().At(i)
+						if !visit(destination{v, v.Type()}, res) {
+							return
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+// This file defines the gopls.import_graph command, which reports
+// the import graph of the workspace packages.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"sort"
+
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/protocol/command"
+	"golang.org/x/tools/internal/graph"
+	"golang.org/x/tools/internal/graph/graphfmt"
+)
+
+// ImportGraph computes the import graph of the workspace packages
+// of the snapshot, and renders it in the specified format ("dot" or
+// "json"; the empty string means "dot").
+//
+// The graph is computed purely from metadata, so it doesn't cause or
+// wait for type checking. Test variants are ignored: each node
+// represents the ordinary (non-test) variant of a package.
+func ImportGraph(ctx context.Context, snapshot *cache.Snapshot, format string) (command.ImportGraphResult, error) {
+	switch format {
+	case "", "dot", "json":
+	default:
+		return command.ImportGraphResult{}, fmt.Errorf("invalid import graph format %q (want dot or json)", format)
+	}
+
+	mps, err := snapshot.WorkspaceMetadata(ctx)
+	if err != nil {
+		return command.ImportGraphResult{}, err
+	}
+
+	g := &importGraph{pkgs: make(map[string]*command.ImportGraphPackage)}
+	for _, mp := range mps {
+		if mp.ForTest != "" || mp.IsIntermediateTestVariant() {
+			continue
+		}
+		path := string(mp.PkgPath)
+		if _, ok := g.pkgs[path]; ok {
+			continue
+		}
+		lines := 0
+		for _, uri := range mp.GoFiles {
+			fh, err := snapshot.ReadFile(ctx, uri)
+			if err != nil {
+				return command.ImportGraphResult{}, err // e.g. context cancelled
+			}
+			content, err := fh.Content()
+			if err != nil {
+				continue // file may have been deleted
+			}
+			lines += bytes.Count(content, []byte("\n"))
+		}
+		var imports []string
+		for depPath := range mp.DepsByPkgPath {
+			imports = append(imports, string(depPath))
+		}
+		g.pkgs[path] = &command.ImportGraphPackage{
+			Path:    path,
+			Lines:   lines,
+			Imports: imports,
+		}
+		g.paths = append(g.paths, path)
+	}
+	sort.Strings(g.paths)
+
+	// Retain only edges to other workspace packages.
+	for _, pkg := range g.pkgs {
+		pkg.Imports = slices.DeleteFunc(pkg.Imports, func(path string) bool {
+			return g.pkgs[path] == nil
+		})
+		sort.Strings(pkg.Imports)
+	}
+
+	// Find import cycles, which appear as strongly connected
+	// components of more than one node (or as self-edges).
+	var cycles [][]string
+	inCycle := make(map[string]int) // maps package path to 1 + index in cycles
+	for _, scc := range graph.SCCs[string](g) {
+		if len(scc) == 1 && !slices.Contains(g.pkgs[scc[0]].Imports, scc[0]) {
+			continue
+		}
+		sort.Strings(scc)
+		cycles = append(cycles, scc)
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	for i, cycle := range cycles {
+		for _, path := range cycle {
+			inCycle[path] = i + 1
+		}
+	}
+
+	result := command.ImportGraphResult{Cycles: cycles}
+	for _, path := range g.paths {
+		result.Packages = append(result.Packages, *g.pkgs[path])
+	}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(struct {
+			Packages []command.ImportGraphPackage
+			Cycles   [][]string `json:",omitempty"`
+		}{result.Packages, result.Cycles}, "", "\t")
+		if err != nil {
+			return command.ImportGraphResult{}, err
+		}
+		result.Output = string(data)
+
+	default:
+		dot := graphfmt.Dot[string]{
+			Name:  "imports",
+			Label: func(path string) string { return path },
+			NodeAttrs: func(path string) []graphfmt.DotAttr {
+				// Scale node area (not width) with package size.
+				width := 0.75 + math.Sqrt(float64(g.pkgs[path].Lines))/40
+				attrs := []graphfmt.DotAttr{
+					{Name: "shape", Val: "box"},
+					{Name: "width", Val: math.Round(width*100) / 100},
+					{Name: "tooltip", Val: fmt.Sprintf("%s (%d lines)", path, g.pkgs[path].Lines)},
+				}
+				if inCycle[path] > 0 {
+					attrs = append(attrs, graphfmt.DotAttr{Name: "color", Val: "red"})
+				}
+				return attrs
+			},
+			EdgeAttrs: func(from, to string) []graphfmt.DotAttr {
+				if c := inCycle[from]; c > 0 && c == inCycle[to] {
+					return []graphfmt.DotAttr{{Name: "color", Val: "red"}}
+				}
+				return nil
+			},
+		}
+		result.Output = dot.Sprint(g)
+	}
+	return result, nil
+}
+
+// importGraph adapts the workspace packages to the [graph.Graph]
+// interface, using package paths as node IDs.
+type importGraph struct {
+	paths []string // sorted
+	pkgs  map[string]*command.ImportGraphPackage
+}
+
+func (g *importGraph) Nodes() iter.Seq[string] { return slices.Values(g.paths) }
+
+func (g *importGraph) NumNodes() int { return len(g.paths) }
+
+func (g *importGraph) Out(path string) iter.Seq[string] {
+	return slices.Values(g.pkgs[path].Imports)
+}
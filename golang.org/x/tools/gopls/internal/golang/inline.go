@@ -65,26 +65,11 @@ func inlineCall(ctx context.Context, snapshot *cache.Snapshot, callerPkg *cache.
 		return nil, nil, err
 	}
 
-	calleePkg, calleePGF, calleePos, err := NarrowestDeclaringPackage(ctx, snapshot, callerPkg, fn)
+	calleePkg, calleePGF, calleeDecl, err := calleeFuncDecl(ctx, snapshot, callerPkg, fn)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var calleeDecl *ast.FuncDecl
-	for _, decl := range calleePGF.File.Decls {
-		funcDecl, ok := decl.(*ast.FuncDecl)
-		if !ok {
-			continue
-		}
-		if funcDecl.Name.Pos() == calleePos {
-			calleeDecl = funcDecl
-			break
-		}
-	}
-	if calleeDecl == nil {
-		return nil, nil, fmt.Errorf("can't find callee")
-	}
-
 	// The inliner assumes that input is well-typed,
 	// but that is frequently not the case within gopls.
 	// Until we are able to harden the inliner,
@@ -128,6 +113,66 @@ func inlineCall(ctx context.Context, snapshot *cache.Snapshot, callerPkg *cache.
 	}, nil
 }
 
+// calleeFuncDecl returns the declaration of the function fn called
+// from package callerPkg, along with its package and file.
+func calleeFuncDecl(ctx context.Context, snapshot *cache.Snapshot, callerPkg *cache.Package, fn *types.Func) (*cache.Package, *parsego.File, *ast.FuncDecl, error) {
+	calleePkg, calleePGF, calleePos, err := NarrowestDeclaringPackage(ctx, snapshot, callerPkg, fn)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, decl := range calleePGF.File.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Name.Pos() == calleePos {
+			return calleePkg, calleePGF, funcDecl, nil
+		}
+	}
+	return nil, nil, nil, fmt.Errorf("can't find callee")
+}
+
+// limitInlineFixes returns diags, but without the suggested fix of
+// each diagnostic of the inline analyzer that reports a call of a
+// function whose declaration has more than maxLines lines; see the
+// inlineMaxLines setting. (The analyzer's own -max_lines flag cannot
+// be used, since analysis results do not depend on settings.)
+func limitInlineFixes(ctx context.Context, snapshot *cache.Snapshot, diags []*cache.Diagnostic, maxLines int64) []*cache.Diagnostic {
+	var result []*cache.Diagnostic
+	for _, diag := range diags {
+		if diag.Source == "inline" && diag.Code == fixInlineCall {
+			if lines, err := inlineCalleeLines(ctx, snapshot, diag); err == nil && lines > maxLines {
+				copy := *diag
+				copy.Message = fmt.Sprintf("%s (not fixed automatically: %d lines exceed the inlineMaxLines setting of %d)", diag.Message, lines, maxLines)
+				copy.BundledFixes = nil
+				copy.SuggestedFixes = nil
+				diag = &copy
+			}
+		}
+		result = append(result, diag)
+	}
+	return result
+}
+
+// inlineCalleeLines returns the number of source lines of the
+// declaration of the function whose call is reported by the inline
+// diagnostic diag, counted as by [inline.Callee.Lines].
+func inlineCalleeLines(ctx context.Context, snapshot *cache.Snapshot, diag *cache.Diagnostic) (int64, error) {
+	pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, diag.URI)
+	if err != nil {
+		return 0, err
+	}
+	start, end, err := pgf.RangePos(diag.Range)
+	if err != nil {
+		return 0, err
+	}
+	_, fn, err := enclosingStaticCall(pkg, pgf, start, end)
+	if err != nil {
+		return 0, err
+	}
+	_, calleePGF, decl, err := calleeFuncDecl(ctx, snapshot, pkg, fn)
+	if err != nil {
+		return 0, err
+	}
+	return int64(safetoken.Line(calleePGF.Tok, decl.End()) - safetoken.Line(calleePGF.Tok, decl.Pos()) + 1), nil
+}
+
 // TODO(adonovan): change the inliner to instead accept an io.Writer.
 func logger(ctx context.Context, name string, verbose bool) func(format string, args ...any) {
 	if verbose {
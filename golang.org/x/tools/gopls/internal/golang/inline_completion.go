@@ -0,0 +1,283 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+// This file defines the textDocument/inlineCompletion provider.
+//
+// Unlike ordinary completion, which offers a menu of candidates for
+// the identifier at the cursor, inline completion proposes a single
+// continuation of the current line, shown as "ghost text" by the
+// client. The provider below uses only deterministic, syntax-driven
+// heuristics, so its suggestions are cheap and predictable; it is
+// intended as a foundation for other (for example model-backed)
+// providers.
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/util/safetoken"
+	"golang.org/x/tools/internal/event"
+	"golang.org/x/tools/internal/typeparams"
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+// InlineCompletion returns inline completion items for the specified
+// position. It offers at most one item, which completes the current
+// line when the cursor is at its end and the line so far contains
+// nothing but (a prefix of) the suggestion. The suggestions are:
+//
+//   - within an empty "if err != nil { ... }" block, a statement
+//     that returns err along with the zero values of the other results
+//     of the enclosing function;
+//   - after the last statement of a function body that is missing a
+//     final return statement, a statement that returns zero values
+//     (and nil for a final error result);
+//   - within a keyed struct literal, the key of the field that
+//     follows the previous element.
+func InlineCompletion(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, position protocol.Position) ([]protocol.InlineCompletionItem, error) {
+	ctx, done := event.Start(ctx, "golang.InlineCompletion")
+	defer done()
+
+	pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, fh.URI())
+	if err != nil {
+		return nil, fmt.Errorf("getting package for InlineCompletion: %w", err)
+	}
+	pos, err := pgf.PositionPos(position)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the text typed so far on the current line,
+	// and require that nothing follows the cursor.
+	offset, err := safetoken.Offset(pgf.Tok, pos)
+	if err != nil {
+		return nil, err
+	}
+	lineStart := offset
+	for lineStart > 0 && pgf.Src[lineStart-1] != '\n' {
+		lineStart--
+	}
+	rest := pgf.Src[offset:]
+	if eol := strings.IndexByte(string(rest), '\n'); eol >= 0 {
+		rest = rest[:eol]
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return nil, nil
+	}
+	typed := strings.TrimLeft(string(pgf.Src[lineStart:offset]), " \t")
+	start := pos - token.Pos(len(typed))
+
+	text := inlineCompletionText(pkg, pgf, start)
+	if text == "" || text == typed || !strings.HasPrefix(text, typed) {
+		return nil, nil
+	}
+	rng, err := pgf.PosRange(start, pos)
+	if err != nil {
+		return nil, err
+	}
+	return []protocol.InlineCompletionItem{{
+		InsertText: protocol.Or_InlineCompletionItem_insertText{Value: text},
+		Range:      &rng,
+	}}, nil
+}
+
+// inlineCompletionText returns the suggested text of a line whose
+// first non-blank character (if any) is at start, or "" if there is
+// no suggestion.
+func inlineCompletionText(pkg *cache.Package, pgf *parsego.File, start token.Pos) string {
+	cur, ok := pgf.Cursor().FindByPos(start, start)
+	if !ok {
+		return ""
+	}
+	var (
+		info = pkg.TypesInfo()
+		qual = typesinternal.FileQualifier(pgf.File, pkg.Types())
+		line = safetoken.Line(pgf.Tok, start)
+	)
+	lineOf := func(pos token.Pos) int { return safetoken.Line(pgf.Tok, pos) }
+
+	// before reports whether node ends on a line before the cursor.
+	before := func(node ast.Node) bool {
+		return lineOf(node.End()) < line
+	}
+	// after reports whether node starts after the text typed so far,
+	// which the parser may have turned into a node of its own.
+	after := func(node ast.Node) bool {
+		return lineOf(node.Pos()) > line
+	}
+
+	for c := range cur.Enclosing((*ast.BlockStmt)(nil), (*ast.CompositeLit)(nil)) {
+		switch n := c.Node().(type) {
+		case *ast.CompositeLit:
+			if lineOf(n.Lbrace) >= line || lineOf(n.Rbrace) <= line {
+				return "" // cursor is not on a line of its own within the literal
+			}
+			return structFieldCompletion(info, pkg.Types(), n, before, after)
+
+		case *ast.BlockStmt:
+			if lineOf(n.Lbrace) >= line || lineOf(n.Rbrace) <= line {
+				return ""
+			}
+			var prev []ast.Stmt // statements before the cursor
+			for _, stmt := range n.List {
+				if before(stmt) {
+					prev = append(prev, stmt)
+				} else if after(stmt) {
+					return "" // cursor is not at the end of the block
+				}
+			}
+
+			// Find the results of the enclosing function.
+			var results *types.Tuple
+			for fn := range c.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+				var sig *types.Signature
+				switch fn := fn.Node().(type) {
+				case *ast.FuncDecl:
+					if obj, ok := info.Defs[fn.Name].(*types.Func); ok {
+						sig = obj.Signature()
+					}
+				case *ast.FuncLit:
+					sig, _ = info.TypeOf(fn).(*types.Signature)
+				}
+				if sig != nil {
+					results = sig.Results()
+				}
+				break
+			}
+			if results == nil || results.Len() == 0 {
+				return ""
+			}
+
+			switch parent := c.Parent().Node().(type) {
+			case *ast.IfStmt:
+				// if err != nil { return ..., err }
+				if len(prev) > 0 || parent.Body != n {
+					return ""
+				}
+				cond, ok := parent.Cond.(*ast.BinaryExpr)
+				if !ok || cond.Op != token.NEQ || !isNil(info, cond.Y) || !isErrorType(info.TypeOf(cond.X)) {
+					return ""
+				}
+				errExpr, ok := cond.X.(*ast.Ident)
+				if !ok {
+					return ""
+				}
+				return returnCompletion(results, errExpr.Name, qual)
+
+			case *ast.FuncDecl, *ast.FuncLit:
+				// Final return statement.
+				if len(prev) == 0 {
+					return ""
+				}
+				// Suggest one only if it is missing, according to
+				// the type checker: the last statement may be
+				// terminating without being a return statement,
+				// for example a call to panic or an infinite loop.
+				missing := slices.ContainsFunc(pkg.TypeErrors(), func(err types.Error) bool {
+					return err.Pos == n.Rbrace && err.Msg == "missing return"
+				})
+				if !missing {
+					return ""
+				}
+				return returnCompletion(results, "nil", qual)
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// returnCompletion returns a return statement for a function with the
+// specified results, using zero values for all but a final error
+// result, whose value is errValue. It returns "" if the final result
+// is not an error, or if another result has no zero value.
+func returnCompletion(results *types.Tuple, errValue string, qual types.Qualifier) string {
+	last := results.Len() - 1
+	if !isErrorType(results.At(last).Type()) {
+		return ""
+	}
+	var values []string
+	for i := range last {
+		zero, ok := typesinternal.ZeroString(results.At(i).Type(), qual)
+		if !ok {
+			return ""
+		}
+		values = append(values, zero)
+	}
+	values = append(values, errValue)
+	return "return " + strings.Join(values, ", ")
+}
+
+// structFieldCompletion returns the key, followed by a colon, of the
+// field of the struct literal lit that follows the key of the last
+// element before the cursor, or "" if there is no such field.
+// The literal must not contain unkeyed elements, and the field must be
+// accessible to pkg and not already present.
+func structFieldCompletion(info *types.Info, pkg *types.Package, lit *ast.CompositeLit, before, after func(ast.Node) bool) string {
+	st, ok := typeparams.CoreType(typeparams.Deref(info.TypeOf(lit))).(*types.Struct)
+	if !ok {
+		return ""
+	}
+	present := make(map[string]bool)
+	var lastKey string
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			if before(elt) || after(elt) {
+				return "" // unkeyed literal
+			}
+			continue // the text typed so far
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			return ""
+		}
+		present[key.Name] = true
+		if before(elt) {
+			lastKey = key.Name
+		}
+	}
+
+	// Find the field following lastKey (or the first field).
+	i := 0
+	if lastKey != "" {
+		for i < st.NumFields() && st.Field(i).Name() != lastKey {
+			i++
+		}
+		i++
+	}
+	for ; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if present[field.Name()] {
+			continue
+		}
+		if !field.Exported() && field.Pkg() != pkg {
+			continue
+		}
+		return field.Name() + ": "
+	}
+	return ""
+}
+
+// isNil reports whether e denotes the predeclared nil.
+func isNil(info *types.Info, e ast.Expr) bool {
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	return ok && info.Uses[id] == types.Universe.Lookup("nil")
+}
+
+// isErrorType reports whether t is the predeclared error type.
+func isErrorType(t types.Type) bool {
+	return t != nil && types.Identical(t, types.Universe.Lookup("error").Type())
+}
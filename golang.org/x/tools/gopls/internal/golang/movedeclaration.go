@@ -4,14 +4,322 @@
 
 package golang
 
+// This file defines the code action "Move declaration to another file".
+
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
 
 	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
 	"golang.org/x/tools/gopls/internal/file"
 	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/util/safetoken"
+	"golang.org/x/tools/internal/refactor"
 )
 
-func MoveDeclaration(ctx context.Context, fh file.Handle, snapshot *cache.Snapshot) ([]protocol.DocumentChange, protocol.Location, error) {
-	return nil, protocol.Location{}, nil
+// MoveDeclaration moves the top-level declaration at loc, along with
+// its doc comment, to the end of the file dest, which must belong to
+// the same package. Unexported declarations of the same file that are
+// used only by the moved declarations are moved too. The imports of
+// both files are updated.
+//
+// It returns the document changes and the location of the moved
+// declarations in dest.
+func MoveDeclaration(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, loc protocol.Location, dest protocol.DocumentURI) ([]protocol.DocumentChange, protocol.Location, error) {
+	pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, fh.URI())
+	if err != nil {
+		return nil, protocol.Location{}, err
+	}
+	if dest == pgf.URI {
+		return nil, protocol.Location{}, fmt.Errorf("cannot move a declaration to its own file")
+	}
+	var destPGF *parsego.File
+	for _, f := range pkg.CompiledGoFiles() {
+		if f.URI == dest {
+			destPGF = f
+			break
+		}
+	}
+	if destPGF == nil {
+		return nil, protocol.Location{}, fmt.Errorf("%s is not a file of package %s", dest.Base(), pkg.Metadata().PkgPath)
+	}
+	if !sameBuildConstraint(pgf, destPGF) {
+		return nil, protocol.Location{}, fmt.Errorf("%s and %s have different build constraints", pgf.URI.Base(), dest.Base())
+	}
+
+	start, end, err := pgf.RangePos(loc.Range)
+	if err != nil {
+		return nil, protocol.Location{}, err
+	}
+	decl, _, ok := selectedSymbolDecl(pgf, start, end)
+	if !ok {
+		return nil, protocol.Location{}, fmt.Errorf("no top-level declaration at selection")
+	}
+
+	info := pkg.TypesInfo()
+	moved := movedDecls(pkg, pgf, decl)
+	inMoved := func(pos token.Pos) bool {
+		for _, d := range moved {
+			if declStart(d) <= pos && pos < d.End() {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Compute the imports needed in dest and unneeded in the source.
+	adds, deletes, err := findImportEditsFunc(pgf.File, info, func(id *ast.Ident) bool {
+		return inMoved(id.Pos())
+	})
+	if err != nil {
+		return nil, protocol.Location{}, err
+	}
+	var destEdits []protocol.TextEdit
+	for _, spec := range adds {
+		pkgName := info.PkgNameOf(spec)
+		if pkgName == nil {
+			continue // e.g. blank import
+		}
+		prefix, edits := refactor.AddImport(info, destPGF.File, pkgName.Name(), pkgName.Imported().Path(), "", destPGF.File.FileEnd-1)
+		if prefix != pkgName.Name()+"." {
+			return nil, protocol.Location{}, fmt.Errorf("import of %s would be renamed in %s", pkgName.Imported().Path(), dest.Base())
+		}
+		for _, edit := range edits {
+			rng, err := destPGF.PosRange(edit.Pos, edit.End)
+			if err != nil {
+				return nil, protocol.Location{}, err
+			}
+			destEdits = append(destEdits, protocol.TextEdit{Range: rng, NewText: string(edit.NewText)})
+		}
+	}
+
+	// Delete the moved declarations (and their trailing blank
+	// lines) from the source file, and append them to dest.
+	var (
+		srcEdits = importDeletesEdits(pgf, deletes)
+		buf      bytes.Buffer
+	)
+	for _, d := range moved {
+		end := declEnd(pgf, d)
+		text, err := pgf.PosText(declStart(d), end)
+		if err != nil {
+			return nil, protocol.Location{}, err
+		}
+		buf.WriteString("\n")
+		buf.Write(text)
+		buf.WriteString("\n")
+
+		endOffset, err := safetoken.Offset(pgf.Tok, end)
+		if err != nil {
+			return nil, protocol.Location{}, err
+		}
+		rest := pgf.Src[endOffset:]
+		spaces := len(rest) - len(bytes.TrimLeft(rest, " \t\n"))
+		rng, err := pgf.PosRange(declStart(d), end+token.Pos(spaces))
+		if err != nil {
+			return nil, protocol.Location{}, err
+		}
+		srcEdits = append(srcEdits, protocol.TextEdit{Range: rng})
+	}
+
+	newText := buf.String()
+	if len(destPGF.Src) > 0 && !bytes.HasSuffix(destPGF.Src, []byte("\n")) {
+		newText = "\n" + newText
+	}
+	destRng, err := destPGF.PosRange(destPGF.File.FileEnd, destPGF.File.FileEnd)
+	if err != nil {
+		return nil, protocol.Location{}, err
+	}
+	destEdits = append(destEdits, protocol.TextEdit{Range: destRng, NewText: newText})
+
+	destFH, err := snapshot.ReadFile(ctx, dest)
+	if err != nil {
+		return nil, protocol.Location{}, err
+	}
+	changes := []protocol.DocumentChange{
+		protocol.DocumentChangeEdit(fh, srcEdits),
+		protocol.DocumentChangeEdit(destFH, destEdits),
+	}
+	return changes, protocol.Location{URI: dest, Range: destRng}, nil
+}
+
+// selectedSymbolDecl returns the top-level declaration (other than an
+// import) whose keyword or declared name encloses [start, end), along
+// with the name of its first declared symbol.
+func selectedSymbolDecl(pgf *parsego.File, start, end token.Pos) (ast.Decl, string, bool) {
+	for _, decl := range pgf.File.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if posRangeContains(decl.Pos(), decl.Name.End(), start, end) {
+				return decl, decl.Name.Name, true
+			}
+
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT || len(decl.Specs) == 0 {
+				continue
+			}
+			var names []*ast.Ident
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					names = append(names, spec.Name)
+				case *ast.ValueSpec:
+					names = append(names, spec.Names...)
+				}
+			}
+			if len(names) == 0 {
+				continue
+			}
+			if posRangeContains(decl.Pos(), decl.Pos()+token.Pos(len(decl.Tok.String())), start, end) {
+				return decl, names[0].Name, true
+			}
+			for _, id := range names {
+				if posRangeContains(id.Pos(), id.End(), start, end) {
+					return decl, id.Name, true
+				}
+			}
+		}
+	}
+	return nil, "", false
+}
+
+// movedDecls returns, in file order, the declarations of pgf that
+// must move along with decl: decl itself, plus the unexported
+// declarations referenced only by the moved declarations.
+func movedDecls(pkg *cache.Package, pgf *parsego.File, decl ast.Decl) []ast.Decl {
+	info := pkg.TypesInfo()
+
+	uses := make(map[types.Object][]*ast.Ident)
+	for id, obj := range info.Uses {
+		uses[obj] = append(uses[obj], id)
+	}
+
+	moved := map[ast.Decl]bool{decl: true}
+	inMoved := func(pos token.Pos) bool {
+		for d := range moved {
+			if declStart(d) <= pos && pos < d.End() {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Iterate to a fixed point, as moving one declaration
+	// may make another one exclusive to the moved set.
+	for changed := true; changed; {
+		changed = false
+		for _, d := range pgf.File.Decls {
+			if moved[d] {
+				continue
+			}
+			objs := declaredObjects(info, d)
+			if len(objs) == 0 {
+				continue
+			}
+			exclusive, referenced := true, false
+			for _, obj := range objs {
+				if obj.Exported() || obj.Name() == "_" || obj.Name() == "init" {
+					exclusive = false
+					break
+				}
+				for _, id := range uses[obj] {
+					if inMoved(id.Pos()) {
+						referenced = true
+					} else {
+						exclusive = false
+					}
+				}
+			}
+			if exclusive && referenced {
+				moved[d] = true
+				changed = true
+			}
+		}
+	}
+
+	var res []ast.Decl
+	for _, d := range pgf.File.Decls {
+		if moved[d] {
+			res = append(res, d)
+		}
+	}
+	return res
+}
+
+// declaredObjects returns the package-level objects declared by a
+// top-level declaration other than a method or an import.
+func declaredObjects(info *types.Info, decl ast.Decl) []types.Object {
+	var objs []types.Object
+	add := func(id *ast.Ident) {
+		if obj := info.Defs[id]; obj != nil {
+			objs = append(objs, obj)
+		}
+	}
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		if decl.Recv == nil {
+			add(decl.Name)
+		}
+	case *ast.GenDecl:
+		if decl.Tok == token.IMPORT {
+			break
+		}
+		for _, spec := range decl.Specs {
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				add(spec.Name)
+			case *ast.ValueSpec:
+				for _, id := range spec.Names {
+					add(id)
+				}
+			}
+		}
+	}
+	return objs
+}
+
+// declStart returns the start of decl, including its doc comment.
+func declStart(decl ast.Decl) token.Pos {
+	var doc *ast.CommentGroup
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		doc = decl.Doc
+	case *ast.GenDecl:
+		doc = decl.Doc
+	}
+	if doc != nil {
+		return doc.Pos()
+	}
+	return decl.Pos()
+}
+
+// declEnd returns the end of decl, including any comment that
+// follows it on its last line.
+func declEnd(pgf *parsego.File, decl ast.Decl) token.Pos {
+	end := decl.End()
+	line := safetoken.Line(pgf.Tok, end)
+	for _, cg := range pgf.File.Comments {
+		if cg.Pos() >= end && safetoken.Line(pgf.Tok, cg.Pos()) == line {
+			return cg.End()
+		}
+	}
+	return end
+}
+
+// sameBuildConstraint reports whether two files have the same build
+// constraint (or none).
+func sameBuildConstraint(x, y *parsego.File) bool {
+	text := func(pgf *parsego.File) string {
+		if c := buildConstraintComment(pgf.File); c != nil {
+			return strings.TrimSpace(c.Text)
+		}
+		return ""
+	}
+	return text(x) == text(y)
 }
@@ -0,0 +1,118 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+// This file defines the "Add doc.go for package P" code action.
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/protocol"
+)
+
+// canAddPackageDoc reports whether the "Add doc.go" code action is
+// offered for the selection [start, end) of pgf: the selection must
+// be within the package clause of a non-test, non-main package none
+// of whose files has a package doc comment or is named doc.go.
+func canAddPackageDoc(pkg *cache.Package, pgf *parsego.File, start, end token.Pos) bool {
+	if pgf.File.Name == nil || start < pgf.File.Package || end > pgf.File.Name.End() {
+		return false
+	}
+	if pkg.Metadata().ForTest != "" || pkg.Metadata().Name == "main" || strings.HasSuffix(pgf.URI.Path(), "_test.go") {
+		return false
+	}
+	for _, pgf := range pkg.CompiledGoFiles() {
+		if pgf.File.Doc != nil || filepath.Base(pgf.URI.Path()) == "doc.go" {
+			return false
+		}
+	}
+	return true
+}
+
+// AddPackageDoc returns the changes that create a doc.go file, in the
+// directory of fh, whose package doc comment is a skeleton listing
+// the exported API of the package: its types, each with the functions
+// that construct it and its methods, and its other functions.
+func AddPackageDoc(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle) ([]protocol.DocumentChange, error) {
+	pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, fh.URI())
+	if err != nil {
+		return nil, err
+	}
+	if !canAddPackageDoc(pkg, pgf, pgf.File.Package, pgf.File.Package) {
+		return nil, fmt.Errorf("package %s already has a doc comment, or is a test or main package", pkg.Metadata().Name)
+	}
+
+	docURI := protocol.URIFromPath(filepath.Join(fh.URI().DirPath(), "doc.go"))
+	docFH, err := snapshot.ReadFile(ctx, docURI)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := docFH.Content(); !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s already exists", docURI.Path())
+	}
+
+	var files []*ast.File
+	for _, pgf := range pkg.CompiledGoFiles() {
+		if !strings.HasSuffix(pgf.URI.Path(), "_test.go") {
+			files = append(files, pgf.File)
+		}
+	}
+	dpkg, err := doc.NewFromFiles(pkg.FileSet(), files, string(pkg.Metadata().PkgPath), doc.PreserveAST)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if c := CopyrightComment(pgf.File); c != nil {
+		text, err := pgf.NodeText(c)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(text)
+		buf.WriteString("\n\n")
+	}
+	fmt.Fprintf(&buf, "// Package %s TODO: summarize the purpose of the package.\n", dpkg.Name)
+	if len(dpkg.Types) > 0 {
+		buf.WriteString("//\n// # Types\n//\n")
+		for _, t := range dpkg.Types {
+			var related []string
+			for _, fn := range t.Funcs {
+				related = append(related, "["+fn.Name+"]")
+			}
+			for _, m := range t.Methods {
+				related = append(related, "["+t.Name+"."+m.Name+"]")
+			}
+			fmt.Fprintf(&buf, "//   - [%s]", t.Name)
+			if len(related) > 0 {
+				fmt.Fprintf(&buf, ": %s", strings.Join(related, ", "))
+			}
+			buf.WriteString("\n")
+		}
+	}
+	if len(dpkg.Funcs) > 0 {
+		buf.WriteString("//\n// # Functions\n//\n")
+		for _, fn := range dpkg.Funcs {
+			fmt.Fprintf(&buf, "//   - [%s]\n", fn.Name)
+		}
+	}
+	fmt.Fprintf(&buf, "package %s\n", dpkg.Name)
+
+	return []protocol.DocumentChange{
+		protocol.DocumentChangeCreate(docURI),
+		protocol.DocumentChangeEdit(docFH, []protocol.TextEdit{{NewText: buf.String()}}),
+	}, nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
+	"golang.org/x/tools/internal/diff"
+)
+
+// PreviewEdit summarizes the changes of a workspace edit without
+// applying them: for each change, the kind of change to the file and
+// the number of lines it adds and deletes, and a unified diff of all
+// the changes. The readFile function returns the current content of a
+// file.
+//
+// It is used by the gopls.preview_edit command, and by the CLI to
+// confirm edits before writing them.
+func PreviewEdit(edit *protocol.WorkspaceEdit, readFile func(protocol.DocumentURI) ([]byte, error)) (command.EditPreview, error) {
+	var (
+		preview command.EditPreview
+		diffs   strings.Builder
+	)
+
+	// contents records the content of each file as changed by
+	// the preceding changes, nil for a deleted file.
+	contents := make(map[protocol.DocumentURI][]byte)
+	content := func(uri protocol.DocumentURI) ([]byte, error) {
+		if data, ok := contents[uri]; ok {
+			if data == nil {
+				return nil, fmt.Errorf("%s was deleted by a previous change", uri)
+			}
+			return data, nil
+		}
+		return readFile(uri)
+	}
+
+	// add records the change of a file from old to new.
+	add := func(file command.FileEditPreview, old, new []byte) {
+		oldPath, newPath := file.URI.Path(), file.URI.Path()
+		if file.NewURI != "" {
+			newPath = file.NewURI.Path()
+		}
+		unified := diff.Unified(oldPath, newPath, string(old), string(new))
+		file.Added, file.Deleted = countLines(unified)
+		preview.Files = append(preview.Files, file)
+		preview.Added += file.Added
+		preview.Deleted += file.Deleted
+		diffs.WriteString(unified)
+	}
+
+	// applyEdits records the application of edits to the file.
+	applyEdits := func(uri protocol.DocumentURI, edits []protocol.TextEdit) error {
+		old, err := content(uri)
+		if err != nil {
+			return err
+		}
+		new, _, err := protocol.ApplyEdits(protocol.NewMapper(uri, old), edits)
+		if err != nil {
+			return err
+		}
+		contents[uri] = new
+		add(command.FileEditPreview{URI: uri, Kind: "edit"}, old, new)
+		return nil
+	}
+
+	for _, c := range edit.DocumentChanges {
+		switch {
+		case c.TextDocumentEdit != nil:
+			edits := protocol.AsTextEdits(c.TextDocumentEdit.Edits)
+			if err := applyEdits(c.TextDocumentEdit.TextDocument.URI, edits); err != nil {
+				return command.EditPreview{}, err
+			}
+
+		case c.CreateFile != nil:
+			uri := c.CreateFile.URI
+			contents[uri] = []byte{}
+			add(command.FileEditPreview{URI: uri, Kind: "create"}, nil, nil)
+
+		case c.RenameFile != nil:
+			oldURI, newURI := c.RenameFile.OldURI, c.RenameFile.NewURI
+			data, err := content(oldURI)
+			if err != nil {
+				return command.EditPreview{}, err
+			}
+			contents[oldURI], contents[newURI] = nil, data
+			add(command.FileEditPreview{URI: oldURI, NewURI: newURI, Kind: "rename"}, data, data)
+
+		case c.DeleteFile != nil:
+			uri := c.DeleteFile.URI
+			data, err := content(uri)
+			if err != nil {
+				return command.EditPreview{}, err
+			}
+			contents[uri] = nil
+			add(command.FileEditPreview{URI: uri, Kind: "delete"}, data, nil)
+
+		default:
+			return command.EditPreview{}, fmt.Errorf("unknown DocumentChange: %#v", c)
+		}
+	}
+
+	// Clients that do not support DocumentChanges receive
+	// the edits of each file in the Changes map.
+	for _, uri := range slices.Sorted(maps.Keys(edit.Changes)) {
+		if err := applyEdits(uri, edit.Changes[uri]); err != nil {
+			return command.EditPreview{}, err
+		}
+	}
+
+	preview.Diff = diffs.String()
+	return preview, nil
+}
+
+// countLines returns the number of lines added and deleted by a
+// unified diff.
+func countLines(unified string) (added, deleted int) {
+	for i, line := range strings.Split(unified, "\n") {
+		if i < 2 {
+			continue // skip the "---" and "+++" header lines
+		}
+		switch {
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			deleted++
+		}
+	}
+	return added, deleted
+}
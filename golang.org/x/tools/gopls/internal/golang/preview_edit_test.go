@@ -0,0 +1,79 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
+)
+
+func TestPreviewEdit(t *testing.T) {
+	files := map[protocol.DocumentURI]string{
+		"file:///a.go": "package a\n\nfunc f() {}\n",
+		"file:///b.go": "package a\n\nvar x = 1\n",
+	}
+	readFile := func(uri protocol.DocumentURI) ([]byte, error) {
+		content, ok := files[uri]
+		if !ok {
+			return nil, fmt.Errorf("no file %s", uri)
+		}
+		return []byte(content), nil
+	}
+	edit := func(uri protocol.DocumentURI, line uint32, newText string) protocol.DocumentChange {
+		rng := protocol.Range{
+			Start: protocol.Position{Line: line},
+			End:   protocol.Position{Line: line + 1},
+		}
+		return protocol.DocumentChange{
+			TextDocumentEdit: &protocol.TextDocumentEdit{
+				TextDocument: protocol.OptionalVersionedTextDocumentIdentifier{
+					TextDocumentIdentifier: protocol.TextDocumentIdentifier{URI: uri},
+				},
+				Edits: protocol.AsAnnotatedTextEdits([]protocol.TextEdit{{Range: rng, NewText: newText}}),
+			},
+		}
+	}
+
+	preview, err := PreviewEdit(protocol.NewWorkspaceEdit(
+		edit("file:///a.go", 2, "func g() {}\n\nfunc h() {}\n"),
+		protocol.DocumentChangeRename("file:///b.go", "file:///c.go"),
+		// Edits to the renamed file apply to its new name.
+		edit("file:///c.go", 2, ""),
+	), readFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []command.FileEditPreview{
+		{URI: "file:///a.go", Kind: "edit", Added: 3, Deleted: 1},
+		{URI: "file:///b.go", NewURI: "file:///c.go", Kind: "rename"},
+		{URI: "file:///c.go", Kind: "edit", Deleted: 1},
+	}
+	if !reflect.DeepEqual(preview.Files, want) {
+		t.Errorf("PreviewEdit files = %+v, want %+v", preview.Files, want)
+	}
+	if preview.Added != 3 || preview.Deleted != 2 {
+		t.Errorf("PreviewEdit totals = +%d -%d, want +3 -2", preview.Added, preview.Deleted)
+	}
+	for _, want := range []string{"-func f() {}", "+func h() {}", "-var x = 1"} {
+		if !strings.Contains(preview.Diff, want) {
+			t.Errorf("PreviewEdit diff does not contain %q:\n%s", want, preview.Diff)
+		}
+	}
+
+	// A change to the old name of a renamed file is an error.
+	_, err = PreviewEdit(protocol.NewWorkspaceEdit(
+		protocol.DocumentChangeRename("file:///b.go", "file:///c.go"),
+		edit("file:///b.go", 0, ""),
+	), readFile)
+	if err == nil {
+		t.Error("PreviewEdit of an edit to a renamed file succeeded")
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/types"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -38,22 +39,44 @@ import (
 	"golang.org/x/tools/gopls/internal/util/morestrings"
 	"golang.org/x/tools/gopls/internal/util/safetoken"
 
+	"golang.org/x/tools/internal/astutil"
 	"golang.org/x/tools/internal/event"
 )
 
 // References returns a list of all references (sorted with
 // definitions before uses) to the object denoted by the identifier at
 // the given file/position, searching the entire workspace.
+//
+// See [StreamReferences] for the weaker ordering of streamed results.
 func References(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, rng protocol.Range, includeDeclaration bool) ([]protocol.Location, error) {
-	references, err := references(ctx, snapshot, fh, rng, includeDeclaration)
+	references, err := references(ctx, snapshot, fh, rng, includeDeclaration, nil)
 	if err != nil {
 		return nil, err
 	}
-	locations := make([]protocol.Location, len(references))
-	for i, ref := range references {
+	return referenceLocations(references), nil
+}
+
+// StreamReferences is like [References], but it passes the references
+// to partial in batches, as they are found, instead of returning them.
+// Each batch is sorted, with definitions before uses, but references
+// are not sorted across batches: a later batch may contain definitions
+// that belong before the uses of an earlier one.
+// Calls to partial are not concurrent.
+//
+// It is used to support the partial results of an LSP request.
+func StreamReferences(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, rng protocol.Range, includeDeclaration bool, partial func([]protocol.Location)) error {
+	_, err := references(ctx, snapshot, fh, rng, includeDeclaration, func(refs []reference) {
+		partial(referenceLocations(refs))
+	})
+	return err
+}
+
+func referenceLocations(refs []reference) []protocol.Location {
+	locations := make([]protocol.Location, len(refs))
+	for i, ref := range refs {
 		locations[i] = ref.location
 	}
-	return locations, nil
+	return locations
 }
 
 // A reference describes an identifier that refers to the same
@@ -67,7 +90,10 @@ type reference struct {
 // references returns a list of all references (sorted with
 // definitions before uses) to the object denoted by the identifier at
 // the given file/position, searching the entire workspace.
-func references(ctx context.Context, snapshot *cache.Snapshot, f file.Handle, rng protocol.Range, includeDeclaration bool) ([]reference, error) {
+//
+// If partial is non-nil, it is additionally called (not concurrently)
+// with each non-empty batch of new references as they are found.
+func references(ctx context.Context, snapshot *cache.Snapshot, f file.Handle, rng protocol.Range, includeDeclaration bool, partial func([]reference)) ([]reference, error) {
 	ctx, done := event.Start(ctx, "golang.references")
 	defer done()
 
@@ -77,37 +103,55 @@ func references(ctx context.Context, snapshot *cache.Snapshot, f file.Handle, rn
 		return nil, err
 	}
 
+	// batch sorts and de-duplicates refs by location,
+	// and optionally removes declarations.
+	// Locations already in seen are removed too.
+	batch := func(refs []reference, seen map[protocol.Location]bool) []reference {
+		sort.Slice(refs, func(i, j int) bool {
+			x, y := refs[i], refs[j]
+			if x.isDeclaration != y.isDeclaration {
+				return x.isDeclaration // decls < refs
+			}
+			return protocol.CompareLocation(x.location, y.location) < 0
+		})
+		out := refs[:0]
+		for _, ref := range refs {
+			if !includeDeclaration && ref.isDeclaration {
+				continue
+			}
+			if !seen[ref.location] {
+				seen[ref.location] = true
+				out = append(out, ref)
+			}
+		}
+		return out
+	}
+
+	// Report partial results as each search task completes.
+	var flush func([]reference)
+	if partial != nil {
+		seen := make(map[protocol.Location]bool)
+		flush = func(refs []reference) {
+			if refs := batch(slices.Clone(refs), seen); len(refs) > 0 {
+				partial(refs)
+			}
+		}
+	}
+
 	var refs []reference
 	if inPackageName {
 		refs, err = packageReferences(ctx, snapshot, f.URI())
+		if err == nil && flush != nil {
+			flush(refs)
+		}
 	} else {
-		refs, err = ordinaryReferences(ctx, snapshot, f.URI(), rng)
+		refs, err = ordinaryReferences(ctx, snapshot, f.URI(), rng, flush)
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	sort.Slice(refs, func(i, j int) bool {
-		x, y := refs[i], refs[j]
-		if x.isDeclaration != y.isDeclaration {
-			return x.isDeclaration // decls < refs
-		}
-		return protocol.CompareLocation(x.location, y.location) < 0
-	})
-
-	// De-duplicate by location, and optionally remove declarations.
-	out := refs[:0]
-	for _, ref := range refs {
-		if !includeDeclaration && ref.isDeclaration {
-			continue
-		}
-		if len(out) == 0 || out[len(out)-1].location != ref.location {
-			out = append(out, ref)
-		}
-	}
-	refs = out
-
-	return refs, nil
+	return batch(refs, make(map[protocol.Location]bool)), nil
 }
 
 // packageReferences returns a list of references to the package
@@ -217,7 +261,10 @@ func packageReferences(ctx context.Context, snapshot *cache.Snapshot, uri protoc
 }
 
 // ordinaryReferences computes references for all ordinary objects (not package declarations).
-func ordinaryReferences(ctx context.Context, snapshot *cache.Snapshot, uri protocol.DocumentURI, rng protocol.Range) ([]reference, error) {
+//
+// If flush is non-nil, it is called (not concurrently) with the
+// references found by each of the parallel search tasks as it completes.
+func ordinaryReferences(ctx context.Context, snapshot *cache.Snapshot, uri protocol.DocumentURI, rng protocol.Range, flush func([]reference)) ([]reference, error) {
 	// Strategy: use the reference information computed by the
 	// type checker to find the declaration. First type-check this
 	// package to find the declaration, then type check the
@@ -242,14 +289,23 @@ func ordinaryReferences(ctx context.Context, snapshot *cache.Snapshot, uri proto
 	}
 	cur, _ := pgf.Cursor().FindByPos(start, end) // can't fail
 
-	candidates, err := objectsAt(pkg.TypesInfo(), cur)
-	if err != nil {
-		return nil, err
+	var obj types.Object
+	if candidates, err := objectsAt(pkg.TypesInfo(), cur); err == nil {
+		// Pick first object arbitrarily.
+		// The case variables of a type switch have different
+		// types but that difference is immaterial here.
+		obj = candidates[0].obj
+	} else {
+		// Is the selection within a doc link such as [fmt.Println]?
+		linkObj, _, linkErr := resolveDocLink(pkg, pgf, astutil.RangeOf(start, end))
+		if linkErr != nil {
+			return nil, err
+		}
+		if _, ok := linkObj.(*types.PkgName); ok {
+			return nil, fmt.Errorf("references to package %q in doc links are not supported", linkObj.Name())
+		}
+		obj = linkObj
 	}
-	// Pick first object arbitrarily.
-	// The case variables of a type switch have different
-	// types but that difference is immaterial here.
-	obj := candidates[0].obj
 
 	// nil, error, error.Error, iota, or other built-in?
 	if isBuiltin(obj) {
@@ -357,8 +413,11 @@ func ordinaryReferences(ctx context.Context, snapshot *cache.Snapshot, uri proto
 
 	// The search functions will call report(loc) for each hit.
 	var (
-		refsMu sync.Mutex
-		refs   []reference
+		refsMu  sync.Mutex
+		refs    []reference
+		flushed int // refs[:flushed] have been flushed
+
+		flushMu sync.Mutex // serializes calls to flush
 	)
 	report := func(loc protocol.Location, isDecl bool) {
 		ref := reference{
@@ -375,12 +434,30 @@ func ordinaryReferences(ctx context.Context, snapshot *cache.Snapshot, uri proto
 	// and perform both the local (in-package) and global
 	// (cross-package) searches, in parallel.
 	//
-	// TODO(adonovan): opt: support LSP reference streaming. See:
-	// - https://github.com/microsoft/vscode-languageserver-node/pull/164
-	// - https://github.com/microsoft/language-server-protocol/pull/182
+	// Each task streams the references reported so far
+	// when it completes.
 	//
 	// Careful: this goroutine must not return before group.Wait.
 	var group errgroup.Group
+	goSearch := func(search func() error) {
+		group.Go(func() error {
+			if err := search(); err != nil {
+				return err
+			}
+			if flush != nil {
+				// Don't hold refsMu while flushing, as flush may
+				// block on the client, and so would every report.
+				flushMu.Lock()
+				defer flushMu.Unlock()
+				refsMu.Lock()
+				batch := slices.Clone(refs[flushed:])
+				flushed = len(refs)
+				refsMu.Unlock()
+				flush(batch)
+			}
+			return nil
+		})
+	}
 
 	// Compute local references for each variant.
 	// The target objects are identified by (URI, offset).
@@ -398,7 +475,7 @@ func ordinaryReferences(ctx context.Context, snapshot *cache.Snapshot, uri proto
 			continue
 		}
 		mp := mp
-		group.Go(func() error {
+		goSearch(func() error {
 			// TODO(adonovan): opt: batch these TypeChecks.
 			pkgs, err := snapshot.TypeCheck(ctx, mp.ID)
 			if err != nil {
@@ -443,7 +520,7 @@ func ordinaryReferences(ctx context.Context, snapshot *cache.Snapshot, uri proto
 	// corresponding methods (see above), which expand the global search.
 	// The target objects are identified by (PkgPath, objectpath).
 	for id := range expansions {
-		group.Go(func() error {
+		goSearch(func() error {
 			// TODO(adonovan): opt: batch these TypeChecks.
 			pkgs, err := snapshot.TypeCheck(ctx, id)
 			if err != nil {
@@ -471,7 +548,7 @@ func ordinaryReferences(ctx context.Context, snapshot *cache.Snapshot, uri proto
 	}
 
 	// Compute global references for selected reverse dependencies.
-	group.Go(func() error {
+	goSearch(func() error {
 		var globalIDs []PackageID
 		for id := range globalScope {
 			globalIDs = append(globalIDs, id)
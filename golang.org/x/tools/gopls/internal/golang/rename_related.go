@@ -0,0 +1,360 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+// This file defines "related" edits of a renaming: updates to textual
+// references to the renamed entity that are not checked by the
+// compiler, and so cannot be found with certainty. They are:
+//
+//   - references to a struct field by name within the tags of the
+//     other fields of its struct, as used by validation libraries
+//     (e.g. `validate:"required_without=Email"`). Only the tag keys
+//     in [fieldReferenceTagKeys] are updated: the values of encoding
+//     keys such as json, xml, or yaml are names in an external data
+//     format, and renaming them would change the serialized output;
+//   - go:embed patterns that name a renamed file or directory.
+//
+// Related edits are annotated as requiring confirmation, so that
+// the client can present them to the user for review before they are
+// applied. They are offered only to clients that honor change
+// annotations.
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/util/pathutil"
+	"golang.org/x/tools/gopls/internal/util/safetoken"
+	"golang.org/x/tools/internal/diff"
+	"golang.org/x/tools/internal/event"
+)
+
+// relatedEditsAnnotation identifies the change annotation of related edits.
+const relatedEditsAnnotation protocol.ChangeAnnotationIdentifier = "gopls.relatedEdits"
+
+// AddRelatedRenameEdits adds to edit the related edits of the renaming
+// of the identifier at rng to newName, such as references to a struct
+// field from the tags of its struct. The related edits are annotated
+// as requiring confirmation.
+func AddRelatedRenameEdits(ctx context.Context, snapshot *cache.Snapshot, f file.Handle, rng protocol.Range, newName string, edit *protocol.WorkspaceEdit) error {
+	ctx, done := event.Start(ctx, "golang.AddRelatedRenameEdits")
+	defer done()
+
+	pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, f.URI())
+	if err != nil {
+		return err
+	}
+	start, end, err := pgf.RangePos(rng)
+	if err != nil {
+		return err
+	}
+	cur, ok := pgf.Cursor().FindByPos(start, end)
+	if !ok {
+		return nil
+	}
+	objs, err := objectsAt(pkg.TypesInfo(), cur)
+	if err != nil {
+		return nil // not an identifier; no related edits
+	}
+	field, ok := objs[0].obj.(*types.Var)
+	if !ok || !field.IsField() || field.Embedded() || field.Pkg() == nil {
+		return nil
+	}
+
+	// Find the package that declares the field.
+	declURI := protocol.URIFromPath(safetoken.StartPosition(pkg.FileSet(), field.Pos()).Filename)
+	if field.Pkg() != pkg.Types() {
+		pkg, _, err = NarrowestPackageForFile(ctx, snapshot, declURI)
+		if err != nil {
+			return err
+		}
+	}
+	declPGF, err := pkg.FileEnclosing(field.Pos())
+	if err != nil {
+		return nil // e.g. field of a struct in a cgo-generated file
+	}
+
+	// Find the struct type that declares the field.
+	var structType *ast.StructType
+	declCur, ok := declPGF.Cursor().FindByPos(field.Pos(), field.Pos())
+	if ok {
+		for c := range declCur.Enclosing((*ast.StructType)(nil)) {
+			structType = c.Node().(*ast.StructType)
+			break
+		}
+	}
+	if structType == nil {
+		return nil
+	}
+
+	edits := structTagRenameEdits(declPGF.Tok, structType, field.Name(), newName)
+	if len(edits) == 0 {
+		return nil
+	}
+	textedits, err := protocol.EditsFromDiffEdits(declPGF.Mapper, edits)
+	if err != nil {
+		return err
+	}
+	fh, err := snapshot.ReadFile(ctx, declURI)
+	if err != nil {
+		return err
+	}
+	addRelatedEdits(edit, fh, textedits, fmt.Sprintf("Update struct tags that refer to field %s", field.Name()))
+	return nil
+}
+
+// fieldReferenceTagKeys is the set of struct tag keys whose values
+// are known to refer to other fields of the struct by their Go names.
+var fieldReferenceTagKeys = map[string]bool{
+	"validate": true, // github.com/go-playground/validator
+	"binding":  true, // github.com/gin-gonic/gin, via validator
+}
+
+// structTagRenameEdits returns the edits that replace each occurrence
+// of the word oldName with newName within the values of the tags of
+// the fields of the struct whose keys are in [fieldReferenceTagKeys].
+// Only raw string tags are updated, as offsets within interpreted
+// strings may be skewed by escapes.
+func structTagRenameEdits(tok *token.File, structType *ast.StructType, oldName, newName string) []diff.Edit {
+	wordRegexp := regexp.MustCompile(`\b` + regexp.QuoteMeta(oldName) + `\b`)
+	var edits []diff.Edit
+	for _, field := range structType.Fields.List {
+		lit := field.Tag
+		if lit == nil || !strings.HasPrefix(lit.Value, "`") {
+			continue
+		}
+		start, err := safetoken.Offset(tok, lit.Pos())
+		if err != nil {
+			continue
+		}
+		for _, kv := range structTagValues(lit.Value[1 : len(lit.Value)-1]) {
+			if !fieldReferenceTagKeys[kv.key] {
+				continue
+			}
+			for _, loc := range wordRegexp.FindAllStringIndex(kv.value, -1) {
+				offset := start + 1 + kv.offset // skip the backquote
+				edits = append(edits, diff.Edit{
+					Start: offset + loc[0],
+					End:   offset + loc[1],
+					New:   newName,
+				})
+			}
+		}
+	}
+	return edits
+}
+
+// A structTagValue is a key:"value" pair of a struct tag.
+type structTagValue struct {
+	key, value string
+	offset     int // offset of value within the tag
+}
+
+// structTagValues parses a struct tag according to the conventional
+// syntax of [reflect.StructTag]. It returns only values without
+// escapes, whose offsets are thus exact; it stops at the first syntax
+// error.
+func structTagValues(tag string) []structTagValue {
+	var (
+		values []structTagValue
+		offset = 0
+	)
+	for {
+		trimmed := strings.TrimLeft(tag, " ")
+		offset += len(tag) - len(trimmed)
+		tag = trimmed
+
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return values
+		}
+		key := tag[:i]
+		valueOffset := offset + i + 2
+
+		// Find the closing quote of the value.
+		j := i + 2
+		escaped := false
+		for j < len(tag) && tag[j] != '"' {
+			if tag[j] == '\\' {
+				escaped = true
+				j++
+			}
+			j++
+		}
+		if j >= len(tag) {
+			return values
+		}
+		if !escaped {
+			values = append(values, structTagValue{key, tag[i+2 : j], valueOffset})
+		}
+		offset += j + 1
+		tag = tag[j+1:]
+	}
+}
+
+// RelatedFileRenameEdits returns the related edits of the renaming of
+// files or directories: updates to the go:embed patterns that name
+// them. The edits are annotated as requiring confirmation.
+// It returns nil if there are no such edits.
+func RelatedFileRenameEdits(ctx context.Context, snapshot *cache.Snapshot, renames []protocol.FileRename) (*protocol.WorkspaceEdit, error) {
+	ctx, done := event.Start(ctx, "golang.RelatedFileRenameEdits")
+	defer done()
+
+	mps, err := snapshot.WorkspaceMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	edit := &protocol.WorkspaceEdit{}
+	seen := make(map[protocol.DocumentURI]bool)
+	for _, mp := range mps {
+		for _, uri := range mp.GoFiles {
+			if seen[uri] {
+				continue // e.g. a file of both p and p [p.test]
+			}
+			seen[uri] = true
+
+			// Find the renamings of files within the package directory,
+			// which are the only ones that go:embed patterns may name.
+			dir := uri.DirPath()
+			type renaming struct{ oldRel, newRel string }
+			var renamings []renaming
+			for _, rename := range renames {
+				oldPath := rename.OldURI.Path()
+				newPath := rename.NewURI.Path()
+				if !pathutil.InDir(dir, oldPath) || !pathutil.InDir(dir, newPath) {
+					continue
+				}
+				oldRel, err1 := filepath.Rel(dir, oldPath)
+				newRel, err2 := filepath.Rel(dir, newPath)
+				if err1 != nil || err2 != nil {
+					continue
+				}
+				renamings = append(renamings, renaming{filepath.ToSlash(oldRel), filepath.ToSlash(newRel)})
+			}
+			if len(renamings) == 0 {
+				continue
+			}
+
+			fh, err := snapshot.ReadFile(ctx, uri)
+			if err != nil {
+				return nil, err
+			}
+			content, err := fh.Content()
+			if err != nil {
+				continue // e.g. file was deleted
+			}
+			var edits []diff.Edit
+			for _, r := range renamings {
+				edits = append(edits, embedRenameEdits(content, r.oldRel, r.newRel)...)
+			}
+			if len(edits) == 0 {
+				continue
+			}
+			textedits, err := protocol.EditsFromDiffEdits(protocol.NewMapper(uri, content), edits)
+			if err != nil {
+				return nil, err
+			}
+			addRelatedEdits(edit, fh, textedits, "Update go:embed patterns that refer to renamed files")
+		}
+	}
+	if len(edit.DocumentChanges) == 0 {
+		return nil, nil
+	}
+	return edit, nil
+}
+
+// embedRenameEdits returns the edits that update each go:embed
+// pattern in the Go source content that names oldPath, or a file
+// within it, to refer to newPath. Paths are slash-separated and
+// relative to the package directory. Patterns containing wildcards
+// are left unchanged.
+func embedRenameEdits(content []byte, oldPath, newPath string) []diff.Edit {
+	var edits []diff.Edit
+	const directive = "//go:embed"
+	offset := 0
+	for line := range strings.Lines(string(content)) {
+		lineStart := offset
+		offset += len(line)
+		if !strings.HasPrefix(line, directive) {
+			continue
+		}
+		patterns, err := parseGoEmbed(strings.TrimRight(line[len(directive):], "\r\n"), lineStart+len(directive))
+		if err != nil {
+			continue
+		}
+		for _, p := range patterns {
+			if strings.ContainsAny(p.pattern, "*?[") {
+				continue
+			}
+			var rest string
+			if p.pattern == oldPath {
+				rest = ""
+			} else if after, ok := strings.CutPrefix(p.pattern, oldPath+"/"); ok {
+				rest = "/" + after
+			} else {
+				continue
+			}
+			newPattern := newPath + rest
+			switch content[p.startOffset] {
+			case '"':
+				newPattern = strconv.Quote(newPattern)
+			case '`':
+				newPattern = "`" + newPattern + "`"
+			default:
+				if strings.ContainsAny(newPattern, " \t\"`") {
+					newPattern = strconv.Quote(newPattern)
+				}
+			}
+			edits = append(edits, diff.Edit{Start: p.startOffset, End: p.endOffset, New: newPattern})
+		}
+	}
+	return edits
+}
+
+// addRelatedEdits adds the related text edits for the file to edit,
+// annotated as requiring confirmation. If edit already changes the
+// file, the related edits are added to the same TextDocumentEdit, so
+// that all their ranges refer to the original content.
+func addRelatedEdits(edit *protocol.WorkspaceEdit, fh file.Handle, textedits []protocol.TextEdit, label string) {
+	id := relatedEditsAnnotation
+	var elems []protocol.Or_TextDocumentEdit_edits_Elem
+	for _, te := range textedits {
+		elems = append(elems, protocol.Or_TextDocumentEdit_edits_Elem{
+			Value: protocol.AnnotatedTextEdit{AnnotationID: &id, TextEdit: te},
+		})
+	}
+
+	if edit.ChangeAnnotations == nil {
+		edit.ChangeAnnotations = make(map[protocol.ChangeAnnotationIdentifier]protocol.ChangeAnnotation)
+	}
+	edit.ChangeAnnotations[id] = protocol.ChangeAnnotation{
+		Label:             label,
+		NeedsConfirmation: true,
+		Description:       "These references are not checked by the compiler and may not refer to the renamed entity.",
+	}
+
+	for _, change := range edit.DocumentChanges {
+		if tde := change.TextDocumentEdit; tde != nil && tde.TextDocument.URI == fh.URI() {
+			tde.Edits = append(tde.Edits, elems...)
+			return
+		}
+	}
+	change := protocol.DocumentChangeEdit(fh, nil)
+	change.TextDocumentEdit.Edits = elems
+	edit.DocumentChanges = append(edit.DocumentChanges, change)
+}
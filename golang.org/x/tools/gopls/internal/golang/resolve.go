@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"go/token"
+	"path/filepath"
 	"slices"
 	"strings"
 	"unicode"
@@ -227,8 +228,8 @@ var implementInterfaceFormString = []protocol.FormField{
 
 var moveDeclarationFormString = []protocol.FormField{
 	{
-		ID:          "string",
-		Description: "destination file uri for the moved declaration, e.g. file:///path/to/file.go",
+		ID:          "file",
+		Description: "destination file for the moved declaration: a file URI, or a path relative to the directory of the current file",
 		Type: protocol.FormFieldTypeFile{
 			Kind: "string",
 		},
@@ -333,17 +334,31 @@ func resolveMoveDeclaration(options settings.ClientOptions, param *protocol.Exec
 		return nil
 	}
 
-	file, err := FormAnswer[string](&param.InteractiveParams, "file")
-	if err != nil {
-		return err
-	}
-	if _, err := protocol.ParseDocumentURI(file); err != nil {
+	if _, err := MoveDeclarationDest(&param.InteractiveParams, a0.Location.URI); err != nil {
 		return err
 	}
 	param.FormFields = nil
 	return nil
 }
 
+// MoveDeclarationDest returns the destination file of a "move
+// declaration" refactoring of a declaration in the file src: the answer
+// to the "file" form field, which is either a file URI or a path
+// relative to the directory of src.
+func MoveDeclarationDest(params *protocol.InteractiveParams, src protocol.DocumentURI) (protocol.DocumentURI, error) {
+	file, err := FormAnswer[string](params, "file")
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(file, "file:") {
+		return protocol.ParseDocumentURI(file)
+	}
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(src.DirPath(), file)
+	}
+	return protocol.URIFromPath(file), nil
+}
+
 // FormAnswer finds, validates, and returns the unique answer for id.
 //
 // It uses a linear scan since the number of answers is small (usually < 5).
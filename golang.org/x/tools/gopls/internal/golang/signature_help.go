@@ -8,12 +8,14 @@ import (
 	"context"
 	"fmt"
 	"go/ast"
+	"go/doc"
 	"go/token"
 	"go/types"
 	"strings"
 
 	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
 	"golang.org/x/tools/gopls/internal/file"
 	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/gopls/internal/settings"
@@ -71,10 +73,21 @@ loop:
 				fnval = callExpr.Fun
 				break loop
 			}
-		case *ast.FuncLit, *ast.FuncType, *ast.CompositeLit:
-			// The user is within an anonymous function or
-			// a composite literal, which may be the argument
-			// to the *ast.CallExpr.
+		case *ast.CompositeLit:
+			// Within the braces of a struct literal, show its
+			// fields as if they were the parameters of a call.
+			// Otherwise, as with an anonymous function, the
+			// literal may be the argument to the *ast.CallExpr;
+			// don't show signature help in this case.
+			if node.Lbrace < start && end <= node.Rbrace {
+				if tStruct, ok := typesinternal.Unpointer(info.TypeOf(node)).Underlying().(*types.Struct); ok {
+					return compositeLitSignatureHelp(ctx, snapshot, pkg, pgf, node, tStruct, start, end)
+				}
+			}
+			return nil, nil
+		case *ast.FuncLit, *ast.FuncType:
+			// The user is within an anonymous function, which may be
+			// the argument to the *ast.CallExpr.
 			// Don't show signature help in this case.
 			return nil, nil
 		case *ast.BasicLit:
@@ -156,6 +169,126 @@ loop:
 	return signatureInformation(s, snapshot.Options(), start, end, callExpr)
 }
 
+// compositeLitSignatureHelp returns signature help for the struct
+// literal lit of type tStruct, presenting its fields as parameters.
+// The active parameter is the field of the element at the position,
+// if any.
+func compositeLitSignatureHelp(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Package, pgf *parsego.File, lit *ast.CompositeLit, tStruct *types.Struct, start, end token.Pos) (*protocol.SignatureInformation, error) {
+	var (
+		options = snapshot.Options()
+		info    = pkg.TypesInfo()
+		mq      = MetadataQualifierForFile(snapshot, pgf.File, pkg.Metadata())
+		qual    = typesinternal.FileQualifier(pgf.File, pkg.Types())
+	)
+
+	// docOf returns the documentation of obj, subject to the HoverKind option.
+	docOf := func(obj types.Object) (string, error) {
+		comment, err := HoverDocForObject(ctx, snapshot, pkg.FileSet(), obj)
+		if err != nil || comment == nil {
+			return "", err
+		}
+		switch options.HoverKind {
+		case settings.SynopsisDocumentation:
+			return doc.Synopsis(comment.Text()), nil
+		case settings.NoDocumentation:
+			return "", nil
+		}
+		return strings.TrimSuffix(comment.Text(), "\n"), nil
+	}
+
+	// The name is that of the literal's type, or "struct" if it is unnamed.
+	var (
+		name    = "struct"
+		typeDoc string
+	)
+	if t, ok := types.Unalias(typesinternal.Unpointer(info.TypeOf(lit))).(*types.Named); ok {
+		name = types.TypeString(t, qual)
+		d, err := docOf(t.Obj())
+		if err != nil {
+			return nil, err
+		}
+		typeDoc = d
+	}
+
+	var (
+		label  strings.Builder
+		params = make([]protocol.ParameterInformation, 0, tStruct.NumFields())
+	)
+	label.WriteString(name + "{")
+	for i := range tStruct.NumFields() {
+		field := tStruct.Field(i)
+		typ, err := FormatVarType(ctx, snapshot, pkg, field, qual, mq)
+		if err != nil {
+			return nil, err
+		}
+		p := typ
+		if !field.Embedded() {
+			p = field.Name() + " " + typ
+		}
+		d, err := docOf(field)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			label.WriteString(", ")
+		}
+		label.WriteString(p)
+		params = append(params, protocol.ParameterInformation{Label: p, Documentation: d})
+	}
+	label.WriteString("}")
+
+	return &protocol.SignatureInformation{
+		Label:           label.String(),
+		Documentation:   stringToSigInfoDocumentation(typeDoc, options),
+		Parameters:      params,
+		ActiveParameter: activeField(info, lit, tStruct, end),
+	}, nil
+}
+
+// activeField returns a pointer to a variable containing the index
+// of the field of tStruct initialized by the element of lit that
+// encloses or precedes the position end, or nil if it is not known.
+func activeField(info *types.Info, lit *ast.CompositeLit, tStruct *types.Struct, end token.Pos) *uint32 {
+	if tStruct.NumFields() == 0 {
+		return nil
+	}
+	for i, elt := range lit.Elts {
+		if end > elt.End() {
+			// Beyond the comma, if any, that follows an element, the next
+			// element is active: in an unkeyed literal, its index is known.
+			if i == len(lit.Elts)-1 {
+				if _, ok := elt.(*ast.KeyValueExpr); !ok && i+1 < tStruct.NumFields() {
+					index := uint32(i + 1)
+					return &index
+				}
+				return nil
+			}
+			continue
+		}
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			key, ok := kv.Key.(*ast.Ident)
+			if !ok {
+				return nil
+			}
+			for j := range tStruct.NumFields() {
+				if tStruct.Field(j) == info.ObjectOf(key) {
+					index := uint32(j)
+					return &index
+				}
+			}
+			return nil
+		}
+		if i >= tStruct.NumFields() {
+			return nil
+		}
+		index := uint32(i)
+		return &index
+	}
+	// No elements yet: assume the first field is next.
+	index := uint32(0)
+	return &index
+}
+
 func signatureInformation(sig *signature, options *settings.Options, start, end token.Pos, call *ast.CallExpr) (*protocol.SignatureInformation, error) {
 	paramInfo := make([]protocol.ParameterInformation, 0, len(sig.params))
 	for _, p := range sig.params {
@@ -0,0 +1,352 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+// This file defines the "struct_layout" code lens and command, which
+// report the memory layout of a struct type, and the "Reorder fields
+// for minimal padding" code action.
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
+	"golang.org/x/tools/gopls/internal/util/safetoken"
+	"golang.org/x/tools/internal/structlayout"
+	"golang.org/x/tools/internal/typeparams"
+)
+
+// structLayoutCodeLens annotates each struct type declaration in the
+// file with a summary of its memory layout.
+func structLayoutCodeLens(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle) ([]protocol.CodeLens, error) {
+	pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, fh.URI())
+	if err != nil {
+		return nil, err
+	}
+	var lenses []protocol.CodeLens
+	for n := range ast.Preorder(pgf.File) {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+		layout, ok := structLayout(pkg, spec)
+		if !ok {
+			continue
+		}
+		rng, err := pgf.NodeRange(spec.Name)
+		if err != nil {
+			return nil, err
+		}
+		title := fmt.Sprintf("size=%d, align=%d, padding=%d", layout.Size, layout.Align, layout.Padding)
+		if layout.OptimalSize < layout.Size {
+			title += fmt.Sprintf(", optimal size=%d", layout.OptimalSize)
+		}
+		cmd := command.NewStructLayoutCommand(title, command.StructLayoutArgs{
+			Location: pgf.URI.Location(rng),
+		})
+		lenses = append(lenses, protocol.CodeLens{
+			Range:   protocol.Range{Start: rng.Start, End: rng.Start},
+			Command: cmd,
+		})
+	}
+	return lenses, nil
+}
+
+// StructLayout returns the memory layout of the struct type whose
+// declaration encloses loc.
+func StructLayout(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, loc protocol.Location) (command.StructLayoutResult, error) {
+	pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, fh.URI())
+	if err != nil {
+		return command.StructLayoutResult{}, err
+	}
+	start, end, err := pgf.RangePos(loc.Range)
+	if err != nil {
+		return command.StructLayoutResult{}, err
+	}
+	spec := enclosingStructSpec(pgf, start, end)
+	if spec == nil {
+		return command.StructLayoutResult{}, fmt.Errorf("no struct type declaration at selection")
+	}
+	layout, ok := structLayout(pkg, spec)
+	if !ok {
+		return command.StructLayoutResult{}, fmt.Errorf("the layout of %s cannot be computed", spec.Name.Name)
+	}
+	return layout, nil
+}
+
+// FormatStructLayout formats the layout of a struct as a table.
+func FormatStructLayout(layout command.StructLayoutResult) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "type %s: size=%d, align=%d, padding=%d", layout.Name, layout.Size, layout.Align, layout.Padding)
+	if layout.OptimalSize < layout.Size {
+		fmt.Fprintf(&buf, ", optimal size=%d", layout.OptimalSize)
+	}
+	for _, f := range layout.Fields {
+		fmt.Fprintf(&buf, "\n%s: offset=%d, size=%d, align=%d", f.Name, f.Offset, f.Size, f.Align)
+		if f.Padding > 0 {
+			fmt.Fprintf(&buf, ", padding=%d", f.Padding)
+		}
+	}
+	return buf.String()
+}
+
+// enclosingStructSpec returns the declaration of a struct type that
+// encloses [start, end), or nil.
+func enclosingStructSpec(pgf *parsego.File, start, end token.Pos) *ast.TypeSpec {
+	cur, ok := pgf.Cursor().FindByPos(start, end)
+	if !ok {
+		return nil
+	}
+	for cur := range cur.Enclosing((*ast.TypeSpec)(nil)) {
+		spec := cur.Node().(*ast.TypeSpec)
+		if _, ok := spec.Type.(*ast.StructType); ok {
+			return spec
+		}
+	}
+	return nil
+}
+
+// structLayout computes the memory layout of the struct type declared
+// by spec. It reports false if spec does not declare a non-generic
+// struct type whose fields all have a well-defined size.
+func structLayout(pkg *cache.Package, spec *ast.TypeSpec) (command.StructLayoutResult, bool) {
+	if _, ok := spec.Type.(*ast.StructType); !ok || spec.TypeParams != nil {
+		return command.StructLayoutResult{}, false
+	}
+	tStruct, ok := pkg.TypesInfo().TypeOf(spec.Type).(*types.Struct)
+	if !ok {
+		return command.StructLayoutResult{}, false
+	}
+	fields := slices.Collect(tStruct.Fields())
+	if !validFieldTypes(fields) {
+		return command.StructLayoutResult{}, false
+	}
+
+	sizes := pkg.TypesSizes()
+	layout := command.StructLayoutResult{
+		Name:  spec.Name.Name,
+		Size:  sizes.Sizeof(tStruct),
+		Align: sizes.Alignof(tStruct),
+	}
+	offsets := sizes.Offsetsof(fields)
+	for i, f := range fields {
+		size := sizes.Sizeof(f.Type())
+		next := layout.Size
+		if i+1 < len(fields) {
+			next = offsets[i+1]
+		}
+		padding := next - offsets[i] - size
+		layout.Padding += padding
+		layout.Fields = append(layout.Fields, command.StructLayoutField{
+			Name:    f.Name(),
+			Offset:  offsets[i],
+			Size:    size,
+			Align:   sizes.Alignof(f.Type()),
+			Padding: padding,
+		})
+	}
+	optimal, _ := structlayout.OptimalOrder(tStruct, structlayout.SizesFor(sizes))
+	layout.OptimalSize = sizes.Sizeof(optimal)
+	return layout, true
+}
+
+// validFieldTypes reports whether the sizes of the types of all fields
+// are well defined.
+func validFieldTypes(fields []*types.Var) bool {
+	var free typeparams.Free
+	for _, f := range fields {
+		if f.Type().Underlying() == types.Typ[types.Invalid] || free.Has(f.Type()) {
+			return false
+		}
+	}
+	return true
+}
+
+// structSize returns the size of a struct with the specified fields.
+func structSize(sizes types.Sizes, fields []*types.Var) int64 {
+	return sizes.Sizeof(types.NewStruct(fields, nil))
+}
+
+// A fieldUnit is a field declaration of a struct type along with
+// its line comment, which are moved together when reordering fields.
+type fieldUnit struct {
+	field      *ast.Field
+	vars       []*types.Var
+	start, end token.Pos // including the line comment
+}
+
+// canReorderFields reports whether the fields of the struct type
+// declaration enclosing [start, end) can be reordered to reduce its size.
+func canReorderFields(pkg *cache.Package, pgf *parsego.File, start, end token.Pos) bool {
+	spec := enclosingStructSpec(pgf, start, end)
+	if spec == nil {
+		return false
+	}
+	_, ok := reorderedFieldGroups(pkg, pgf, spec)
+	return ok
+}
+
+// reorderFields is a singleFileFixer that reorders the fields of the
+// struct type declaration enclosing [start, end) to minimize padding.
+// Fields are reordered only within groups of consecutive lines: field
+// groups separated by blank lines or comment lines are preserved.
+func reorderFields(pkg *cache.Package, pgf *parsego.File, start, end token.Pos) (*token.FileSet, *analysis.SuggestedFix, error) {
+	spec := enclosingStructSpec(pgf, start, end)
+	if spec == nil {
+		return nil, nil, fmt.Errorf("no struct type declaration at selection")
+	}
+	groups, ok := reorderedFieldGroups(pkg, pgf, spec)
+	if !ok {
+		return nil, nil, fmt.Errorf("reordering the fields of %s does not reduce its size", spec.Name.Name)
+	}
+
+	var edits []analysis.TextEdit
+	for _, group := range groups {
+		// Use the indentation of the first field.
+		first := slices.MinFunc(group, func(x, y fieldUnit) int { return int(x.start - y.start) })
+		last := slices.MaxFunc(group, func(x, y fieldUnit) int { return int(x.end - y.end) })
+		startOffset, err := safetoken.Offset(pgf.Tok, first.start)
+		if err != nil {
+			return nil, nil, err
+		}
+		lineStart := startOffset
+		for lineStart > 0 && pgf.Src[lineStart-1] != '\n' {
+			lineStart--
+		}
+		indent := string(pgf.Src[lineStart:startOffset])
+
+		var texts []string
+		for _, u := range group {
+			text, err := pgf.PosText(u.start, u.end)
+			if err != nil {
+				return nil, nil, err
+			}
+			texts = append(texts, string(text))
+		}
+		edits = append(edits, analysis.TextEdit{
+			Pos:     first.start,
+			End:     last.end,
+			NewText: []byte(strings.Join(texts, "\n"+indent)),
+		})
+	}
+	return pkg.FileSet(), &analysis.SuggestedFix{TextEdits: edits}, nil
+}
+
+// reorderedFieldGroups returns the groups of field declarations of
+// the struct type declared by spec, each sorted so as to minimize
+// padding. It reports false if the fields cannot be reordered, or if
+// reordering them would not reduce the size of the struct.
+func reorderedFieldGroups(pkg *cache.Package, pgf *parsego.File, spec *ast.TypeSpec) ([][]fieldUnit, bool) {
+	layout, ok := structLayout(pkg, spec)
+	if !ok || layout.Padding == 0 {
+		return nil, false
+	}
+	var (
+		info   = pkg.TypesInfo()
+		sizes  = pkg.TypesSizes()
+		fields = spec.Type.(*ast.StructType).Fields.List
+		groups [][]fieldUnit
+		line   = func(pos token.Pos) int { return safetoken.Line(pgf.Tok, pos) }
+	)
+	for i, field := range fields {
+		u := fieldUnit{field: field, start: field.Pos(), end: field.End()}
+		if field.Comment != nil {
+			u.end = field.Comment.End()
+		}
+		for _, name := range field.Names {
+			v, ok := info.Defs[name].(*types.Var)
+			if !ok {
+				return nil, false
+			}
+			u.vars = append(u.vars, v)
+		}
+		if len(field.Names) == 0 { // embedded field
+			v, ok := embeddedFieldVar(info, field)
+			if !ok {
+				return nil, false
+			}
+			u.vars = append(u.vars, v)
+		}
+		if i == 0 {
+			groups = append(groups, []fieldUnit{u})
+			continue
+		}
+		// A comment line above a field (its doc comment) stays in
+		// place, like a blank line.
+		prevEnd := line(groups[len(groups)-1][len(groups[len(groups)-1])-1].end)
+		switch {
+		case line(u.start) == prevEnd:
+			return nil, false // several fields on one line
+		case line(u.start) > prevEnd+1:
+			groups = append(groups, []fieldUnit{u}) // blank line or comment line
+		default:
+			groups[len(groups)-1] = append(groups[len(groups)-1], u)
+		}
+	}
+
+	var (
+		reordered [][]fieldUnit
+		vars      []*types.Var
+	)
+	for _, group := range groups {
+		// Order the declarations as fieldalignment orders their
+		// first fields; all the fields of a declaration share a type.
+		first := make([]*types.Var, len(group))
+		for i, u := range group {
+			first[i] = u.vars[0]
+		}
+		_, order := structlayout.OptimalOrder(types.NewStruct(first, nil), structlayout.SizesFor(sizes))
+		sorted := make([]fieldUnit, len(group))
+		for i, j := range order {
+			sorted[i] = group[j]
+		}
+		if !slices.EqualFunc(group, sorted, func(x, y fieldUnit) bool { return x.field == y.field }) {
+			reordered = append(reordered, sorted)
+		}
+		for _, u := range sorted {
+			vars = append(vars, u.vars...)
+		}
+	}
+	if len(reordered) == 0 || structSize(sizes, vars) >= layout.Size {
+		return nil, false
+	}
+	return reordered, true
+}
+
+// embeddedFieldVar returns the field variable declared by an
+// embedded field.
+func embeddedFieldVar(info *types.Info, field *ast.Field) (*types.Var, bool) {
+	typ := field.Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if idx, ok := typ.(*ast.IndexExpr); ok {
+		typ = idx.X
+	} else if idx, ok := typ.(*ast.IndexListExpr); ok {
+		typ = idx.X
+	}
+	var id *ast.Ident
+	switch typ := typ.(type) {
+	case *ast.Ident:
+		id = typ
+	case *ast.SelectorExpr:
+		id = typ.Sel
+	}
+	if id == nil {
+		return nil, false
+	}
+	v, ok := info.Defs[id].(*types.Var)
+	return v, ok
+}
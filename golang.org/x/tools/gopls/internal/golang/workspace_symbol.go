@@ -51,6 +51,13 @@ type WorkspaceSymbolsOptions struct {
 	// package-qualified) used for both matching against the query and for
 	// the final presentation.
 	Style settings.SymbolStyle
+
+	// Partial, if non-nil, is called (not concurrently) with each
+	// non-empty batch of results as it is found, to support the
+	// partial results of an LSP request. Each batch holds the matches
+	// from a chunk of files: results are ranked within a batch, but
+	// not across batches.
+	Partial func([]protocol.SymbolInformation)
 }
 
 // SymbolFilter returns whether the symbol we want to keep.
@@ -79,8 +86,36 @@ func WorkspaceSymbols(ctx context.Context, snapshots []*cache.Snapshot, query st
 	if query == "" {
 		return nil, nil
 	}
+	if opts.Partial == nil {
+		return collectSymbols(ctx, snapshots, query, opts, nil)
+	}
 
-	return collectSymbols(ctx, snapshots, query, opts)
+	// Stream the results of each chunk of files in turn,
+	// subject to the overall limit.
+	var (
+		all  []protocol.SymbolInformation
+		seen = make(map[protocol.Location]bool)
+	)
+	_, err := collectSymbols(ctx, snapshots, query, opts, func(syms []protocol.SymbolInformation) {
+		var batch []protocol.SymbolInformation
+		for _, sym := range syms {
+			if len(all)+len(batch) == maxSymbols {
+				break
+			}
+			if !seen[sym.Location] {
+				seen[sym.Location] = true
+				batch = append(batch, sym)
+			}
+		}
+		if len(batch) > 0 {
+			opts.Partial(batch)
+			all = append(all, batch...)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
 }
 
 // A matcherFunc returns the index and score of a symbol match.
@@ -305,7 +340,11 @@ func (c comboMatcher) match(chunks []string) (int, float64) {
 // The search behavior (scoring, filtering, and formatting) is governed by
 // the provided options. See [WorkspaceSymbolsOptions] for details on how
 // matches are calculated and styled.
-func collectSymbols(ctx context.Context, snapshots []*cache.Snapshot, query string, opts WorkspaceSymbolsOptions) ([]protocol.SymbolInformation, error) {
+//
+// If partial is non-nil, the files are matched in chunks of
+// [symbolChunkFiles], partial is called with the sorted matches
+// of each chunk as soon as it is complete, and the result is nil.
+func collectSymbols(ctx context.Context, snapshots []*cache.Snapshot, query string, opts WorkspaceSymbolsOptions, partial func([]protocol.SymbolInformation)) ([]protocol.SymbolInformation, error) {
 	// Extract symbols from all files.
 	var work []symbolFile
 	seen := make(map[protocol.DocumentURI]*metadata.Package) // only scan each file once
@@ -411,7 +450,26 @@ func collectSymbols(ctx context.Context, snapshots []*cache.Snapshot, query stri
 		panic(fmt.Sprintf("unknown symbol style: %v", opts.Style))
 	}
 
-	// Match symbols in parallel.
+	if partial == nil {
+		return matchSymbols(work, symbolizer, query, opts).results(), nil
+	}
+	for chunk := range slices.Chunk(work, symbolChunkFiles) {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if syms := matchSymbols(chunk, symbolizer, query, opts).results(); len(syms) > 0 {
+			partial(syms)
+		}
+	}
+	return nil, nil
+}
+
+// symbolChunkFiles is the number of files whose symbols are
+// matched together when streaming partial results.
+const symbolChunkFiles = 256
+
+// matchSymbols matches the symbols of the given files in parallel.
+func matchSymbols(work []symbolFile, symbolizer symbolizer, query string, opts WorkspaceSymbolsOptions) *symbolStore {
 	// Each worker has its own symbolStore,
 	// which we merge at the end.
 	nmatchers := runtime.GOMAXPROCS(-1) // matching is CPU bound
@@ -429,16 +487,11 @@ func collectSymbols(ctx context.Context, snapshots []*cache.Snapshot, query stri
 	}
 
 	// Gather and merge results as they arrive.
-	var unified symbolStore
+	unified := new(symbolStore)
 	for range nmatchers {
-		store := <-results
-		for _, syms := range store.res {
-			if syms != nil {
-				unified.store(syms)
-			}
-		}
+		unified.merge(<-results)
 	}
-	return unified.results(), nil
+	return unified
 }
 
 // symbolFile holds symbol information for a single file.
@@ -569,6 +622,15 @@ func (sc *symbolStore) store(ss *scoredSymbol) {
 	sc.res[insertAt] = ss
 }
 
+// merge stores the results of other in sc.
+func (sc *symbolStore) merge(other *symbolStore) {
+	for _, syms := range other.res {
+		if syms != nil {
+			sc.store(syms)
+		}
+	}
+}
+
 func (sc *symbolStore) tooLow(score float64) bool {
 	last := sc.res[len(sc.res)-1]
 	if last == nil {
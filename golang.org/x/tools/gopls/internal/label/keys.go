@@ -33,5 +33,8 @@ var (
 	GoplsPath    = keys.NewString("gopls_path", "")
 	ClientID     = keys.NewString("client_id", "")
 
+	MCPSession = keys.NewString("mcp_session", "The ID of an MCP session")
+	MCPClient  = keys.NewString("mcp_client", "The name and version of an MCP client")
+
 	Level = keys.NewInt("level", "The logging level")
 )
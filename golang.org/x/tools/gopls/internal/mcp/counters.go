@@ -14,10 +14,13 @@ var (
 	countGoFileContextMCP      = counter.New("gopls/mcp-tool:go_file_context")
 	countGoFileDiagnosticsMCP  = counter.New("gopls/mcp-tool:go_file_diagnostics")
 	countGoFileMetadataMCP     = counter.New("gopls/mcp-tool:go_file_metadata")
+	countGoFileSummaryMCP      = counter.New("gopls/mcp-tool:go_file_summary")
 	countGoPackageAPIMCP       = counter.New("gopls/mcp-tool:go_package_api")
 	countGoReferencesMCP       = counter.New("gopls/mcp-tool:go_references")
+	countGoRenameMCP           = counter.New("gopls/mcp-tool:go_rename")
 	countGoRenameSymbolMCP     = counter.New("gopls/mcp-tool:go_rename_symbol")
 	countGoSearchMCP           = counter.New("gopls/mcp-tool:go_search")
+	countGoSymbolDocsMCP       = counter.New("gopls/mcp-tool:go_symbol_docs")
 	countGoSymbolReferencesMCP = counter.New("gopls/mcp-tool:go_symbol_references")
 	countGoWorkspaceMCP        = counter.New("gopls/mcp-tool:go_workspace")
 	countGoVulncheckMCP        = counter.New("gopls/mcp-tool:go_vulncheck")
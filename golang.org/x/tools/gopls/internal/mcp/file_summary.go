@@ -0,0 +1,226 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/file"
+)
+
+// defaultSummaryTokens is the default token budget of go_file_summary.
+const defaultSummaryTokens = 1000
+
+type fileSummaryParams struct {
+	File      string `json:"file" jsonschema:"the absolute path to the file"`
+	MaxTokens int    `json:"max_tokens,omitempty" jsonschema:"the approximate maximum size of the summary, in tokens (default 1000)"`
+}
+
+func (h *handler) fileSummaryHandler(ctx context.Context, req *mcp.CallToolRequest, params fileSummaryParams) (*mcp.CallToolResult, any, error) {
+	countGoFileSummaryMCP.Inc()
+	fh, snapshot, release, err := h.fileOf(ctx, params.File)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	if snapshot.FileKind(fh) != file.Go {
+		return nil, nil, fmt.Errorf("can't summarize non-Go file %s", params.File)
+	}
+	pgf, err := snapshot.ParseGo(ctx, fh, parsego.Full)
+	if err != nil {
+		return nil, nil, err
+	}
+	maxTokens := params.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultSummaryTokens
+	}
+	return textResult(summarizeFile(pgf, maxTokens)), nil, nil
+}
+
+// A summaryDecl is the summary of a top-level declaration.
+type summaryDecl struct {
+	doc  string // first line of the doc comment, or ""
+	text string // condensed declaration, on one line
+}
+
+// summarizeFile returns a condensed summary of the structure of a
+// file: its package and imports, and for each declaration, its
+// signature and the first line of its doc comment. Types are
+// summarized with the names of their fields and methods, and
+// functions with their signature.
+//
+// The summary is sized to fit within maxTokens (estimated as
+// in [estimateTokens]): if it is too large, the doc comments are
+// dropped, then the declarations that do not fit, and a final line
+// reports how many declarations were omitted.
+func summarizeFile(pgf *parsego.File, maxTokens int) string {
+	var header strings.Builder
+	fmt.Fprintf(&header, "package %s\n", pgf.File.Name.Name)
+	if len(pgf.File.Imports) > 0 {
+		var paths []string
+		for _, imp := range pgf.File.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			if imp.Name != nil {
+				path = imp.Name.Name + " " + path
+			}
+			paths = append(paths, path)
+		}
+		fmt.Fprintf(&header, "\nimports: %s\n", strings.Join(paths, ", "))
+	}
+
+	var decls []summaryDecl
+	for _, decl := range pgf.File.Decls {
+		decls = append(decls, summarizeDecl(decl)...)
+	}
+
+	format := func(withDocs bool) string {
+		var out strings.Builder
+		out.WriteString(header.String())
+		for i, decl := range decls {
+			var text strings.Builder
+			text.WriteString("\n")
+			if withDocs && decl.doc != "" {
+				fmt.Fprintf(&text, "// %s\n", decl.doc)
+			}
+			text.WriteString(decl.text + "\n")
+			if !withDocs && estimateTokens(out.String()+text.String()) > maxTokens {
+				fmt.Fprintf(&out, "\n// ... %d more declarations omitted\n", len(decls)-i)
+				break
+			}
+			out.WriteString(text.String())
+		}
+		return out.String()
+	}
+	if summary := format(true); estimateTokens(summary) <= maxTokens {
+		return summary
+	}
+	return format(false)
+}
+
+// estimateTokens returns the approximate number of tokens used by
+// text, assuming an average of four bytes per token.
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// summarizeDecl returns the summaries of the specs of a declaration.
+func summarizeDecl(decl ast.Decl) []summaryDecl {
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		text := "func "
+		if decl.Recv != nil && len(decl.Recv.List) > 0 {
+			recv := decl.Recv.List[0]
+			text += "("
+			if len(recv.Names) > 0 {
+				text += recv.Names[0].Name + " "
+			}
+			text += types.ExprString(recv.Type) + ") "
+		}
+		text += decl.Name.Name + typeParams(decl.Type.TypeParams) + strings.TrimPrefix(types.ExprString(decl.Type), "func")
+		return []summaryDecl{{docLine(decl.Doc), text}}
+
+	case *ast.GenDecl:
+		if decl.Tok == token.IMPORT {
+			return nil
+		}
+		var result []summaryDecl
+		for _, spec := range decl.Specs {
+			doc := decl.Doc
+			if len(decl.Specs) > 1 || doc == nil {
+				doc = specDoc(spec)
+			}
+			var text string
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				text = "type " + spec.Name.Name + typeParams(spec.TypeParams)
+				if spec.Assign.IsValid() {
+					text += " ="
+				}
+				switch t := spec.Type.(type) {
+				case *ast.StructType:
+					text += " struct" + fieldNames(t.Fields)
+				case *ast.InterfaceType:
+					text += " interface" + fieldNames(t.Methods)
+				default:
+					text += " " + types.ExprString(t)
+				}
+			case *ast.ValueSpec:
+				var names []string
+				for _, name := range spec.Names {
+					names = append(names, name.Name)
+				}
+				text = decl.Tok.String() + " " + strings.Join(names, ", ")
+				if spec.Type != nil {
+					text += " " + types.ExprString(spec.Type)
+				}
+			}
+			result = append(result, summaryDecl{docLine(doc), text})
+		}
+		return result
+	}
+	return nil
+}
+
+// fieldNames returns the names of the fields (or methods) of a struct
+// (or interface) type, as "{ A, B, C }". Embedded fields are named by
+// their type.
+func fieldNames(fields *ast.FieldList) string {
+	var names []string
+	for _, field := range fields.List {
+		if len(field.Names) == 0 {
+			names = append(names, types.ExprString(field.Type))
+		}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	if len(names) == 0 {
+		return "{}"
+	}
+	return "{ " + strings.Join(names, ", ") + " }"
+}
+
+// typeParams returns the type parameters of a list as "[T any]",
+// or "" if there are none.
+func typeParams(fields *ast.FieldList) string {
+	if fields == nil {
+		return ""
+	}
+	var params []string
+	for _, field := range fields.List {
+		var names []string
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		params = append(params, strings.Join(names, ", ")+" "+types.ExprString(field.Type))
+	}
+	return "[" + strings.Join(params, ", ") + "]"
+}
+
+// specDoc returns the doc comment of a spec.
+func specDoc(spec ast.Spec) *ast.CommentGroup {
+	switch spec := spec.(type) {
+	case *ast.TypeSpec:
+		return spec.Doc
+	case *ast.ValueSpec:
+		return spec.Doc
+	}
+	return nil
+}
+
+// docLine returns the first line of a doc comment.
+func docLine(doc *ast.CommentGroup) string {
+	line, _, _ := strings.Cut(doc.Text(), "\n")
+	return line
+}
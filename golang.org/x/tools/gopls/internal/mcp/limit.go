@@ -0,0 +1,62 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mcp
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxRequestSize is the default limit on the size in bytes of
+// the body of an HTTP request to the MCP server, or of a message to
+// the MCP server over stdio.
+const DefaultMaxRequestSize = 4 << 20
+
+// limitRequestSize returns a handler that rejects requests whose body
+// exceeds limit bytes before passing them on to h.
+//
+// Requests that declare a larger Content-Length are answered with
+// status 413 (Request Entity Too Large) without reading their body.
+// Otherwise, reading more than limit bytes of the body fails, so that a
+// client that does not declare the length of its message (or lies
+// about it) cannot make the server buffer an arbitrarily large one.
+func limitRequestSize(h http.Handler, limit int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > limit {
+			http.Error(w, fmt.Sprintf("request body of %d bytes exceeds the limit of %d bytes", r.ContentLength, limit), http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+		h.ServeHTTP(w, r)
+	})
+}
+
+// A lineLimitReader is an io.Reader that fails once a line read from
+// the underlying reader exceeds limit bytes, not counting the newline.
+//
+// The stdio transport of MCP sends each message on a line of its own,
+// so this keeps a client from making the server buffer an arbitrarily
+// large message.
+type lineLimitReader struct {
+	r     io.Reader
+	limit int64
+	n     int64 // length of the current line so far
+}
+
+func (r *lineLimitReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	for i, b := range p[:n] {
+		if b == '\n' {
+			r.n = 0
+			continue
+		}
+		r.n++
+		if r.n > r.limit {
+			return i, fmt.Errorf("message exceeds the limit of %d bytes", r.limit)
+		}
+	}
+	return n, err
+}
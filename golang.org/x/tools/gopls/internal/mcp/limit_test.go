@@ -0,0 +1,91 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mcp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLimitRequestSize(t *testing.T) {
+	// read reads the whole body, as the MCP handlers do.
+	read := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	const limit = 10
+	tests := []struct {
+		body          string
+		contentLength int64 // -1 for unknown
+		want          int   // status code
+	}{
+		{"small", 5, http.StatusOK},
+		{"exactly 10", 10, http.StatusOK},
+		{"more than ten", 13, http.StatusRequestEntityTooLarge},
+		{"small", -1, http.StatusOK},
+		{"more than ten", -1, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(test.body))
+		req.ContentLength = test.contentLength
+		rec := httptest.NewRecorder()
+		limitRequestSize(read, limit).ServeHTTP(rec, req)
+		if rec.Code != test.want {
+			t.Errorf("body %q with Content-Length %d: got status %d, want %d", test.body, test.contentLength, rec.Code, test.want)
+		}
+	}
+}
+
+func TestStdioRequestSize(t *testing.T) {
+	const (
+		small = `{"jsonrpc":"2.0","id":1,"method":"ping"}`
+		large = `{"jsonrpc":"2.0","id":2,"method":"ping","params":{"pad":"` + "0123456789" + `"}}`
+	)
+	limit := int64(len(small))
+	conn, err := stdioTransport(io.NopCloser(strings.NewReader(small+"\n"+large+"\n")), io.Discard, limit).Connect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.Read(context.Background()); err != nil {
+		t.Errorf("reading message of %d bytes: %v", len(small), err)
+	}
+	if msg, err := conn.Read(context.Background()); err == nil {
+		t.Errorf("reading message of %d bytes: got %v, want error", len(large), msg)
+	}
+}
+
+// FuzzStdioMessages checks that decoding arbitrary input to the
+// stdio transport does not panic, and fails at the end of the input
+// at the latest.
+func FuzzStdioMessages(f *testing.F) {
+	f.Add(`{"jsonrpc":"2.0","id":1,"method":"ping"}` + "\n")
+	f.Add(`[{"jsonrpc":"2.0","id":1,"method":"ping"},{"jsonrpc":"2.0","method":"notifications/initialized"}]` + "\n")
+	f.Add(`{"jsonrpc":"2.0","id":1,"result":{}}` + "\r\n" + `{"jsonrpc":"2.0","id":2,"error":{"code":-32600,"message":"x"}}`)
+	f.Add(`{"jsonrpc":"2.0","id":1,` + "\n" + `"method":"ping"}`)
+	f.Add("[]\n{}\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		const limit = 64
+		conn, err := stdioTransport(io.NopCloser(strings.NewReader(input)), io.Discard, limit).Connect(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		for range len(input) + 1 {
+			if _, err := conn.Read(context.Background()); err != nil {
+				return
+			}
+		}
+		t.Errorf("read more than %d messages from %d bytes", len(input)+1, len(input))
+	})
+}
@@ -21,8 +21,10 @@ import (
 	"golang.org/x/tools/gopls/internal/cache"
 	"golang.org/x/tools/gopls/internal/cache/metadata"
 	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/label"
 	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/gopls/internal/settings"
+	"golang.org/x/tools/internal/event"
 	"golang.org/x/tools/internal/moremaps"
 )
 
@@ -31,8 +33,9 @@ var Instructions string
 
 // A handler implements various MCP tools for an LSP session.
 type handler struct {
-	session   *cache.Session
-	lspServer protocol.Server
+	session     *cache.Session
+	lspServer   protocol.Server
+	allowWrites bool // whether tools may modify files; see [settings.InternalOptions.MCPAllowWrites]
 }
 
 // Sessions is the interface used to access gopls sessions.
@@ -52,7 +55,12 @@ type Sessions interface {
 // subsequently whenever the MCP client signals a change to the workspace roots.
 // It is passed the list roots result returned by the MCP client, or an error
 // if the roots could not be retrieved. rootsHandler may be called concurrently.
-func Serve(ctx context.Context, address string, sessions Sessions, isDaemon bool, rootsHandler func(*mcp.ListRootsResult, error)) error {
+//
+// Browser requests are accepted only from local origins and from
+// allowedOrigins; see [checkOrigin]. Requests whose body exceeds
+// maxRequestSize bytes (or [DefaultMaxRequestSize], if it is not
+// positive) are rejected; see [limitRequestSize].
+func Serve(ctx context.Context, address string, allowedOrigins []string, maxRequestSize int64, sessions Sessions, isDaemon bool, rootsHandler func(*mcp.ListRootsResult, error)) error {
 	if strings.HasPrefix(address, ":") {
 		return fmt.Errorf("address %s implicitly binds all network interfaces; please use an explicit host such as 0.0.0.0 (all interfaces) or localhost (safer)", address)
 	}
@@ -73,8 +81,12 @@ func Serve(ctx context.Context, address string, sessions Sessions, isDaemon bool
 		defer log.Printf("Gopls MCP %s: exiting", kind)
 	}
 
+	if maxRequestSize <= 0 {
+		maxRequestSize = DefaultMaxRequestSize
+	}
+	handler := HTTPHandler(sessions, isDaemon, rootsHandler)
 	svr := http.Server{
-		Handler: HTTPHandler(sessions, isDaemon, rootsHandler),
+		Handler: checkOrigin(limitRequestSize(handler, maxRequestSize), allowedOrigins),
 		BaseContext: func(net.Listener) context.Context {
 			return ctx
 		},
@@ -89,19 +101,43 @@ func Serve(ctx context.Context, address string, sessions Sessions, isDaemon bool
 }
 
 // StartStdIO starts an MCP server over stdio.
-func StartStdIO(ctx context.Context, session *cache.Session, server protocol.Server, rpcLog io.Writer, rootsHandler func(*mcp.ListRootsResult, error)) error {
+//
+// Messages that exceed maxRequestSize bytes (or [DefaultMaxRequestSize],
+// if it is not positive) end the session; see [lineLimitReader].
+func StartStdIO(ctx context.Context, session *cache.Session, server protocol.Server, rpcLog io.Writer, maxRequestSize int64, rootsHandler func(*mcp.ListRootsResult, error)) error {
+	if maxRequestSize <= 0 {
+		maxRequestSize = DefaultMaxRequestSize
+	}
 	s := NewServer(session, server, rootsHandler)
+	var transport mcp.Transport = stdioTransport(os.Stdin, os.Stdout, maxRequestSize)
 	if rpcLog != nil {
-		return s.Run(ctx, &mcp.LoggingTransport{
-			Transport: &mcp.StdioTransport{},
+		transport = &mcp.LoggingTransport{
+			Transport: transport,
 			Writer:    rpcLog,
-		})
-	} else {
-		return s.Run(ctx, &mcp.StdioTransport{})
+		}
 	}
+	return s.Run(ctx, transport)
+}
 
+// stdioTransport returns the transport of an MCP server that reads
+// messages of at most maxRequestSize bytes from r and writes to w,
+// using newline-delimited JSON. Like [mcp.StdioTransport], it closes r
+// but not w.
+func stdioTransport(r io.ReadCloser, w io.Writer, maxRequestSize int64) mcp.Transport {
+	return &mcp.IOTransport{
+		Reader: struct {
+			io.Reader
+			io.Closer
+		}{&lineLimitReader{r: r, limit: maxRequestSize}, r},
+		Writer: nopCloser{w},
+	}
 }
 
+// nopCloser is an io.WriteCloser with a trivial Close method.
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
 func HTTPHandler(sessions Sessions, isDaemon bool, rootsHandler func(*mcp.ListRootsResult, error)) http.Handler {
 	var (
 		mu          sync.Mutex                         // lock for mcpHandlers.
@@ -167,12 +203,26 @@ func HTTPHandler(sessions Sessions, isDaemon bool, rootsHandler func(*mcp.ListRo
 	return mux
 }
 
+// schemaCache holds the JSON schemas of the input and output types of
+// the tools, inferred by reflection and then resolved. A server is
+// created for each MCP session, so sharing the cache spares each new
+// server the cost of inferring and resolving them again.
+var schemaCache = mcp.NewSchemaCache()
+
+// emptyObjectSchema is the input schema of tools without arguments.
+// It is a variable, so that the schema cache, which records provided
+// schemas by address, resolves it only once.
+var emptyObjectSchema = &jsonschema.Schema{
+	Type:       "object",
+	Properties: map[string]*jsonschema.Schema{},
+}
+
 func NewServer(session *cache.Session, lspServer protocol.Server, rootsHandler func(*mcp.ListRootsResult, error)) *mcp.Server {
 	h := handler{
 		session:   session,
 		lspServer: lspServer,
 	}
-	opts := &mcp.ServerOptions{}
+	opts := &mcp.ServerOptions{SchemaCache: schemaCache}
 	mcpServer := mcp.NewServer(&mcp.Implementation{Name: "gopls", Version: "v1.0.0"}, opts)
 
 	defaultTools := []string{
@@ -181,6 +231,7 @@ func NewServer(session *cache.Session, lspServer protocol.Server, rootsHandler f
 		"go_diagnostics",
 		"go_rename_symbol",
 		"go_symbol_references",
+		"go_symbol_docs",
 		"go_search",
 		"go_file_context",
 		"go_vulncheck"}
@@ -200,12 +251,19 @@ func NewServer(session *cache.Session, lspServer protocol.Server, rootsHandler f
 			// The symbolic variant seems to be easier to get right, albeit less
 			// powerful.
 			"go_references",
+			// The rename tool also requires a location, and may modify files.
+			// go_rename_symbol only reports edits.
+			"go_rename",
+			// The file summary tool is an alternative to reading a file,
+			// for agents with a small context window.
+			"go_file_summary",
 		}...)
 	var toolConfig map[string]bool // non-default settings
 	// For testing, poke through to the gopls server to access its options,
 	// and enable some of the disabled tools.
 	if hasOpts, ok := lspServer.(interface{ Options() *settings.Options }); ok {
 		toolConfig = hasOpts.Options().MCPTools
+		h.allowWrites = hasOpts.Options().MCPAllowWrites
 	}
 	var tools []string
 	for _, tool := range defaultTools {
@@ -223,6 +281,12 @@ func NewServer(session *cache.Session, lspServer protocol.Server, rootsHandler f
 		addToolByName(mcpServer, h, tool)
 	}
 
+	// Annotate each request, whether made by the client or sent to
+	// it, with the client of its session, so that operators can tell
+	// which agents are connected and misbehaving.
+	mcpServer.AddReceivingMiddleware(clientMiddleware(false))
+	mcpServer.AddSendingMiddleware(clientMiddleware(true))
+
 	// Subscribe to the roots change.
 	if rootsHandler != nil {
 		mcpServer.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
@@ -269,6 +333,63 @@ func NewServer(session *cache.Session, lspServer protocol.Server, rootsHandler f
 	return mcpServer
 }
 
+// ClientName returns the name and version of the client
+// implementation of an MCP session, as reported by the client
+// when it initialized the session, or "unknown".
+func ClientName(session *mcp.ServerSession) string {
+	return clientName(session.InitializeParams())
+}
+
+func clientName(params *mcp.InitializeParams) string {
+	if params == nil || params.ClientInfo == nil || params.ClientInfo.Name == "" {
+		return "unknown"
+	}
+	if params.ClientInfo.Version == "" {
+		return params.ClientInfo.Name
+	}
+	return params.ClientInfo.Name + " " + params.ClientInfo.Version
+}
+
+// clientMiddleware returns a middleware that records each request in
+// a span labeled with its session and client, and logs the requests
+// that fail. If sending, it applies to the requests and notifications
+// sent by the server to the client, such as roots/list, whose spans
+// are named "mcp.send.<method>"; otherwise it applies to the requests
+// received from the client, whose spans are named "mcp.<method>", and
+// also logs the client of each new session.
+func clientMiddleware(sending bool) mcp.Middleware {
+	prefix, failure := "mcp.", "MCP request %s failed"
+	if sending {
+		prefix, failure = "mcp.send.", "MCP request %s to client failed"
+	}
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			session, ok := req.GetSession().(*mcp.ServerSession)
+			if !ok {
+				return next(ctx, method, req)
+			}
+			// The session records its parameters only once initialized.
+			params := session.InitializeParams()
+			if p, ok := req.GetParams().(*mcp.InitializeParams); ok {
+				params = p
+			}
+			sessionLabel := label.MCPSession.Of(session.ID())
+			clientLabel := label.MCPClient.Of(clientName(params))
+
+			ctx, done := event.Start(ctx, prefix+method, sessionLabel, clientLabel)
+			defer done()
+
+			result, err := next(ctx, method, req)
+			if err != nil {
+				event.Error(ctx, fmt.Sprintf(failure, method), err, sessionLabel, clientLabel)
+			} else if method == "initialize" && !sending {
+				event.Log(ctx, "MCP session initialized", sessionLabel, clientLabel)
+			}
+			return result, err
+		}
+	}
+}
+
 func addToolByName(mcpServer *mcp.Server, h handler, name string) {
 	switch name {
 	case "go_context":
@@ -301,6 +422,18 @@ performed.
 			Name:        "go_file_metadata",
 			Description: "Provides metadata about the Go package containing the file",
 		}, h.fileMetadataHandler)
+	case "go_file_summary":
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name: "go_file_summary",
+			Description: `Summarizes the structure of a Go file
+
+Given the absolute path of a Go file, go_file_summary returns a condensed
+summary of it, instead of its full text: its package and imports, the
+names of the fields and methods of its types, the signatures of its
+functions, and the first line of each doc comment. The summary is sized
+to fit within "max_tokens" tokens (default 1000); if the file is too
+large, doc comments and then trailing declarations are omitted.`,
+		}, h.fileSummaryHandler)
 	case "go_package_api":
 		mcp.AddTool(mcpServer, &mcp.Tool{
 			Name:        "go_package_api",
@@ -311,6 +444,16 @@ performed.
 			Name:        "go_references",
 			Description: "Provide the locations of references to a given object",
 		}, h.referencesHandler)
+	case "go_rename":
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name: "go_rename",
+			Description: `Renames the Go symbol at a location
+
+Given the location of an identifier, a new name, and "dry_run": true, go_rename
+returns the edits necessary to rename the symbol across the Go workspace as a
+unified diff, for review. Without "dry_run", it also applies the edits to the
+files, if the server permits it to modify files; otherwise it fails.`,
+		}, h.renameHandler)
 	case "go_rename_symbol":
 		mcp.AddTool(mcpServer, &mcp.Tool{
 			Name: "go_rename_symbol",
@@ -332,6 +475,22 @@ Go symbols 'Foo', 'fooBar', 'futils.Oboe', 'github.com/foo/bar.Baz'.
 Results are limited to 100 symbols.
 `,
 		}, h.searchHandler)
+	case "go_symbol_docs":
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name: "go_symbol_docs",
+			Description: `Provides the documentation of a Go symbol.
+
+Given a symbol qualified by its package path, go_symbol_docs returns the
+symbol's signature, its doc comment rendered as Markdown, the location of
+its declaration, and any examples for it found in the package's tests.
+
+For example, given arguments {"symbol": "net/http.Get"}, go_symbol_docs
+describes the Get function of the net/http package, and given
+{"symbol": "net/http.Client.Do"}, it describes the Do method of the
+http.Client type. The package must be known to the workspace, either as a
+workspace package or as one of its dependencies.
+`,
+		}, h.symbolDocsHandler)
 	case "go_symbol_references":
 		mcp.AddTool(mcpServer, &mcp.Tool{
 			Name: "go_symbol_references",
@@ -355,10 +514,7 @@ does the same for a symbol in the imported package "lib".
 		mcp.AddTool(mcpServer, &mcp.Tool{
 			Name:        "go_workspace",
 			Description: "Summarize the Go programming language workspace",
-			InputSchema: &jsonschema.Schema{
-				Type:       "object",
-				Properties: map[string]*jsonschema.Schema{},
-			},
+			InputSchema: emptyObjectSchema,
 		}, h.workspaceHandler)
 	case "go_vulncheck":
 		mcp.AddTool(mcpServer, &mcp.Tool{
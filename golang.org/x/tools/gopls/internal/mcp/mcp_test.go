@@ -8,6 +8,8 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,6 +17,10 @@ import (
 	"golang.org/x/tools/gopls/internal/cache"
 	internalmcp "golang.org/x/tools/gopls/internal/mcp"
 	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/internal/event"
+	"golang.org/x/tools/internal/event/core"
+	"golang.org/x/tools/internal/event/keys"
+	"golang.org/x/tools/internal/event/label"
 )
 
 type emptySessions struct {
@@ -39,7 +45,7 @@ func TestContextCancellation(t *testing.T) {
 
 	res := make(chan error)
 	go func() {
-		res <- internalmcp.Serve(ctx, "localhost:0", emptySessions{}, true, nil)
+		res <- internalmcp.Serve(ctx, "localhost:0", nil, 0, emptySessions{}, true, nil)
 	}()
 
 	time.Sleep(1 * time.Second)
@@ -154,3 +160,83 @@ func TestClientRootChange(t *testing.T) {
 		t.Fatal("Timeout waiting for updated roots.")
 	}
 }
+
+func TestClientName(t *testing.T) {
+	server := internalmcp.NewServer(nil, nil, nil)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-agent", Version: "v1.2.3"}, nil)
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+
+	ctx := t.Context()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serverSession.Close()
+
+	if got, want := internalmcp.ClientName(serverSession), "unknown"; got != want {
+		t.Errorf("before initialization, ClientName = %q, want %q", got, want)
+	}
+
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clientSession.Close()
+
+	if got, want := internalmcp.ClientName(serverSession), "test-agent v1.2.3"; got != want {
+		t.Errorf("ClientName = %q, want %q", got, want)
+	}
+}
+
+func TestClientMiddleware(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		spans []string
+	)
+	event.SetExporter(func(ctx context.Context, ev core.Event, lm label.Map) context.Context {
+		if event.IsStart(ev) {
+			mu.Lock()
+			spans = append(spans, keys.Start.Get(lm))
+			mu.Unlock()
+		}
+		return ctx
+	})
+	defer event.SetExporter(nil)
+
+	server := internalmcp.NewServer(nil, nil, nil)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-agent"}, nil)
+	client.AddRoots(&mcp.Root{Name: "root", URI: "file:///path/to/root"})
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+
+	ctx := t.Context()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serverSession.Close()
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clientSession.Close()
+
+	if _, err := serverSession.ListRoots(ctx, &mcp.ListRootsParams{}); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, want := range []string{"mcp.initialize", "mcp.send.roots/list"} {
+		if !slices.Contains(spans, want) {
+			t.Errorf("no %q span among %q", want, spans)
+		}
+	}
+}
+
+// BenchmarkNewServer measures the cost of creating a server for an MCP
+// session, which registers the default tools.
+func BenchmarkNewServer(b *testing.B) {
+	for b.Loop() {
+		internalmcp.NewServer(nil, nil, nil)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mcp
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// checkOrigin returns a handler that applies a cross-origin policy to
+// requests before passing them on to h.
+//
+// Requests without an Origin header, such as those of MCP clients
+// that are not web pages, are always accepted. Otherwise, by default
+// only pages served from the local machine (localhost or a loopback
+// address) may connect, since any other web page visited by the user
+// could otherwise drive the MCP server through the user's browser.
+// Each element of allowedOrigins (e.g. "https://example.com") permits
+// an additional origin; the special value "*" permits all origins.
+//
+// Accepted cross-origin requests receive the CORS response headers
+// needed by browsers, and preflight (OPTIONS) requests are answered
+// directly.
+func checkOrigin(h http.Handler, allowedOrigins []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		if !isLocalOrigin(origin) && !slices.Contains(allowedOrigins, "*") && !slices.Contains(allowedOrigins, origin) {
+			http.Error(w, "origin not allowed: "+origin, http.StatusForbidden)
+			return
+		}
+
+		header := w.Header()
+		header.Set("Access-Control-Allow-Origin", origin)
+		header.Add("Vary", "Origin")
+		header.Set("Access-Control-Expose-Headers", "Mcp-Session-Id")
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			header.Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+			if reqHeaders := r.Header.Get("Access-Control-Request-Headers"); reqHeaders != "" {
+				header.Set("Access-Control-Allow-Headers", reqHeaders)
+			}
+			header.Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// isLocalOrigin reports whether the origin (a URL without a path)
+// denotes a page served from the local machine.
+func isLocalOrigin(origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
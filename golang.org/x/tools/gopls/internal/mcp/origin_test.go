@@ -0,0 +1,58 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckOrigin(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		method  string
+		origin  string
+		allowed []string
+		want    int // status code
+	}{
+		{"GET", "", nil, http.StatusOK}, // not a browser
+		{"GET", "http://localhost:8080", nil, http.StatusOK},
+		{"GET", "http://127.0.0.1", nil, http.StatusOK},
+		{"GET", "http://[::1]:3000", nil, http.StatusOK},
+		{"GET", "https://example.com", nil, http.StatusForbidden},
+		{"GET", "https://localhost.example.com", nil, http.StatusForbidden},
+		{"GET", "null", nil, http.StatusForbidden},
+		{"GET", "https://example.com", []string{"https://example.com"}, http.StatusOK},
+		{"GET", "https://example.org", []string{"https://example.com"}, http.StatusForbidden},
+		{"GET", "https://example.org", []string{"*"}, http.StatusOK},
+		{"OPTIONS", "http://localhost", nil, http.StatusNoContent},
+		{"OPTIONS", "https://example.com", nil, http.StatusForbidden},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, "/", nil)
+		if test.origin != "" {
+			req.Header.Set("Origin", test.origin)
+		}
+		if test.method == "OPTIONS" {
+			req.Header.Set("Access-Control-Request-Method", "POST")
+			req.Header.Set("Access-Control-Request-Headers", "Content-Type")
+		}
+		rec := httptest.NewRecorder()
+		checkOrigin(ok, test.allowed).ServeHTTP(rec, req)
+		if rec.Code != test.want {
+			t.Errorf("%s from origin %q (allowed %q): got status %d, want %d", test.method, test.origin, test.allowed, rec.Code, test.want)
+			continue
+		}
+		if test.origin != "" && rec.Code != http.StatusForbidden {
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != test.origin {
+				t.Errorf("%s from origin %q: Access-Control-Allow-Origin = %q, want %q", test.method, test.origin, got, test.origin)
+			}
+		}
+	}
+}
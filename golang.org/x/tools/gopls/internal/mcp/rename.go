@@ -0,0 +1,144 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/golang"
+	"golang.org/x/tools/gopls/internal/protocol"
+)
+
+type renameParams struct {
+	Location protocol.Location `json:"location"`
+	NewName  string            `json:"new_name" jsonschema:"the new name for the symbol"`
+	DryRun   bool              `json:"dry_run,omitempty" jsonschema:"report the edits without applying them"`
+}
+
+func (h *handler) renameHandler(ctx context.Context, req *mcp.CallToolRequest, params renameParams) (*mcp.CallToolResult, any, error) {
+	countGoRenameMCP.Inc()
+	if !params.DryRun && !h.allowWrites {
+		return nil, nil, fmt.Errorf("the server does not permit tools to modify files; use \"dry_run\": true to report the edits")
+	}
+	fh, snapshot, release, err := h.session.FileOf(ctx, params.Location.URI)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	if snapshot.FileKind(fh) != file.Go {
+		return nil, nil, fmt.Errorf("can't rename symbols in non-Go files")
+	}
+	pos := params.Location.Range.Start
+	changes, err := golang.Rename(ctx, snapshot, fh, protocol.Range{Start: pos, End: pos}, params.NewName)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Report the edits in a deterministic order. Only edits of file
+	// contents may be reordered: the order of file operations matters.
+	sorted := slices.Clone(changes)
+	if !slices.ContainsFunc(changes, func(change protocol.DocumentChange) bool { return change.TextDocumentEdit == nil }) {
+		slices.SortStableFunc(sorted, func(x, y protocol.DocumentChange) int {
+			return strings.Compare(string(x.TextDocumentEdit.TextDocument.URI), string(y.TextDocumentEdit.TextDocument.URI))
+		})
+	}
+	var builder strings.Builder
+	if params.DryRun {
+		if err := formatRenameChanges(ctx, snapshot, &builder, sorted); err != nil {
+			return nil, nil, err
+		}
+		return textResult(builder.String()), nil, nil
+	}
+	builder.WriteString("The following changes were applied to rename the symbol:\n")
+	if err := writeUnifiedDiff(ctx, snapshot, &builder, sorted); err != nil {
+		return nil, nil, err
+	}
+	if err := applyDocumentChanges(ctx, snapshot, changes); err != nil {
+		return nil, nil, err
+	}
+	return textResult(builder.String()), nil, nil
+}
+
+// applyDocumentChanges applies the changes to the files on disk.
+//
+// It fails without applying any change if one of the edited files is
+// open in an editor with unsaved edits, as writing it would discard
+// the edits or be overwritten by them.
+func applyDocumentChanges(ctx context.Context, snapshot *cache.Snapshot, changes []protocol.DocumentChange) error {
+	// Compute the new contents of the edited files before writing any of them.
+	newContents := make(map[protocol.DocumentURI][]byte)
+	for _, change := range changes {
+		if change.TextDocumentEdit == nil {
+			continue
+		}
+		uri := change.TextDocumentEdit.TextDocument.URI
+		fh, err := snapshot.ReadFile(ctx, uri)
+		if err != nil {
+			return err
+		}
+		if !fh.SameContentsOnDisk() {
+			return fmt.Errorf("%s has unsaved edits in the editor", filepath.ToSlash(uri.Path()))
+		}
+		content, err := fh.Content()
+		if err != nil {
+			return err
+		}
+		if prev, ok := newContents[uri]; ok {
+			content = prev // a file may be edited more than once
+		}
+		mapper := protocol.NewMapper(uri, content)
+		newContent, _, err := protocol.ApplyEdits(mapper, protocol.AsTextEdits(change.TextDocumentEdit.Edits))
+		if err != nil {
+			return err
+		}
+		newContents[uri] = newContent
+	}
+
+	for _, change := range changes {
+		switch {
+		case change.TextDocumentEdit != nil:
+			uri := change.TextDocumentEdit.TextDocument.URI
+			content, ok := newContents[uri]
+			if !ok {
+				continue // already written
+			}
+			if err := writeFile(uri.Path(), content); err != nil {
+				return err
+			}
+			delete(newContents, uri)
+		case change.CreateFile != nil:
+			if err := writeFile(change.CreateFile.URI.Path(), nil); err != nil {
+				return err
+			}
+		case change.RenameFile != nil:
+			if err := os.Rename(change.RenameFile.OldURI.Path(), change.RenameFile.NewURI.Path()); err != nil {
+				return err
+			}
+		case change.DeleteFile != nil:
+			if err := os.Remove(change.DeleteFile.URI.Path()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeFile writes content to the named file, preserving its
+// permissions if it exists.
+func writeFile(filename string, content []byte) error {
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(filename); err == nil {
+		perm = info.Mode().Perm()
+	}
+	return os.WriteFile(filename, content, perm)
+}
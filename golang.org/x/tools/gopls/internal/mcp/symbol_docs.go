@@ -0,0 +1,195 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/format"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/metadata"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/golang"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/util/tokeninternal"
+)
+
+// symbolDocsParams defines the parameters for the "go_symbol_docs" tool.
+type symbolDocsParams struct {
+	Symbol string `json:"symbol" jsonschema:"the symbol qualified by its package path, such as 'net/http.Get' or 'net/http.Client.Do'"`
+}
+
+// symbolDocsHandler is the handler for the "go_symbol_docs" tool. It
+// describes the signature, documentation, declaration and examples of
+// a package-level symbol, or of a field or method of a package-level type.
+func (h *handler) symbolDocsHandler(ctx context.Context, req *mcp.CallToolRequest, params symbolDocsParams) (*mcp.CallToolResult, any, error) {
+	countGoSymbolDocsMCP.Inc()
+	snapshot, release, err := h.snapshot()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	md, err := snapshot.LoadMetadataGraph(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	mp, names, err := splitQualifiedSymbol(md, params.Symbol)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkgs, err := snapshot.TypeCheck(ctx, mp.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkg := pkgs[0]
+
+	obj := pkg.Types().Scope().Lookup(names[0])
+	if obj == nil {
+		return nil, nil, fmt.Errorf("package %s has no member %q", mp.PkgPath, names[0])
+	}
+	if len(names) == 2 {
+		member, _, _ := types.LookupFieldOrMethod(obj.Type(), true, pkg.Types(), names[1])
+		if member == nil {
+			return nil, nil, fmt.Errorf("%s.%s has no field or method %q", mp.PkgPath, names[0], names[1])
+		}
+		obj = member
+	}
+
+	var b strings.Builder
+	qual := types.RelativeTo(pkg.Types())
+	fmt.Fprintf(&b, "Signature:\n```go\n%s\n```\n\n", types.ObjectString(obj, qual))
+
+	if loc, err := golang.ObjectLocation(ctx, pkg.FileSet(), snapshot, obj); err == nil {
+		fmt.Fprintf(&b, "Declared in %s:%d\n\n", loc.URI.Path(), loc.Range.Start.Line+1)
+	}
+
+	if comment, err := golang.HoverDocForObject(ctx, snapshot, pkg.FileSet(), obj); err == nil && comment != nil {
+		fmt.Fprintf(&b, "Documentation:\n%s\n", golang.DocCommentToMarkdown(comment.Text(), snapshot.Options()))
+	} else {
+		fmt.Fprintf(&b, "The symbol is undocumented.\n\n")
+	}
+
+	examples, err := symbolExamples(ctx, snapshot, md, mp.PkgPath, strings.Join(names, "_"))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, ex := range examples {
+		b.WriteString(ex)
+	}
+	return textResult(b.String()), nil, nil
+}
+
+// splitQualifiedSymbol splits a symbol of the form "pkgpath.Name" or
+// "pkgpath.Type.Member" into the metadata of the named package, and the
+// one or two names that follow its path.
+//
+// Since package paths may themselves contain dots, it prefers the
+// longest prefix of the symbol that names a known package.
+func splitQualifiedSymbol(md *metadata.Graph, symbol string) (*metadata.Package, []string, error) {
+	slash := strings.LastIndex(symbol, "/")
+	for i := len(symbol) - 1; i > slash; i-- {
+		if symbol[i] != '.' {
+			continue
+		}
+		mps := md.ForPackagePath[metadata.PackagePath(symbol[:i])]
+		if len(mps) == 0 {
+			continue
+		}
+		names := strings.Split(symbol[i+1:], ".")
+		if len(names) > 2 || names[0] == "" || names[len(names)-1] == "" {
+			return nil, nil, fmt.Errorf("invalid symbol %q: expected pkgpath.Name or pkgpath.Type.Member", symbol)
+		}
+		return mps[0], names, nil // first is best
+	}
+	return nil, nil, fmt.Errorf("invalid symbol %q: no known package is a prefix of the symbol (expected pkgpath.Name or pkgpath.Type.Member)", symbol)
+}
+
+// symbolExamples returns the formatted examples for the named symbol
+// (as it appears in Example function names, e.g. "Type_Method") found
+// in the test files of the given package.
+//
+// Test files are only known for workspace packages, so this returns no
+// examples for dependencies.
+func symbolExamples(ctx context.Context, snapshot *cache.Snapshot, md *metadata.Graph, pkgPath metadata.PackagePath, name string) ([]string, error) {
+	// Test files may belong to several package variants.
+	testFiles := make(map[protocol.DocumentURI]bool)
+	for _, mp := range md.Packages {
+		if mp.ForTest != pkgPath {
+			continue
+		}
+		for _, uri := range mp.CompiledGoFiles {
+			if strings.HasSuffix(uri.Path(), "_test.go") {
+				testFiles[uri] = true
+			}
+		}
+	}
+
+	var result []string
+	for _, uri := range slices.Sorted(maps.Keys(testFiles)) {
+		fh, err := snapshot.ReadFile(ctx, uri)
+		if err != nil {
+			return nil, err // context cancelled
+		}
+		pgf, err := snapshot.ParseGo(ctx, fh, parsego.Full)
+		if err != nil {
+			return nil, err
+		}
+		fset := tokeninternal.FileSetFor(pgf.Tok)
+		for _, ex := range doc.Examples(pgf.File) {
+			suffix, ok := exampleSuffix(ex.Name, name)
+			if !ok {
+				continue
+			}
+			var code bytes.Buffer
+			var node any = ex.Code
+			if block, ok := ex.Code.(*ast.BlockStmt); ok {
+				node = block.List
+			}
+			if err := format.Node(&code, fset, node); err != nil {
+				continue // ignore malformed examples
+			}
+			var b strings.Builder
+			b.WriteString("Example")
+			if suffix != "" {
+				fmt.Fprintf(&b, " (%s)", suffix)
+			}
+			fmt.Fprintf(&b, ":\n```go\n%s\n```\n", code.String())
+			if ex.Output != "" {
+				fmt.Fprintf(&b, "Output:\n```\n%s```\n", ex.Output)
+			}
+			b.WriteString("\n")
+			result = append(result, b.String())
+		}
+	}
+	return result, nil
+}
+
+// exampleSuffix reports whether exName, the name of an Example function
+// without its "Example" prefix, is an example for the named symbol, and
+// if so returns its suffix (e.g. "second" in "ExampleT_M_second").
+func exampleSuffix(exName, name string) (string, bool) {
+	if exName == name {
+		return "", true
+	}
+	suffix, ok := strings.CutPrefix(exName, name+"_")
+	if !ok || suffix == "" {
+		return "", false
+	}
+	// As in go/doc, the suffix must start with a lower-case letter.
+	r, _ := utf8.DecodeRuneInString(suffix)
+	return suffix, unicode.IsLower(r)
+}
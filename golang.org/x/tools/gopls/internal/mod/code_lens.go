@@ -22,6 +22,7 @@ import (
 func CodeLensSources() map[settings.CodeLensSource]cache.CodeLensSourceFunc {
 	return map[settings.CodeLensSource]cache.CodeLensSourceFunc{
 		settings.CodeLensUpgradeDependency: upgradeLenses,        // commands: CheckUpgrades, UpgradeDependency
+		settings.CodeLensImportGraph:       importGraphLens,      // commands: ImportGraph
 		settings.CodeLensTidy:              tidyLens,             // commands: Tidy
 		settings.CodeLensVendor:            vendorLens,           // commands: Vendor
 		settings.CodeLensVulncheck:         vulncheckLenses,      // commands: Vulncheck
@@ -95,6 +96,19 @@ func tidyLens(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle) ([]
 	}}, nil
 }
 
+func importGraphLens(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle) ([]protocol.CodeLens, error) {
+	pm, err := snapshot.ParseMod(ctx, fh)
+	if err != nil || pm.File == nil {
+		return nil, err
+	}
+	rng, err := moduleStmtRange(fh, pm)
+	if err != nil {
+		return nil, err
+	}
+	cmd := command.NewImportGraphCommand("Show import graph", command.ImportGraphArgs{URI: fh.URI(), Show: true})
+	return []protocol.CodeLens{{Range: rng, Command: cmd}}, nil
+}
+
 func vendorLens(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle) ([]protocol.CodeLens, error) {
 	pm, err := snapshot.ParseMod(ctx, fh)
 	if err != nil || pm.File == nil {
@@ -26,8 +26,11 @@ import (
 const (
 	AddDependency           Command = "gopls.add_dependency"
 	AddImport               Command = "gopls.add_import"
+	AddPackageDoc           Command = "gopls.add_package_doc"
 	AddTelemetryCounters    Command = "gopls.add_telemetry_counters"
 	AddTest                 Command = "gopls.add_test"
+	AffectedTests           Command = "gopls.affected_tests"
+	AnalysisStats           Command = "gopls.analysis_stats"
 	ApplyFix                Command = "gopls.apply_fix"
 	Assembly                Command = "gopls.assembly"
 	ChangeSignature         Command = "gopls.change_signature"
@@ -42,7 +45,9 @@ const (
 	GCDetails               Command = "gopls.gc_details"
 	Generate                Command = "gopls.generate"
 	GoGetPackage            Command = "gopls.go_get_package"
+	HiddenDiagnostics       Command = "gopls.hidden_diagnostics"
 	ImplementInterface      Command = "gopls.implement_interface"
+	ImportGraph             Command = "gopls.import_graph"
 	ListImports             Command = "gopls.list_imports"
 	ListKnownPackages       Command = "gopls.list_known_packages"
 	LSP                     Command = "gopls.lsp"
@@ -51,9 +56,11 @@ const (
 	ModifyTags              Command = "gopls.modify_tags"
 	Modules                 Command = "gopls.modules"
 	MoveDeclaration         Command = "gopls.move_declaration"
+	MoveSymbol              Command = "gopls.move_symbol"
 	MoveType                Command = "gopls.move_type"
 	PackageSymbols          Command = "gopls.package_symbols"
 	Packages                Command = "gopls.packages"
+	PreviewEdit             Command = "gopls.preview_edit"
 	RegenerateCgo           Command = "gopls.regenerate_cgo"
 	RemoveDependency        Command = "gopls.remove_dependency"
 	ResetGoModDiagnostics   Command = "gopls.reset_go_mod_diagnostics"
@@ -65,6 +72,7 @@ const (
 	StartDebugging          Command = "gopls.start_debugging"
 	StartProfile            Command = "gopls.start_profile"
 	StopProfile             Command = "gopls.stop_profile"
+	StructLayout            Command = "gopls.struct_layout"
 	Tidy                    Command = "gopls.tidy"
 	UpdateGoSum             Command = "gopls.update_go_sum"
 	UpgradeDependency       Command = "gopls.upgrade_dependency"
@@ -77,8 +85,11 @@ const (
 var Commands = []Command{
 	AddDependency,
 	AddImport,
+	AddPackageDoc,
 	AddTelemetryCounters,
 	AddTest,
+	AffectedTests,
+	AnalysisStats,
 	ApplyFix,
 	Assembly,
 	ChangeSignature,
@@ -93,7 +104,9 @@ var Commands = []Command{
 	GCDetails,
 	Generate,
 	GoGetPackage,
+	HiddenDiagnostics,
 	ImplementInterface,
+	ImportGraph,
 	ListImports,
 	ListKnownPackages,
 	LSP,
@@ -102,9 +115,11 @@ var Commands = []Command{
 	ModifyTags,
 	Modules,
 	MoveDeclaration,
+	MoveSymbol,
 	MoveType,
 	PackageSymbols,
 	Packages,
+	PreviewEdit,
 	RegenerateCgo,
 	RemoveDependency,
 	ResetGoModDiagnostics,
@@ -116,6 +131,7 @@ var Commands = []Command{
 	StartDebugging,
 	StartProfile,
 	StopProfile,
+	StructLayout,
 	Tidy,
 	UpdateGoSum,
 	UpgradeDependency,
@@ -139,6 +155,12 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return nil, s.AddImport(ctx, a0)
+	case AddPackageDoc:
+		var a0 protocol.Location
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.AddPackageDoc(ctx, a0)
 	case AddTelemetryCounters:
 		var a0 AddTelemetryCountersArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -151,6 +173,14 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return s.AddTest(ctx, a0)
+	case AffectedTests:
+		var a0 URIArg
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.AffectedTests(ctx, a0)
+	case AnalysisStats:
+		return s.AnalysisStats(ctx)
 	case ApplyFix:
 		var a0 ApplyFixArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -238,12 +268,24 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return nil, s.GoGetPackage(ctx, a0)
+	case HiddenDiagnostics:
+		var a0 URIArg
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.HiddenDiagnostics(ctx, a0)
 	case ImplementInterface:
 		var a0 ImplementInterfaceArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
 			return nil, err
 		}
 		return nil, s.ImplementInterface(ctx, a0, &params.InteractiveParams)
+	case ImportGraph:
+		var a0 ImportGraphArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.ImportGraph(ctx, a0)
 	case ListImports:
 		var a0 URIArg
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -284,6 +326,12 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return nil, s.MoveDeclaration(ctx, a0, &params.InteractiveParams)
+	case MoveSymbol:
+		var a0 MoveSymbolArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.MoveSymbol(ctx, a0)
 	case MoveType:
 		var a0 MoveTypeArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -302,6 +350,12 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return s.Packages(ctx, a0)
+	case PreviewEdit:
+		var a0 PreviewEditArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.PreviewEdit(ctx, a0)
 	case RegenerateCgo:
 		var a0 URIArg
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -365,6 +419,12 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return s.StopProfile(ctx, a0)
+	case StructLayout:
+		var a0 StructLayoutArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.StructLayout(ctx, a0)
 	case Tidy:
 		var a0 URIArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -419,6 +479,14 @@ func NewAddImportCommand(title string, a0 AddImportArgs) *protocol.Command {
 	}
 }
 
+func NewAddPackageDocCommand(title string, a0 protocol.Location) *protocol.Command {
+	return &protocol.Command{
+		Title:     title,
+		Command:   AddPackageDoc.String(),
+		Arguments: MustMarshalArgs(a0),
+	}
+}
+
 func NewAddTelemetryCountersCommand(title string, a0 AddTelemetryCountersArgs) *protocol.Command {
 	return &protocol.Command{
 		Title:     title,
@@ -435,6 +503,22 @@ func NewAddTestCommand(title string, a0 protocol.Location) *protocol.Command {
 	}
 }
 
+func NewAffectedTestsCommand(title string, a0 URIArg) *protocol.Command {
+	return &protocol.Command{
+		Title:     title,
+		Command:   AffectedTests.String(),
+		Arguments: MustMarshalArgs(a0),
+	}
+}
+
+func NewAnalysisStatsCommand(title string) *protocol.Command {
+	return &protocol.Command{
+		Title:     title,
+		Command:   AnalysisStats.String(),
+		Arguments: MustMarshalArgs(),
+	}
+}
+
 func NewApplyFixCommand(title string, a0 ApplyFixArgs) *protocol.Command {
 	return &protocol.Command{
 		Title:     title,
@@ -547,6 +631,14 @@ func NewGoGetPackageCommand(title string, a0 GoGetPackageArgs) *protocol.Command
 	}
 }
 
+func NewHiddenDiagnosticsCommand(title string, a0 URIArg) *protocol.Command {
+	return &protocol.Command{
+		Title:     title,
+		Command:   HiddenDiagnostics.String(),
+		Arguments: MustMarshalArgs(a0),
+	}
+}
+
 func NewImplementInterfaceCommand(title string, a0 ImplementInterfaceArgs) *protocol.Command {
 	return &protocol.Command{
 		Title:     title,
@@ -555,6 +647,14 @@ func NewImplementInterfaceCommand(title string, a0 ImplementInterfaceArgs) *prot
 	}
 }
 
+func NewImportGraphCommand(title string, a0 ImportGraphArgs) *protocol.Command {
+	return &protocol.Command{
+		Title:     title,
+		Command:   ImportGraph.String(),
+		Arguments: MustMarshalArgs(a0),
+	}
+}
+
 func NewListImportsCommand(title string, a0 URIArg) *protocol.Command {
 	return &protocol.Command{
 		Title:     title,
@@ -619,6 +719,14 @@ func NewMoveDeclarationCommand(title string, a0 MoveDeclarationArgs) *protocol.C
 	}
 }
 
+func NewMoveSymbolCommand(title string, a0 MoveSymbolArgs) *protocol.Command {
+	return &protocol.Command{
+		Title:     title,
+		Command:   MoveSymbol.String(),
+		Arguments: MustMarshalArgs(a0),
+	}
+}
+
 func NewMoveTypeCommand(title string, a0 MoveTypeArgs) *protocol.Command {
 	return &protocol.Command{
 		Title:     title,
@@ -643,6 +751,18 @@ func NewPackagesCommand(title string, a0 PackagesArgs) *protocol.Command {
 	}
 }
 
+func NewPreviewEditCommand(title string, a0 PreviewEditArgs) (*protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return nil, err
+	}
+	return &protocol.Command{
+		Title:     title,
+		Command:   PreviewEdit.String(),
+		Arguments: args,
+	}, nil
+}
+
 func NewRegenerateCgoCommand(title string, a0 URIArg) *protocol.Command {
 	return &protocol.Command{
 		Title:     title,
@@ -731,6 +851,14 @@ func NewStopProfileCommand(title string, a0 StopProfileArgs) *protocol.Command {
 	}
 }
 
+func NewStructLayoutCommand(title string, a0 StructLayoutArgs) *protocol.Command {
+	return &protocol.Command{
+		Title:     title,
+		Command:   StructLayout.String(),
+		Arguments: MustMarshalArgs(a0),
+	}
+}
+
 func NewTidyCommand(title string, a0 URIArgs) *protocol.Command {
 	return &protocol.Command{
 		Title:     title,
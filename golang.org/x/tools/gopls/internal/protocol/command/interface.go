@@ -183,6 +183,13 @@ type Interface interface {
 	// Used by the code action of the same name.
 	ExtractToNewFile(context.Context, protocol.Location) error
 
+	// AddPackageDoc: Add a doc.go file documenting the package
+	//
+	// Creates a doc.go file whose package doc comment is a skeleton
+	// that lists the package's exported API, grouped by type.
+	// Used by the code action of the same name.
+	AddPackageDoc(context.Context, protocol.Location) error
+
 	// StartDebugging: Start the gopls debug server
 	//
 	// Start the gopls debug server if it isn't running, and return the debug
@@ -246,6 +253,17 @@ type Interface interface {
 	// command.
 	WorkspaceStats(context.Context) (WorkspaceStatsResult, error)
 
+	// AnalysisStats: Fetch analyzer statistics
+	//
+	// Run the enabled analyzers over the workspace packages of each view,
+	// and report, for each analyzer, the number of diagnostics it produced
+	// and its total running time. The running time of results from the
+	// file cache is the time it took to compute them.
+	//
+	// This command is intended for internal use only, by the gopls stats
+	// command.
+	AnalysisStats(context.Context) (AnalysisStatsResult, error)
+
 	// RunGoWorkCommand: Run `go work [args...]`, and apply the resulting go.work
 	// edits to the current go.work file
 	RunGoWorkCommand(context.Context, RunGoWorkArgs) error
@@ -338,7 +356,66 @@ type Interface interface {
 	ImplementInterface(context.Context, ImplementInterfaceArgs, *protocol.InteractiveParams) error
 
 	// MoveDeclaration: Move a declaration to a different file.
+	//
+	// Moves the top-level declaration at the given location, along
+	// with its doc comment and any unexported declarations in the same
+	// file that are used only by it, to the end of another file of the
+	// same package, updating the imports of both files. The
+	// destination file is the answer to the "file" form field.
 	MoveDeclaration(context.Context, MoveDeclarationArgs, *protocol.InteractiveParams) error
+
+	// MoveSymbol: Move a top-level declaration to another file.
+	//
+	// Moves the declaration at the given location, along with its doc
+	// comment and any unexported declarations in the same file that
+	// are used only by it, to the end of another file of the same
+	// package, updating the imports of both files.
+	MoveSymbol(context.Context, MoveSymbolArgs) error
+
+	// ImportGraph: Compute the import graph of the workspace
+	//
+	// Returns the import graph of the workspace packages of the
+	// view containing the given file, rendered as Graphviz DOT or
+	// JSON. Packages participating in import cycles are
+	// highlighted, and each node is sized by the number of lines
+	// of Go source in its package.
+	ImportGraph(context.Context, ImportGraphArgs) (ImportGraphResult, error)
+
+	// StructLayout: Show the memory layout of a struct type
+	//
+	// Reports the size, alignment, and padding of the struct type
+	// declared at the given location, and the offset, size,
+	// alignment, and trailing padding of each of its fields.
+	StructLayout(context.Context, StructLayoutArgs) (StructLayoutResult, error)
+
+	// HiddenDiagnostics: List the diagnostics that gopls does not report for a file
+	//
+	// Computes the diagnostics of the given file that gopls does
+	// not publish, each with the reason it is suppressed: for
+	// example, the file does not belong to a workspace package,
+	// analyzers do not run because no file of its package is open,
+	// or the analysisSkipGenerated setting suppresses the analysis
+	// diagnostics of generated files.
+	HiddenDiagnostics(context.Context, URIArg) (HiddenDiagnosticsResult, error)
+
+	// PreviewEdit: Summarize a workspace edit
+	//
+	// Summarizes the changes of a workspace edit, such as one
+	// returned by a rename or a refactoring code action, without
+	// applying them: the files it touches, the number of lines
+	// added and deleted in each, and a unified diff. Clients that
+	// cannot ask the user to confirm a workspace/applyEdit request
+	// may use it to show a confirmation step before applying an edit.
+	PreviewEdit(context.Context, PreviewEditArgs) (EditPreview, error)
+
+	// AffectedTests: List the tests affected by unsaved changes to a file
+	//
+	// Reports the tests and benchmarks of the package of the given
+	// file that refer, directly or through a call to a function of
+	// the package, to a declaration of the file that has been
+	// changed since it was last saved. Used by the affected_tests
+	// code lens.
+	AffectedTests(context.Context, URIArg) (AffectedTestsResult, error)
 }
 
 type RunTestsArgs struct {
@@ -580,6 +657,19 @@ type WorkspaceStatsResult struct {
 	Views []ViewStats // stats for each view in the session
 }
 
+// AnalysisStatsResult holds statistics about each analyzer, in
+// descending order of running time.
+type AnalysisStatsResult struct {
+	Analyzers []AnalyzerStats
+}
+
+// AnalyzerStats holds statistics about a single analyzer.
+type AnalyzerStats struct {
+	Name        string // name of the analyzer
+	Duration    string // total running time, in time.Duration string form
+	Diagnostics int    // number of diagnostics in the workspace
+}
+
 // FileStats holds information about a set of files.
 type FileStats struct {
 	Total   int // total number of files
@@ -722,6 +812,10 @@ const (
 	// Populate the [TestFile.Tests] field in [Package] returned by the
 	// Packages command.
 	NeedTests PackagesMode = 1 << iota
+
+	// Populate the [Package.GoFiles] field returned by the Packages
+	// command.
+	NeedFiles
 )
 
 // PackagesResult is the result of the Packages command.
@@ -755,6 +849,10 @@ type Package struct {
 	// They are ordered deterministically as determined
 	// by the underlying build system.
 	TestFiles []TestFile
+
+	// GoFiles contains the Go source files of the package, if the
+	// NeedFiles mode was requested.
+	GoFiles []protocol.DocumentURI `json:",omitempty"`
 }
 
 type Module struct {
@@ -923,3 +1021,118 @@ type MoveDeclarationArgs struct {
 	// The location of the declaration to move.
 	Location protocol.Location
 }
+
+// MoveSymbolArgs specifies a "move symbol" refactoring to perform.
+type MoveSymbolArgs struct {
+	// The location of the declaration to move.
+	Location protocol.Location
+	// The file to move the declaration to. It must belong to
+	// the same package as the declaration.
+	Dest protocol.DocumentURI
+}
+
+// ImportGraphArgs holds arguments for the ImportGraph command.
+type ImportGraphArgs struct {
+	// URI is a file (typically go.mod) belonging to the view
+	// whose workspace import graph is requested.
+	URI protocol.DocumentURI
+
+	// Format selects the rendering of the Output field of the
+	// result: "dot" (the default) for Graphviz DOT, or "json".
+	Format string `json:"Format,omitempty"`
+
+	// Show causes the rendered graph to be written to a temporary
+	// file, which the client is then asked to open.
+	Show bool `json:"Show,omitempty"`
+}
+
+// ImportGraphResult is the result of the ImportGraph command.
+type ImportGraphResult struct {
+	// Packages lists the workspace packages, ordered by path.
+	Packages []ImportGraphPackage
+
+	// Cycles holds, for each import cycle, the sorted paths of
+	// the packages that form it.
+	Cycles [][]string `json:"Cycles,omitempty"`
+
+	// Output is the graph rendered in the requested format.
+	Output string
+}
+
+// ImportGraphPackage describes a single node of the import graph.
+type ImportGraphPackage struct {
+	Path    string   // package path
+	Lines   int      // number of lines in the package's Go files
+	Imports []string // sorted paths of the imported workspace packages
+}
+
+// StructLayoutArgs holds arguments for the StructLayout command.
+type StructLayoutArgs struct {
+	// The location of the struct type declaration.
+	Location protocol.Location
+}
+
+// StructLayoutResult describes the memory layout of a struct type.
+type StructLayoutResult struct {
+	Name        string // name of the struct type
+	Size        int64  // size in bytes
+	Align       int64  // alignment in bytes
+	Padding     int64  // total padding in bytes
+	OptimalSize int64  // size with fields reordered for minimal padding
+	Fields      []StructLayoutField
+}
+
+// StructLayoutField describes the layout of a single field of a struct.
+type StructLayoutField struct {
+	Name    string
+	Offset  int64
+	Size    int64
+	Align   int64
+	Padding int64 // padding between this field and the next one (or the end)
+}
+
+// HiddenDiagnosticsResult is the result of the HiddenDiagnostics command.
+type HiddenDiagnosticsResult struct {
+	Diagnostics []HiddenDiagnostic
+}
+
+// HiddenDiagnostic describes a diagnostic that gopls does not publish.
+type HiddenDiagnostic struct {
+	Range    protocol.Range
+	Severity protocol.DiagnosticSeverity
+	Source   string // e.g. "compiler" or the name of an analyzer
+	Message  string
+	Reason   string // why gopls does not publish the diagnostic
+}
+
+// PreviewEditArgs holds the arguments of the PreviewEdit command.
+type PreviewEditArgs struct {
+	Edit protocol.WorkspaceEdit
+}
+
+// EditPreview is the result of the PreviewEdit command.
+type EditPreview struct {
+	Files   []FileEditPreview
+	Added   int    // total number of added lines
+	Deleted int    // total number of deleted lines
+	Diff    string // unified diff of all the changes
+}
+
+// FileEditPreview summarizes the changes of a workspace edit to one file.
+type FileEditPreview struct {
+	URI     protocol.DocumentURI
+	NewURI  protocol.DocumentURI `json:",omitempty"` // for a rename
+	Kind    string               // "edit", "create", "rename", or "delete"
+	Added   int                  // number of added lines
+	Deleted int                  // number of deleted lines
+}
+
+// AffectedTestsResult is the result of the AffectedTests command.
+type AffectedTestsResult struct {
+	// TestFile is a test file of the package containing the
+	// affected tests, suitable as the URI of a RunTests command.
+	TestFile protocol.DocumentURI `json:",omitempty"`
+
+	Tests      []string // sorted names of the affected tests
+	Benchmarks []string // sorted names of the affected benchmarks
+}
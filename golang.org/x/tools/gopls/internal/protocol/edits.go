@@ -5,9 +5,13 @@
 package protocol
 
 import (
+	"cmp"
 	"fmt"
+	"go/token"
 	"slices"
 
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/gopls/internal/util/safetoken"
 	"golang.org/x/tools/internal/diff"
 )
 
@@ -66,6 +70,91 @@ func ApplyEdits(m *Mapper, edits []TextEdit) ([]byte, []diff.Edit, error) {
 	return out, diffEdits, err
 }
 
+// FixEdits converts the edits of an analysis.SuggestedFix, whose
+// positions belong to fset, to LSP TextEdits, grouped by file and
+// sorted. The mapper function returns the Mapper for the current
+// content of a file.
+//
+// It reports an error if an edit has no file, ends before it starts
+// or beyond the end of its file, overlaps another edit, or applies to
+// a file whose content has changed since it was parsed. The message
+// names the position of the offending edit.
+func FixEdits(fset *token.FileSet, edits []analysis.TextEdit, mapper func(DocumentURI) (*Mapper, error)) (map[DocumentURI][]TextEdit, error) {
+	type offsetEdit struct {
+		start, end int
+		posn       token.Position
+		newText    []byte
+	}
+	type fileEdits struct {
+		tf    *token.File
+		edits []offsetEdit
+	}
+	files := make(map[DocumentURI]*fileEdits)
+	for _, edit := range edits {
+		tf := fset.File(edit.Pos)
+		if tf == nil {
+			return nil, fmt.Errorf("no file for edit position %d", edit.Pos)
+		}
+		posn := safetoken.StartPosition(fset, edit.Pos)
+		end := edit.End
+		if !end.IsValid() {
+			end = edit.Pos
+		}
+		if end < edit.Pos {
+			return nil, fmt.Errorf("%v: edit ends (%d) before it starts (%d)", posn, end, edit.Pos)
+		}
+		start, endOffset, err := safetoken.Offsets(tf, edit.Pos, end)
+		if err != nil {
+			return nil, fmt.Errorf("%v: edit extends beyond end of file (size %d): %v", posn, tf.Size(), err)
+		}
+		uri := URIFromPath(tf.Name())
+		info, ok := files[uri]
+		if !ok {
+			info = &fileEdits{tf: tf}
+			files[uri] = info
+		} else if info.tf != tf {
+			return nil, fmt.Errorf("%v: edits apply to two different parses of %s", posn, tf.Name())
+		}
+		info.edits = append(info.edits, offsetEdit{start, endOffset, posn, edit.NewText})
+	}
+
+	result := make(map[DocumentURI][]TextEdit, len(files))
+	for uri, info := range files {
+		m, err := mapper(uri)
+		if err != nil {
+			return nil, err
+		}
+		if len(m.Content) != info.tf.Size() {
+			return nil, fmt.Errorf("%s has changed since the edits were computed (size %d, was %d)",
+				uri.Path(), len(m.Content), info.tf.Size())
+		}
+
+		// Stably sort by position, so that multiple
+		// insertions at the same point retain their order.
+		slices.SortStableFunc(info.edits, func(x, y offsetEdit) int {
+			if c := cmp.Compare(x.start, y.start); c != 0 {
+				return c
+			}
+			return cmp.Compare(x.end, y.end)
+		})
+		textedits := make([]TextEdit, len(info.edits))
+		for i, edit := range info.edits {
+			if i > 0 {
+				if prev := info.edits[i-1]; prev.end > edit.start {
+					return nil, fmt.Errorf("%v: edit overlaps edit at %v", edit.posn, prev.posn)
+				}
+			}
+			rng, err := m.OffsetRange(edit.start, edit.end)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %v", edit.posn, err)
+			}
+			textedits[i] = TextEdit{Range: rng, NewText: string(edit.newText)}
+		}
+		result[uri] = textedits
+	}
+	return result, nil
+}
+
 // AsTextEdits converts a slice possibly containing AnnotatedTextEdits
 // to a slice of TextEdits.
 func AsTextEdits(edits []Or_TextDocumentEdit_edits_Elem) []TextEdit {
@@ -0,0 +1,70 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocol_test
+
+import (
+	"go/token"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/gopls/internal/protocol"
+)
+
+func TestFixEdits(t *testing.T) {
+	const src = "𐐀 := 1\nx := 2\n"
+	fset := token.NewFileSet()
+	tf := fset.AddFile("/a.go", -1, len(src))
+	tf.SetLinesForContent([]byte(src))
+	pos := func(offset int) token.Pos { return tf.Pos(offset) }
+	uri := protocol.URIFromPath("/a.go")
+
+	mapper := func(content string) func(protocol.DocumentURI) (*protocol.Mapper, error) {
+		return func(uri protocol.DocumentURI) (*protocol.Mapper, error) {
+			return protocol.NewMapper(uri, []byte(content)), nil
+		}
+	}
+
+	// Edits are sorted, and ranges are in UTF-16.
+	// Insertions at the same point retain their order.
+	got, err := protocol.FixEdits(fset, []analysis.TextEdit{
+		{Pos: pos(10), End: pos(11), NewText: []byte("y")},
+		{Pos: pos(5), NewText: []byte("a")},
+		{Pos: pos(5), NewText: []byte("b")},
+	}, mapper(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []protocol.TextEdit{
+		{Range: protocol.Range{Start: protocol.Position{Line: 0, Character: 3}, End: protocol.Position{Line: 0, Character: 3}}, NewText: "a"},
+		{Range: protocol.Range{Start: protocol.Position{Line: 0, Character: 3}, End: protocol.Position{Line: 0, Character: 3}}, NewText: "b"},
+		{Range: protocol.Range{Start: protocol.Position{Line: 1, Character: 0}, End: protocol.Position{Line: 1, Character: 1}}, NewText: "y"},
+	}
+	if len(got) != 1 || len(got[uri]) != len(want) {
+		t.Fatalf("FixEdits = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[uri][i] != want[i] {
+			t.Errorf("edit %d = %v, want %v", i, got[uri][i], want[i])
+		}
+	}
+
+	for _, test := range []struct {
+		name    string
+		edits   []analysis.TextEdit
+		content string
+		wantErr string
+	}{
+		{"no file", []analysis.TextEdit{{Pos: token.NoPos}}, src, "no file"},
+		{"reversed", []analysis.TextEdit{{Pos: pos(5), End: pos(4)}}, src, "/a.go:1:6: edit ends"},
+		{"beyond EOF", []analysis.TextEdit{{Pos: pos(12), End: pos(12) + 100}}, src, "beyond end of file"},
+		{"overlap", []analysis.TextEdit{{Pos: pos(10), End: pos(12)}, {Pos: pos(11), End: pos(13)}}, src, "/a.go:2:2: edit overlaps edit at /a.go:2:1"},
+		{"changed", []analysis.TextEdit{{Pos: pos(12)}}, src + "\n", "has changed"},
+	} {
+		if _, err := protocol.FixEdits(fset, test.edits, mapper(test.content)); err == nil || !strings.Contains(err.Error(), test.wantErr) {
+			t.Errorf("%s: FixEdits error = %v, want %q", test.name, err, test.wantErr)
+		}
+	}
+}
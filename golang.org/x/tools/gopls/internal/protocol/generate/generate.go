@@ -74,7 +74,7 @@ func propStar(name string, t NameType, gotype string) (omitempty, indirect bool)
 		}
 	}
 	oind, oomit := indirect, omitempty
-	if newStar, ok := goplsStar[prop{name, t.Name}]; ok {
+	if newStar, ok := goplsStar[prop{name, t.Name}]; ok && !*spec {
 		switch newStar {
 		case nothing:
 			indirect, omitempty = false, false
@@ -105,7 +105,7 @@ func goName(s string) string {
 	}
 
 	// renames for temporary GOPLS compatibility
-	if news := goplsType[s]; news != "" {
+	if news := goplsType[s]; news != "" && !*spec {
 		usedGoplsType[s] = true
 		s = news
 	}
@@ -36,6 +36,8 @@ var lspGitRef = "release/protocol/3.18.2"
 var (
 	repodir   = flag.String("d", "", "directory containing clone of "+vscodeRepo)
 	outputdir = flag.String("o", ".", "output directory")
+	spec      = flag.Bool("spec", false, "generate only the protocol types, in the shapes of the specification, without gopls compatibility renames")
+	pkgname   = flag.String("pkg", "protocol", "package name of the generated files")
 	// PJW: not for real code
 	lineNumbers = flag.Bool("l", false, "add line numbers to generated output")
 )
@@ -77,6 +79,16 @@ func processinline() {
 	}
 
 	model := parse(filepath.Join(*repodir, "protocol/metaModel.json"))
+	if *spec {
+		// Clients of other LSP implementations need neither the
+		// gopls extensions nor the client and server interfaces.
+		findTypeNames(model)
+		generateOutput(model)
+		fileHdr = fileHeader(model)
+		writeprotocol()
+		writejsons()
+		return
+	}
 
 	// Add a client to server LSP method "command/resolve" for interactive
 	// refactoring. The method's param and result are both "ExecuteCommandParams".
@@ -220,10 +232,16 @@ func ServerDispatchCall(ctx context.Context, server Server, method string, raw j
 func writeprotocol() {
 	out := new(bytes.Buffer)
 	fmt.Fprintln(out, fileHdr)
-	out.WriteString("import \"encoding/json\"\n\n")
+	if !*spec {
+		// for the json.RawMessage fields of renameProp
+		out.WriteString("import \"encoding/json\"\n\n")
+	}
 
 	// The following are unneeded, but make the new code a superset of the old
 	hack := func(newer, existing string) {
+		if *spec {
+			return // no old code to be compatible with
+		}
 		if _, ok := types[existing]; !ok {
 			log.Fatalf("types[%q] not found", existing)
 		}
@@ -236,7 +254,7 @@ func writeprotocol() {
 	hack("_InitializeParams", "XInitializeParams")
 
 	for _, k := range types.keys() {
-		if k == "WatchKind" {
+		if k == "WatchKind" && !*spec {
 			types[k] = "type WatchKind = uint32" // strict gopls compatibility needs the '='
 		}
 		out.WriteString(types[k])
@@ -320,7 +338,7 @@ func fileHeader(model *Model) string {
 
 // Code generated for LSP. DO NOT EDIT.
 
-package protocol
+package %[6]s
 
 // Code generated from %[1]s at ref %[2]s (hash %[3]s).
 // %[4]s/blob/%[2]s/%[1]s
@@ -332,7 +350,8 @@ package protocol
 		lspGitRef,                 // 2
 		githash,                   // 3
 		vscodeRepo,                // 4
-		model.Version.Version)     // 5
+		model.Version.Version,     // 5
+		*pkgname)                  // 6
 }
 
 func parse(fname string) *Model {
@@ -293,7 +293,7 @@ func genStructs(model *Model) {
 		}
 		// TODO(hxjiang): clean this up after microsoft/language-server-protocol#377
 		// is fixed and released.
-		if nm == "TextDocumentPositionParams" {
+		if nm == "TextDocumentPositionParams" && !*spec {
 			out.WriteString("\t// Range is an optional field representing the user's text selection in the document.\n")
 			out.WriteString("\t// If provided, the Position must be contained within this range.\n")
 			out.WriteString("\t//\n")
@@ -307,6 +307,12 @@ func genStructs(model *Model) {
 	// base types
 	// (For URI and DocumentURI, see ../uri.go.)
 	types["LSPAny"] = "type LSPAny = any\n"
+	if *spec {
+		// There is no uri.go in a package of spec-shaped types.
+		types["DocumentURI"] = "type DocumentURI = string\n"
+		types["URI"] = "type URI = string\n"
+		return
+	}
 	// A special case, the only previously existing Or type
 	types["DocumentDiagnosticReport"] = "type DocumentDiagnosticReport = Or_DocumentDiagnosticReport // (alias) \n"
 
@@ -328,7 +334,7 @@ func lspLink(model *Model, fragment string) string {
 func genProps(out *bytes.Buffer, props []NameType, name string) {
 	for _, p := range props {
 		tp := goplsName(p.Type)
-		if newNm, ok := renameProp[prop{name, p.Name}]; ok {
+		if newNm, ok := renameProp[prop{name, p.Name}]; ok && !*spec {
 			usedRenameProp[prop{name, p.Name}] = true
 			if tp == newNm {
 				log.Printf("renameProp useless {%q, %q} for %s", name, p.Name, tp)
@@ -342,7 +348,7 @@ func genProps(out *bytes.Buffer, props []NameType, name string) {
 			json = fmt.Sprintf(" `json:\"%s,omitempty\"`", p.Name)
 		}
 		generateDoc(out, p.Documentation)
-		if docs := appendTypePropDocComments[name]; docs != nil {
+		if docs := appendTypePropDocComments[name]; docs != nil && !*spec {
 			if doc, ok := docs[p.Name]; ok {
 				out.WriteString(doc)
 			}
@@ -354,7 +360,7 @@ func genProps(out *bytes.Buffer, props []NameType, name string) {
 		}
 	}
 
-	if block, ok := appendTypeProp[name]; ok {
+	if block, ok := appendTypeProp[name]; ok && !*spec {
 		out.WriteString(block)
 	}
 }
@@ -496,6 +502,9 @@ func linex(n int) string {
 
 func goplsName(t *Type) string {
 	nm := typeNames[t]
+	if *spec {
+		return specName(nm)
+	}
 	// translate systematic name to gopls name
 	if newNm, ok := goplsType[nm]; ok {
 		usedGoplsType[nm] = true
@@ -504,6 +513,23 @@ func goplsName(t *Type) string {
 	return nm
 }
 
+// specName translates the systematic name of a type to Go, without
+// the gopls compatibility renames: only the names of base types, even
+// those within an array or map type, are changed.
+func specName(nm string) string {
+	if elem, ok := strings.CutPrefix(nm, "[]"); ok {
+		return "[]" + specName(elem)
+	}
+	if rest, ok := strings.CutPrefix(nm, "map["); ok {
+		key, value, _ := strings.Cut(rest, "]") // keys are never composite
+		return "map[" + specName(key) + "]" + specName(value)
+	}
+	if newNm, ok := specType[nm]; ok {
+		return newNm
+	}
+	return nm
+}
+
 func notNil(t *Type) bool { // shutdwon is the special case that needs this
 	return t != nil && (t.Kind != "base" || t.Name != "null")
 }
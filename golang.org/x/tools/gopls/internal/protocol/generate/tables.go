@@ -182,6 +182,19 @@ var goplsType = map[string]string{
 
 var usedGoplsType = make(map[string]bool)
 
+// specType translates the names of base types when generating
+// spec-shaped types (see the -spec flag); unlike goplsType, it has no
+// entries for gopls compatibility.
+var specType = map[string]string{
+	"DocumentUri": "DocumentURI",
+	"LSPAny":      "any",
+	"Or_LSPAny":   "any",
+	"boolean":     "bool",
+	"decimal":     "float64",
+	"integer":     "int32",
+	"uinteger":    "uint32",
+}
+
 // methodNames is a map from the method to the name of the function that handles it
 var methodNames = map[string]string{
 	"$/cancelRequest":                        "CancelRequest",
@@ -6,6 +6,7 @@ package server
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
@@ -22,10 +23,12 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/fatih/gomodifytags/modifytags"
 	"golang.org/x/mod/modfile"
 	"golang.org/x/telemetry/counter"
+	"golang.org/x/tools/benchmark/parse"
 	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/gopls/internal/cache"
 	"golang.org/x/tools/gopls/internal/cache/metadata"
@@ -226,11 +229,15 @@ func (h *commandHandler) Packages(ctx context.Context, args command.PackagesArgs
 					result.Module[mod.Path] = mod // Overwriting is ok
 				}
 
-				result.Packages = append(result.Packages, command.Package{
+				pkg := command.Package{
 					Path:       string(meta.PkgPath),
 					ForTest:    string(meta.ForTest),
 					ModulePath: mod.Path,
-				})
+				}
+				if args.Mode&command.NeedFiles != 0 {
+					pkg.GoFiles = meta.GoFiles
+				}
+				result.Packages = append(result.Packages, pkg)
 			}
 
 			if args.Mode&command.NeedTests == 0 {
@@ -798,9 +805,11 @@ func (c *commandHandler) runTests(ctx context.Context, snapshot *cache.Snapshot,
 	// Run `go test -run=^$ -bench Func` on each test.
 	var failedBenchmarks int
 	for _, funcName := range benchmarks {
-		inv, cleanupInvocation, err := snapshot.GoCommandInvocation(cache.NoNetwork, uri.DirPath(), "test", []string{
-			pkgPath, "-v", "-run=^$", fmt.Sprintf("-bench=^%s$", regexp.QuoteMeta(funcName)),
-		})
+		args := []string{pkgPath, "-v", "-run=^$", fmt.Sprintf("-bench=^%s$", regexp.QuoteMeta(funcName)), "-benchmem"}
+		if benchtime := snapshot.Options().BenchTime; benchtime != "" {
+			args = append(args, "-benchtime="+benchtime)
+		}
+		inv, cleanupInvocation, err := snapshot.GoCommandInvocation(cache.NoNetwork, uri.DirPath(), "test", args)
 		if err != nil {
 			return err
 		}
@@ -833,6 +842,8 @@ func (c *commandHandler) runTests(ctx context.Context, snapshot *cache.Snapshot,
 	}
 	if failedTests > 0 || failedBenchmarks > 0 {
 		message += "\n" + buf.String()
+	} else if results := benchmarkResults(buf.String()); results != "" {
+		message += "\n" + results
 	}
 
 	showMessage(ctx, c.s.client, protocol.Info, message)
@@ -843,6 +854,28 @@ func (c *commandHandler) runTests(ctx context.Context, snapshot *cache.Snapshot,
 	return nil
 }
 
+// benchmarkResults returns a summary of the results of the benchmarks
+// in the output of 'go test -bench -benchmem', one line per benchmark,
+// such as "BenchmarkFoo-8: 1052 ns/op, 128 B/op, 2 allocs/op".
+func benchmarkResults(output string) string {
+	var buf strings.Builder
+	for line := range strings.Lines(output) {
+		b, err := parse.ParseLine(strings.TrimSpace(line))
+		if err != nil || b.Measured&parse.NsPerOp == 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, "%s: %.0f ns/op", b.Name, b.NsPerOp)
+		if b.Measured&parse.AllocedBytesPerOp != 0 {
+			fmt.Fprintf(&buf, ", %d B/op", b.AllocedBytesPerOp)
+		}
+		if b.Measured&parse.AllocsPerOp != 0 {
+			fmt.Fprintf(&buf, ", %d allocs/op", b.AllocsPerOp)
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
 func (c *commandHandler) Generate(ctx context.Context, args command.GenerateArgs) error {
 	title := "Running go generate ."
 	if args.Recursive {
@@ -1155,6 +1188,19 @@ func (c *commandHandler) ExtractToNewFile(ctx context.Context, args protocol.Loc
 	})
 }
 
+func (c *commandHandler) AddPackageDoc(ctx context.Context, loc protocol.Location) error {
+	return c.run(ctx, commandConfig{
+		progress: "Add package documentation",
+		forURI:   loc.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		changes, err := golang.AddPackageDoc(ctx, deps.snapshot, deps.fh)
+		if err != nil {
+			return err
+		}
+		return applyChanges(ctx, c.s.client, changes)
+	})
+}
+
 func (c *commandHandler) StartDebugging(ctx context.Context, args command.DebuggingArgs) (result command.DebuggingResult, _ error) {
 	addr := args.Addr
 	if addr == "" {
@@ -1412,6 +1458,57 @@ func (c *commandHandler) WorkspaceStats(ctx context.Context) (command.WorkspaceS
 	return res, nil
 }
 
+// AnalysisStats implements the AnalysisStats command, reporting the
+// running time and number of diagnostics of each analyzer.
+func (c *commandHandler) AnalysisStats(ctx context.Context) (command.AnalysisStatsResult, error) {
+	// Analyze the workspace packages of each view. Results already in
+	// the file cache are not recomputed, but they record the running
+	// time of the analyzers that produced them.
+	var (
+		durations   = make(map[string]time.Duration) // by analyzer name
+		diagnostics = make(map[string]int)           // by analyzer name
+	)
+	for _, view := range c.s.session.Views() {
+		snapshot, release, err := view.Snapshot()
+		if err != nil {
+			return command.AnalysisStatsResult{}, err
+		}
+		wsMD, err := snapshot.WorkspaceMetadata(ctx)
+		if err != nil {
+			release()
+			return command.AnalysisStatsResult{}, err
+		}
+		pkgs := make(map[metadata.PackageID]*metadata.Package)
+		for _, mp := range wsMD {
+			pkgs[mp.ID] = mp
+		}
+		diags, times, err := snapshot.AnalyzeTimed(ctx, pkgs)
+		release()
+		if err != nil {
+			return command.AnalysisStatsResult{}, err
+		}
+		for _, t := range times {
+			durations[t.Label] += t.Duration
+		}
+		for _, d := range diags {
+			diagnostics[string(d.Source)]++
+		}
+	}
+
+	var res command.AnalysisStatsResult
+	for _, name := range slices.Sorted(maps.Keys(durations)) {
+		res.Analyzers = append(res.Analyzers, command.AnalyzerStats{
+			Name:        name,
+			Duration:    fmt.Sprint(durations[name]),
+			Diagnostics: diagnostics[name],
+		})
+	}
+	slices.SortStableFunc(res.Analyzers, func(x, y command.AnalyzerStats) int {
+		return -cmp.Compare(durations[x.Name], durations[y.Name])
+	})
+	return res, nil
+}
+
 func collectViewStats(ctx context.Context, view *cache.View) (command.ViewStats, error) {
 	s, release, err := view.Snapshot()
 	if err != nil {
@@ -1959,10 +2056,142 @@ func (c *commandHandler) MoveDeclaration(ctx context.Context, args command.MoveD
 	return c.run(ctx, commandConfig{
 		forURI: args.Location.URI,
 	}, func(ctx context.Context, deps commandDeps) error {
-		changes, _, err := golang.MoveDeclaration(ctx, deps.fh, deps.snapshot)
+		dest, err := golang.MoveDeclarationDest(params, args.Location.URI)
 		if err != nil {
 			return err
 		}
-		return applyChanges(ctx, c.s.client, changes)
+		return c.moveDeclaration(ctx, deps, args.Location, dest)
+	})
+}
+
+func (c *commandHandler) MoveSymbol(ctx context.Context, args command.MoveSymbolArgs) error {
+	return c.run(ctx, commandConfig{
+		forURI: args.Location.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		return c.moveDeclaration(ctx, deps, args.Location, args.Dest)
+	})
+}
+
+// moveDeclaration moves the declaration at loc to the file dest, and
+// shows it in its new file.
+func (c *commandHandler) moveDeclaration(ctx context.Context, deps commandDeps, loc protocol.Location, dest protocol.DocumentURI) error {
+	changes, moved, err := golang.MoveDeclaration(ctx, deps.snapshot, deps.fh, loc, dest)
+	if err != nil {
+		return err
+	}
+	if err := applyChanges(ctx, c.s.client, changes); err != nil {
+		return err
+	}
+	// Show the moved declarations in their new file.
+	showDocumentImpl(ctx, c.s.client, protocol.URI(moved.URI), &moved.Range, c.s.options)
+	return nil
+}
+
+func (c *commandHandler) AffectedTests(ctx context.Context, args command.URIArg) (command.AffectedTestsResult, error) {
+	var result command.AffectedTestsResult
+	err := c.run(ctx, commandConfig{
+		forURI: args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		var err error
+		result, err = golang.AffectedTests(ctx, deps.snapshot, deps.fh)
+		return err
+	})
+	return result, err
+}
+
+func (c *commandHandler) ImportGraph(ctx context.Context, args command.ImportGraphArgs) (command.ImportGraphResult, error) {
+	var result command.ImportGraphResult
+	err := c.run(ctx, commandConfig{
+		progress: "Computing import graph",
+		forURI:   args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		var err error
+		result, err = golang.ImportGraph(ctx, deps.snapshot, args.Format)
+		if err != nil || !args.Show {
+			return err
+		}
+		return c.showImportGraph(ctx, result, args.Format)
+	})
+	return result, err
+}
+
+// showImportGraph writes the rendered import graph to a temporary
+// file and asks the client to open it, or, if the client cannot
+// show documents, tells the user where to find it. The file is
+// removed at shutdown, as the client may open it at any time before.
+func (c *commandHandler) showImportGraph(ctx context.Context, result command.ImportGraphResult, format string) (err error) {
+	ext := ".dot"
+	if format == "json" {
+		ext = ".json"
+	}
+	f, err := os.CreateTemp("", "gopls-importgraph-*"+ext)
+	if err != nil {
+		return fmt.Errorf("creating import graph file: %v", err)
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(f.Name()) // ignore error
+		}
+	}()
+	_, err = f.WriteString(result.Output)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("writing import graph file: %v", err)
+	}
+	c.s.tempFilesMu.Lock()
+	c.s.tempFiles = append(c.s.tempFiles, f.Name())
+	c.s.tempFilesMu.Unlock()
+	if c.s.Options().ShowDocumentSupported {
+		openClientEditor(ctx, c.s.client, protocol.Location{URI: protocol.URIFromPath(f.Name())}, c.s.Options())
+		return nil
+	}
+	return c.s.client.ShowMessage(ctx, &protocol.ShowMessageParams{
+		Type:    protocol.Info,
+		Message: fmt.Sprintf("Import graph written to %s", f.Name()),
+	})
+}
+
+func (c *commandHandler) StructLayout(ctx context.Context, args command.StructLayoutArgs) (command.StructLayoutResult, error) {
+	var result command.StructLayoutResult
+	err := c.run(ctx, commandConfig{
+		forURI: args.Location.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		var err error
+		result, err = golang.StructLayout(ctx, deps.snapshot, deps.fh, args.Location)
+		if err != nil {
+			return err
+		}
+		return c.s.client.ShowMessage(ctx, &protocol.ShowMessageParams{
+			Type:    protocol.Info,
+			Message: golang.FormatStructLayout(result),
+		})
+	})
+	return result, err
+}
+
+func (c *commandHandler) HiddenDiagnostics(ctx context.Context, args command.URIArg) (command.HiddenDiagnosticsResult, error) {
+	var result command.HiddenDiagnosticsResult
+	err := c.run(ctx, commandConfig{
+		progress: "Computing hidden diagnostics",
+		forURI:   args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		var err error
+		result.Diagnostics, err = hiddenDiagnostics(ctx, deps.snapshot, args.URI)
+		return err
+	})
+	return result, err
+}
+
+func (c *commandHandler) PreviewEdit(ctx context.Context, args command.PreviewEditArgs) (command.EditPreview, error) {
+	// Read files through the session, so that unsaved
+	// edits in the editor are taken into account.
+	return golang.PreviewEdit(&args.Edit, func(uri protocol.DocumentURI) ([]byte, error) {
+		fh, err := c.s.session.ReadFile(ctx, uri)
+		if err != nil {
+			return nil, err
+		}
+		return fh.Content()
 	})
 }
@@ -0,0 +1,24 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import "testing"
+
+func TestBenchmarkResults(t *testing.T) {
+	const output = `goos: linux
+goarch: amd64
+pkg: example.com/a
+BenchmarkFoo
+BenchmarkFoo-8   	 1000000	      1052 ns/op	     128 B/op	       2 allocs/op
+BenchmarkBar-8   	     100	  12345678 ns/op
+PASS
+ok  	example.com/a	2.345s
+`
+	const want = "BenchmarkFoo-8: 1052 ns/op, 128 B/op, 2 allocs/op\n" +
+		"BenchmarkBar-8: 12345678 ns/op\n"
+	if got := benchmarkResults(output); got != want {
+		t.Errorf("benchmarkResults returned:\n%s\nwant:\n%s", got, want)
+	}
+}
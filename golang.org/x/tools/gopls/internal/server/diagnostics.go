@@ -24,6 +24,7 @@ import (
 	"golang.org/x/tools/gopls/internal/label"
 	"golang.org/x/tools/gopls/internal/mod"
 	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
 	"golang.org/x/tools/gopls/internal/settings"
 	"golang.org/x/tools/gopls/internal/template"
 	"golang.org/x/tools/gopls/internal/work"
@@ -453,15 +454,7 @@ func (s *server) diagnose(ctx context.Context, snapshot *cache.Snapshot) (diagMa
 		toAnalyzeWidest = make(map[golang.PackagePath]*metadata.Package)
 	)
 	for _, mp := range workspacePkgs {
-		var hasNonIgnored, hasOpenFile bool
-		for _, uri := range mp.CompiledGoFiles {
-			if !hasNonIgnored && !snapshot.IgnoredFile(uri) {
-				hasNonIgnored = true
-			}
-			if !hasOpenFile && snapshot.IsOpen(uri) {
-				hasOpenFile = true
-			}
-		}
+		hasNonIgnored, hasOpenFile := packageFileStatus(snapshot, mp)
 		if hasNonIgnored {
 			toDiagnose[mp.ID] = mp
 			if hasOpenFile {
@@ -510,9 +503,7 @@ func (s *server) diagnose(ctx context.Context, snapshot *cache.Snapshot) (diagMa
 		// across clients.
 		for uri, diags := range analysisDiags {
 			if !snapshot.IsOpen(uri) {
-				newDiags := slices.DeleteFunc(diags, func(diag *cache.Diagnostic) bool {
-					return diag.Severity == protocol.SeverityHint
-				})
+				newDiags := slices.DeleteFunc(diags, isHint)
 				if len(newDiags) == 0 {
 					delete(analysisDiags, uri)
 				} else {
@@ -546,6 +537,113 @@ func (s *server) diagnose(ctx context.Context, snapshot *cache.Snapshot) (diagMa
 	return diagnostics, nil
 }
 
+// packageFileStatus reports whether the package mp has a file that
+// is not ignored by the go command, and whether it has an open file.
+// Only the former packages are diagnosed, and only the latter are
+// analyzed.
+func packageFileStatus(snapshot *cache.Snapshot, mp *metadata.Package) (hasNonIgnored, hasOpenFile bool) {
+	for _, uri := range mp.CompiledGoFiles {
+		if !hasNonIgnored && !snapshot.IgnoredFile(uri) {
+			hasNonIgnored = true
+		}
+		if !hasOpenFile && snapshot.IsOpen(uri) {
+			hasOpenFile = true
+		}
+	}
+	return hasNonIgnored, hasOpenFile
+}
+
+// isHint reports whether diag has Hint severity. Analysis diagnostics
+// of this severity are not published for closed files.
+func isHint(diag *cache.Diagnostic) bool {
+	return diag.Severity == protocol.SeverityHint
+}
+
+// hiddenDiagnostics computes the diagnostics of the Go file uri that
+// diagnose does not publish, along with the reason of each.
+func hiddenDiagnostics(ctx context.Context, snapshot *cache.Snapshot, uri protocol.DocumentURI) ([]command.HiddenDiagnostic, error) {
+	mps, err := snapshot.MetadataForFile(ctx, uri, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(mps) == 0 {
+		return nil, fmt.Errorf("no package metadata for file %s", uri)
+	}
+	// Use the widest workspace package, as diagnose does.
+	mp := mps[len(mps)-1]
+	isWorkspace := false
+	for _, m := range slices.Backward(mps) {
+		if snapshot.IsWorkspacePackage(m.ID) {
+			mp, isWorkspace = m, true
+			break
+		}
+	}
+	hasNonIgnored, hasOpenFile := packageFileStatus(snapshot, mp)
+
+	pkgDiags, err := snapshot.PackageDiagnostics(ctx, mp.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Unlike golang.Analyze, Snapshot.Analyze reports the diagnostics
+	// in files that the analysis settings skip.
+	allAnalysisDiags, err := snapshot.Analyze(ctx, map[metadata.PackageID]*metadata.Package{mp.ID: mp}, nil)
+	if err != nil {
+		return nil, err
+	}
+	analysisDiags := moremaps.Group(allAnalysisDiags, func(d *cache.Diagnostic) protocol.DocumentURI { return d.URI })
+	skipped, err := golang.AnalysisSkipped(ctx, snapshot, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var hidden []command.HiddenDiagnostic
+	add := func(diag *cache.Diagnostic, reason string) {
+		hidden = append(hidden, command.HiddenDiagnostic{
+			Range:    diag.Range,
+			Severity: diag.Severity,
+			Source:   string(diag.Source),
+			Message:  diag.Message,
+			Reason:   reason,
+		})
+	}
+	diags := golang.CombineDiagnostics(pkgDiags[uri], analysisDiags[uri])
+	switch {
+	case !isWorkspace:
+		for _, diag := range diags {
+			add(diag, "the file does not belong to a workspace package (see the directoryFilters setting)")
+		}
+	case !hasNonIgnored:
+		for _, diag := range diags {
+			add(diag, "the go command ignores the package directory (its name begins with '.' or '_', or it is testdata)")
+		}
+	default:
+		// Analysis diagnostics that duplicate a type error are
+		// published as part of it.
+		type key struct {
+			rng     protocol.Range
+			message string
+		}
+		published := make(map[key]bool)
+		for _, diag := range pkgDiags[uri] {
+			published[key{diag.Range, diag.Message}] = true
+		}
+		for _, diag := range analysisDiags[uri] {
+			if published[key{diag.Range, diag.Message}] {
+				continue
+			}
+			switch {
+			case skipped != "" && !slices.Contains(snapshot.Options().AnalysisSkipExempt, string(diag.Source)):
+				add(diag, skipped)
+			case !hasOpenFile:
+				add(diag, "analyzers run only on packages with an open file")
+			case isHint(diag) && !snapshot.IsOpen(uri):
+				add(diag, "hint diagnostics are published only for open files")
+			}
+		}
+	}
+	return hidden, nil
+}
+
 func (s *server) compilerOptDetailsDiagnostics(ctx context.Context, snapshot *cache.Snapshot, toDiagnose map[metadata.PackageID]*metadata.Package) (diagMap, error) {
 	// Process requested diagnostics about compiler optimization details.
 	//
@@ -189,6 +189,25 @@ func (s *server) Initialize(ctx context.Context, params *protocol.ParamInitializ
 		// is a setting that should ideally live on the front-end.
 	}
 
+	// As with semantic tokens (see above), advertise inline completion
+	// unless it is disabled and the setting can never change.
+	var inlineCompletionProvider *protocol.Or_ServerCapabilities_inlineCompletionProvider
+	if options.InlineCompletion || options.ConfigurationSupported {
+		inlineCompletionProvider = &protocol.Or_ServerCapabilities_inlineCompletionProvider{Value: protocol.InlineCompletionOptions{}}
+	}
+
+	// Files renamed by the client may be named by go:embed patterns,
+	// whose updates require confirmation.
+	var willRenameOptions *protocol.FileOperationRegistrationOptions
+	if options.RenameChangeAnnotationsSupported {
+		willRenameOptions = &protocol.FileOperationRegistrationOptions{
+			Filters: []protocol.FileOperationFilter{{
+				Scheme:  "file",
+				Pattern: protocol.FileOperationPattern{Glob: "**/*"},
+			}},
+		}
+	}
+
 	versionInfo := debug.VersionInfo()
 
 	goplsVersion, err := json.Marshal(versionInfo)
@@ -218,6 +237,7 @@ func (s *server) Initialize(ctx context.Context, params *protocol.ParamInitializ
 			DocumentHighlightProvider: &protocol.Or_ServerCapabilities_documentHighlightProvider{Value: true},
 			DocumentLinkProvider:      &protocol.DocumentLinkOptions{},
 			InlayHintProvider:         protocol.InlayHintOptions{},
+			InlineCompletionProvider:  inlineCompletionProvider,
 			DiagnosticProvider:        diagnosticProvider,
 			ReferencesProvider:        &protocol.Or_ServerCapabilities_referencesProvider{Value: true},
 			RenameProvider:            renameOpts,
@@ -250,6 +270,7 @@ func (s *server) Initialize(ctx context.Context, params *protocol.ParamInitializ
 							Pattern: protocol.FileOperationPattern{Glob: "**/*.go"},
 						}},
 					},
+					WillRename: willRenameOptions,
 				},
 			},
 			Experimental: map[string]any{
@@ -526,7 +547,16 @@ func (s *server) updateWatchedDirectories(ctx context.Context) error {
 // current session settings (creating, replacing, or closing it as needed)
 // and updates the directories it monitors based on the provided patterns.
 func (s *server) updateServerSideWatcher(ctx context.Context, patterns map[protocol.RelativePattern]unit) error {
-	wantMode := s.Options().FileWatcher
+	opts := s.Options()
+	wantMode := opts.FileWatcher
+	if wantMode == settings.FileWatcherAuto {
+		// Fall back to polling only if the client can't watch files for us.
+		if opts.DynamicWatchedFilesSupported {
+			wantMode = settings.FileWatcherOff
+		} else {
+			wantMode = settings.FileWatcherPoll
+		}
+	}
 	s.fileWatcherMu.Lock()
 	defer s.fileWatcherMu.Unlock()
 
@@ -563,7 +593,7 @@ func (s *server) updateServerSideWatcher(ctx context.Context, patterns map[proto
 			event.Error(watcherCtx, "file watcher error", err)
 		}
 
-		w, err := filewatcher.New(wantMode, nil, onChange, onErr)
+		w, err := filewatcher.New(wantMode, opts.FileWatcherPollInterval, nil, onChange, onErr)
 		if err != nil {
 			return err
 		}
@@ -798,11 +828,22 @@ func (s *server) Shutdown(ctx context.Context) error {
 
 		// drop all the active views
 		s.session.Shutdown(ctx)
+		s.removeTempFiles()
 		s.state = serverShutDown
 	}
 	return nil
 }
 
+// removeTempFiles removes the temporary files shown to the client.
+func (s *server) removeTempFiles() {
+	s.tempFilesMu.Lock()
+	defer s.tempFilesMu.Unlock()
+	for _, name := range s.tempFiles {
+		os.Remove(name) // ignore error
+	}
+	s.tempFiles = nil
+}
+
 func (s *server) Exit(ctx context.Context) error {
 	ctx, done := event.Start(ctx, "server.Exit")
 	defer done()
@@ -0,0 +1,37 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"context"
+
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/golang"
+	"golang.org/x/tools/gopls/internal/label"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/internal/event"
+)
+
+func (s *server) InlineCompletion(ctx context.Context, params *protocol.InlineCompletionParams) (*protocol.Or_Result_textDocument_inlineCompletion, error) {
+	ctx, done := event.Start(ctx, "server.InlineCompletion", label.URI.Of(params.TextDocument.URI))
+	defer done()
+
+	fh, snapshot, release, err := s.session.FileOf(ctx, params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var items []protocol.InlineCompletionItem
+	if snapshot.Options().InlineCompletion && snapshot.FileKind(fh) == file.Go {
+		items, err = golang.InlineCompletion(ctx, snapshot, fh, params.Position)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &protocol.Or_Result_textDocument_inlineCompletion{
+		Value: protocol.InlineCompletionList{Items: protocol.NonNilSlice(items)},
+	}, nil
+}
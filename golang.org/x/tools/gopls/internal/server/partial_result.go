@@ -0,0 +1,33 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"context"
+
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/internal/event"
+)
+
+// partialResults returns a function that reports a batch of results
+// of a request to the client as a $/progress notification for the
+// request's partial result token, or nil if the client provided no
+// token.
+//
+// Once partial results have been reported, the final response to the
+// request must be empty, so callers should stream all their results.
+func partialResults[T any](ctx context.Context, client protocol.Client, token *protocol.ProgressToken) func([]T) {
+	if token == nil {
+		return nil
+	}
+	return func(batch []T) {
+		if err := client.Progress(ctx, &protocol.ProgressParams{
+			Token: *token,
+			Value: batch,
+		}); err != nil {
+			event.Error(ctx, "sending partial results", err)
+		}
+	}
+}
@@ -36,6 +36,12 @@ func (s *server) References(ctx context.Context, params *protocol.ReferenceParam
 	case file.Tmpl:
 		return template.References(ctx, snapshot, fh, params)
 	case file.Go:
+		if partial := partialResults[protocol.Location](ctx, s.client, params.PartialResultToken); partial != nil {
+			// Stream references as they are found,
+			// as a query may take a while in a large workspace.
+			err := golang.StreamReferences(ctx, snapshot, fh, params.Range, params.Context.IncludeDeclaration, partial)
+			return nil, err
+		}
 		return golang.References(ctx, snapshot, fh, params.Range, params.Context.IncludeDeclaration)
 	case file.Mod:
 		return mod.References(ctx, snapshot, fh, params)
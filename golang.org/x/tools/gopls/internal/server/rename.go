@@ -34,7 +34,39 @@ func (s *server) Rename(ctx context.Context, params *protocol.RenameParams) (*pr
 	if err != nil {
 		return nil, err
 	}
-	return protocol.NewWorkspaceEdit(changes...), nil
+	edit := protocol.NewWorkspaceEdit(changes...)
+
+	// Offer edits to unchecked textual references, such as in struct
+	// tags, only to clients that will ask the user to confirm them.
+	if snapshot.Options().RenameChangeAnnotationsSupported {
+		if err := golang.AddRelatedRenameEdits(ctx, snapshot, fh, params.Range, params.NewName, edit); err != nil {
+			// The related edits are optional: don't fail the renaming.
+			event.Error(ctx, "computing related rename edits", err)
+		}
+	}
+	return edit, nil
+}
+
+// WillRenameFiles implements the workspace/willRenameFiles handler.
+// It returns edits to the go:embed patterns that refer to the renamed
+// files, annotated as requiring confirmation.
+func (s *server) WillRenameFiles(ctx context.Context, params *protocol.RenameFilesParams) (*protocol.WorkspaceEdit, error) {
+	ctx, done := event.Start(ctx, "server.WillRenameFiles")
+	defer done()
+
+	if len(params.Files) == 0 {
+		return nil, nil
+	}
+	_, snapshot, release, err := s.session.FileOf(ctx, params.Files[0].OldURI)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if !snapshot.Options().RenameChangeAnnotationsSupported {
+		return nil, nil
+	}
+	return golang.RelatedFileRenameEdits(ctx, snapshot, params.Files)
 }
 
 // PrepareRename implements the textDocument/prepareRename handler. It may
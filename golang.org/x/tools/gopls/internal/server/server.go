@@ -134,6 +134,11 @@ type server struct {
 	ongoingProfileMu sync.Mutex
 	ongoingProfile   *os.File // if non-nil, an ongoing profile is writing to this file
 
+	// Track the temporary files shown to the client, such as import
+	// graphs, which are removed at shutdown.
+	tempFilesMu sync.Mutex
+	tempFiles   []string
+
 	// Track most recently requested options.
 	optionsMu sync.Mutex
 	options   *settings.Options
@@ -37,5 +37,14 @@ func (s *server) Symbol(ctx context.Context, params *protocol.WorkspaceSymbolPar
 		defer release()
 		snapshots = append(snapshots, snapshot)
 	}
-	return golang.WorkspaceSymbols(ctx, snapshots, params.Query, golang.WorkspaceSymbolsOptions{Matcher: matcher, Style: style})
+	opts := golang.WorkspaceSymbolsOptions{
+		Matcher: matcher,
+		Style:   style,
+		Partial: partialResults[protocol.SymbolInformation](ctx, s.client, params.PartialResultToken),
+	}
+	syms, err := golang.WorkspaceSymbols(ctx, snapshots, params.Query, opts)
+	if opts.Partial != nil {
+		return nil, err // results were streamed
+	}
+	return syms, err
 }
@@ -19,11 +19,13 @@ import (
 	"golang.org/x/tools/go/analysis/passes/unusedwrite"
 	"golang.org/x/tools/go/analysis/suite/fix"
 	"golang.org/x/tools/go/analysis/suite/vet"
+	"golang.org/x/tools/gopls/internal/analysis/appendresult"
 	"golang.org/x/tools/gopls/internal/analysis/deprecated"
 	"golang.org/x/tools/gopls/internal/analysis/embeddirective"
 	"golang.org/x/tools/gopls/internal/analysis/errorsastypeshadow"
 	"golang.org/x/tools/gopls/internal/analysis/fillreturns"
 	"golang.org/x/tools/gopls/internal/analysis/infertypeargs"
+	"golang.org/x/tools/gopls/internal/analysis/losterr"
 	"golang.org/x/tools/gopls/internal/analysis/maprange"
 	"golang.org/x/tools/gopls/internal/analysis/nonewvars"
 	"golang.org/x/tools/gopls/internal/analysis/noresultvalues"
@@ -143,7 +145,13 @@ func initAnalyzers() (res []*Analyzer) {
 			// De-duplicate, since the suites overlap.
 			if !seen[a] {
 				seen[a] = true
-				res = append(res, &Analyzer{analyzer: a, severity: suite.severity})
+				analyzer := &Analyzer{analyzer: a, severity: suite.severity}
+				if a == modernize.AnyAnalyzer {
+					// Replacing interface{} by any is always safe,
+					// so offer to do it throughout a file at once.
+					analyzer.actionKinds = []protocol.CodeActionKind{protocol.SourceFixAll, protocol.QuickFix}
+				}
+				res = append(res, analyzer)
 			}
 		}
 	}
@@ -173,6 +181,8 @@ func initAnalyzers() (res []*Analyzer) {
 		{analyzer: errorsastypeshadow.Analyzer}, // under evaluation
 		{analyzer: writestring.Analyzer},        // under evaluation
 		{analyzer: ptrtoerror.Analyzer},         // under evaluation
+		{analyzer: losterr.Analyzer},            // under evaluation
+		{analyzer: appendresult.Analyzer},       // under evaluation
 
 		// disabled due to high false positives
 		{analyzer: shadow.Analyzer, severity: protocol.SeverityHint, nonDefault: true},         // very noisy
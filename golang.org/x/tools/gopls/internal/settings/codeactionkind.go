@@ -82,6 +82,7 @@ const (
 	GoTest                     protocol.CodeActionKind = "source.test"
 	GoToggleCompilerOptDetails protocol.CodeActionKind = "source.toggleCompilerOptDetails"
 	AddTest                    protocol.CodeActionKind = "source.addTest"
+	AddPackageDoc              protocol.CodeActionKind = "source.addPackageDoc"
 	OrganizeImports            protocol.CodeActionKind = "source.organizeImports"
 
 	// gopls
@@ -101,23 +102,27 @@ const (
 	RefactorRewriteAddTags            protocol.CodeActionKind = "refactor.rewrite.addTags"
 	RefactorRewriteImplementInterface protocol.CodeActionKind = "refactor.rewrite.implementInterface"
 	RefactorRewriteRemoveTags         protocol.CodeActionKind = "refactor.rewrite.removeTags"
+	RefactorRewriteReorderFields      protocol.CodeActionKind = "refactor.rewrite.reorderFields"
 
 	// refactor.inline
 	RefactorInlineCall     protocol.CodeActionKind = "refactor.inline.call"
 	RefactorInlineVariable protocol.CodeActionKind = "refactor.inline.variable"
 
 	// refactor.extract
-	RefactorExtractConstant    protocol.CodeActionKind = "refactor.extract.constant"
-	RefactorExtractConstantAll protocol.CodeActionKind = "refactor.extract.constant-all"
-	RefactorExtractFunction    protocol.CodeActionKind = "refactor.extract.function"
-	RefactorExtractMethod      protocol.CodeActionKind = "refactor.extract.method"
-	RefactorExtractVariable    protocol.CodeActionKind = "refactor.extract.variable"
-	RefactorExtractVariableAll protocol.CodeActionKind = "refactor.extract.variable-all"
-	RefactorExtractToNewFile   protocol.CodeActionKind = "refactor.extract.toNewFile"
+	RefactorExtractConstant        protocol.CodeActionKind = "refactor.extract.constant"
+	RefactorExtractConstantAll     protocol.CodeActionKind = "refactor.extract.constant-all"
+	RefactorExtractFunction        protocol.CodeActionKind = "refactor.extract.function"
+	RefactorExtractMethod          protocol.CodeActionKind = "refactor.extract.method"
+	RefactorExtractPackageConstant protocol.CodeActionKind = "refactor.extract.packageConstant"
+	RefactorExtractPackageVariable protocol.CodeActionKind = "refactor.extract.packageVariable"
+	RefactorExtractVariable        protocol.CodeActionKind = "refactor.extract.variable"
+	RefactorExtractVariableAll     protocol.CodeActionKind = "refactor.extract.variable-all"
+	RefactorExtractToNewFile       protocol.CodeActionKind = "refactor.extract.toNewFile"
 
 	// refactor.move
 	RefactorMoveType        protocol.CodeActionKind = "refactor.move.moveType"
 	RefactorMoveDeclaration protocol.CodeActionKind = "refactor.move.moveDeclaration"
+	RefactorMoveSymbol      protocol.CodeActionKind = "refactor.move.symbol"
 
 	// Note: add new kinds to:
 	// - the SupportedCodeActions map in default.go
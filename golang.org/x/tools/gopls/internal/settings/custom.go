@@ -1,15 +1,48 @@
 package settings
 
 import (
-	"golang.org/x/tools/custom/analyzer/nosprintf"
+	"golang.org/x/tools/custom/analyzer/registry"
 	"golang.org/x/tools/gopls/internal/protocol"
 )
 
+// addCustomAnalyzers appends the custom analyzers of the registry to a,
+// along with their severity, whether they are enabled by default, and
+// the kinds of code action that apply their fixes. Analyzers that only custom-lint runs are omitted.
 func addCustomAnalyzers(a []*Analyzer) []*Analyzer {
-	return append(a, []*Analyzer{
-		{
-			analyzer:    nosprintf.Analyzer,
-			actionKinds: []protocol.CodeActionKind{protocol.SourceFixAll, protocol.QuickFix},
-		},
-	}...)
+	for _, e := range registry.Entries() {
+		if e.CommandOnly {
+			continue
+		}
+		a = append(a, &Analyzer{
+			analyzer:    e.Analyzer,
+			actionKinds: customActionKinds(e.Fix),
+			severity:    customSeverity(e.Severity),
+			nonDefault:  e.NonDefault,
+		})
+	}
+	return a
+}
+
+// customActionKinds returns the kinds of code action that offer the
+// fixes of a custom analyzer: only safe fixes are applied by
+// source.fixAll.
+func customActionKinds(fix registry.FixSafety) []protocol.CodeActionKind {
+	if fix == registry.SafeFix {
+		return []protocol.CodeActionKind{protocol.SourceFixAll, protocol.QuickFix}
+	}
+	return []protocol.CodeActionKind{protocol.QuickFix}
+}
+
+// customSeverity returns the LSP severity of the diagnostics of a custom
+// analyzer.
+func customSeverity(s registry.Severity) protocol.DiagnosticSeverity {
+	switch s {
+	case registry.SeverityHint:
+		return protocol.SeverityHint
+	case registry.SeverityInfo:
+		return protocol.SeverityInformation
+	case registry.SeverityError:
+		return protocol.SeverityError
+	}
+	return protocol.SeverityWarning
 }
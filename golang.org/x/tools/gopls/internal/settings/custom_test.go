@@ -0,0 +1,50 @@
+package settings_test
+
+import (
+	"slices"
+	"testing"
+
+	"golang.org/x/tools/custom/analyzer/registry"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/settings"
+)
+
+// TestCustomAnalyzers ensures that gopls runs every custom analyzer of
+// the registry, which custom-lint also runs, with the registered
+// severity, default and fix safety, except those that only custom-lint runs.
+func TestCustomAnalyzers(t *testing.T) {
+	for _, e := range registry.Entries() {
+		i := slices.IndexFunc(settings.AllAnalyzers, func(a *settings.Analyzer) bool {
+			return a.Analyzer().Name == e.Analyzer.Name
+		})
+		if e.CommandOnly {
+			if i >= 0 {
+				t.Errorf("command-only custom analyzer %q is run by gopls", e.Analyzer.Name)
+			}
+			continue
+		}
+		if i < 0 {
+			t.Errorf("custom analyzer %q is missing from gopls", e.Analyzer.Name)
+			continue
+		}
+		a := settings.AllAnalyzers[i]
+		if a.Analyzer() != e.Analyzer {
+			t.Errorf("gopls analyzer %q is not the registered one", e.Analyzer.Name)
+		}
+		want := map[registry.Severity]protocol.DiagnosticSeverity{
+			registry.SeverityHint:    protocol.SeverityHint,
+			registry.SeverityInfo:    protocol.SeverityInformation,
+			registry.SeverityWarning: protocol.SeverityWarning,
+			registry.SeverityError:   protocol.SeverityError,
+		}[e.Severity]
+		if got := a.Severity(); got != want {
+			t.Errorf("severity of %q = %v, want %v", e.Analyzer.Name, got, want)
+		}
+		if got, want := a.Enabled(new(settings.Options)), !e.NonDefault; got != want {
+			t.Errorf("%q is enabled by default: %t, want %t", e.Analyzer.Name, got, want)
+		}
+		if got, want := slices.Contains(a.ActionKinds(), protocol.SourceFixAll), e.Fix == registry.SafeFix; got != want {
+			t.Errorf("%q offers source.fixAll: %t, want %t", e.Analyzer.Name, got, want)
+		}
+	}
+}
@@ -50,6 +50,7 @@ func DefaultOptions(overrides ...func(*Options)) *Options {
 						protocol.SourceFixAll:             true,
 						protocol.SourceOrganizeImports:    true,
 						protocol.QuickFix:                 true,
+						AddPackageDoc:                     true,
 						GoAssembly:                        true,
 						GoDoc:                             true,
 						GoFreeSymbols:                     true,
@@ -62,6 +63,7 @@ func DefaultOptions(overrides ...func(*Options)) *Options {
 						RefactorRewriteInvertIf:           true,
 						RefactorRewriteJoinLines:          true,
 						RefactorRewriteRemoveUnusedParam:  true,
+						RefactorRewriteReorderFields:      true,
 						RefactorRewriteSplitLines:         true,
 						RefactorInlineCall:                true,
 						RefactorInlineVariable:            true,
@@ -69,11 +71,14 @@ func DefaultOptions(overrides ...func(*Options)) *Options {
 						RefactorExtractConstantAll:        true,
 						RefactorExtractFunction:           true,
 						RefactorExtractMethod:             true,
+						RefactorExtractPackageConstant:    true,
+						RefactorExtractPackageVariable:    true,
 						RefactorExtractVariable:           true,
 						RefactorExtractVariableAll:        true,
 						RefactorExtractToNewFile:          true,
 						RefactorMoveType:                  true, // gated by MoveType setting, which is off by default
 						RefactorMoveDeclaration:           true, // gated by MoveDeclaration setting, which is off by default
+						RefactorMoveSymbol:                true, // gated by MoveSymbol setting, which is off by default
 						// Not GoTest: it must be explicit in CodeActionParams.Context.Only
 					},
 					file.Mod: {
@@ -126,6 +131,7 @@ func DefaultOptions(overrides ...func(*Options)) *Options {
 						CompletionBudget:               100 * time.Millisecond,
 						ExperimentalPostfixCompletions: true,
 						CompleteFunctionCalls:          true,
+						FieldOrder:                     RankFieldOrder,
 					},
 					Codelenses: map[CodeLensSource]bool{
 						CodeLensGenerate:          true,
@@ -138,7 +144,8 @@ func DefaultOptions(overrides ...func(*Options)) *Options {
 					NewGoFileHeader:        true,
 					RenameMovesSubpackages: false,
 				},
-				FileWatcher: FileWatcherOff,
+				FileWatcher:             FileWatcherAuto,
+				FileWatcherPollInterval: time.Second,
 			},
 			InternalOptions: InternalOptions{
 				CompleteUnimported:          true,
@@ -107,6 +107,7 @@ type ClientOptions struct {
 	SupportedResourceOperations                []protocol.ResourceOperationKind
 	CodeActionResolveOptions                   []string
 	ShowDocumentSupported                      bool
+	RenameChangeAnnotationsSupported           bool
 	// SupportedWorkDoneProgressFormats specifies the formats supported by the
 	// client for handling workdone progress metadata.
 	SupportedWorkDoneProgressFormats map[WorkDoneProgressStyle]bool
@@ -225,6 +226,11 @@ type UIOptions struct {
 	// ```
 	Codelenses map[CodeLensSource]bool
 
+	// BenchTime is the value of the -benchtime flag of `go test` when
+	// the `test` code lens runs benchmarks, such as "100ms" or
+	// "1000x". If empty, the default of `go test`, one second, is used.
+	BenchTime string `status:"experimental"`
+
 	// SemanticTokens determines whether gopls will return a
 	// SemanticTokensProvider at initialization, or respond
 	// to requests for semantic tokens.
@@ -267,6 +273,15 @@ type UIOptions struct {
 	// and package declaration in a newly created Go file.
 	NewGoFileHeader bool
 
+	// NewGoFileHeaderTemplate names a file, absolute or relative to the
+	// workspace folder, whose content is the header inserted in a newly
+	// created Go file when newGoFileHeader is enabled, in place of the
+	// copyright comment of another file of the package. The file is a
+	// [text/template](https://pkg.go.dev/text/template) whose data has
+	// the fields Year, Package, and Filename, as in
+	// `// Copyright {{.Year}} The Authors.`
+	NewGoFileHeaderTemplate string `status:"experimental"`
+
 	// RenameMovesSubpackages enables Rename operations on packages to
 	// move subdirectories of the target package.
 	RenameMovesSubpackages bool `status:"experimental"`
@@ -275,9 +290,16 @@ type UIOptions struct {
 	// is unfinished so we use this setting to gate its use.
 	MoveType bool `status:"experimental"`
 
-	// MoveDeclaration enables producing Move Declaration codeactions. The implementation
-	// is unfinished so we use this setting to gate its use.
+	// MoveDeclaration enables producing Move Declaration codeactions,
+	// which move a top-level declaration to another file of the same
+	// package. The destination file is chosen through an interactive
+	// form, so this requires a client that supports it.
 	MoveDeclaration bool `status:"experimental"`
+
+	// MoveSymbol enables producing Move Symbol codeactions, which move a
+	// top-level declaration to another file of the same package. One
+	// action is offered per candidate file, so it is off by default.
+	MoveSymbol bool `status:"experimental"`
 }
 
 // A CodeLensSource identifies an (algorithmic) source of code lenses.
@@ -306,6 +328,20 @@ const (
 	// more details.
 	CodeLensGenerate CodeLensSource = "generate"
 
+	// Show the workspace import graph
+	//
+	// This codelens source annotates the `module` directive in a
+	// go.mod file with a command to show the import graph of
+	// the workspace packages, in Graphviz DOT form, by opening a
+	// temporary file containing it in the editor. Packages that
+	// participate in import cycles are highlighted, and each node
+	// is sized by the number of lines in its package, which is
+	// useful when planning the refactoring of a large module.
+	//
+	// The same graph is available in JSON form from the
+	// `gopls importgraph` command.
+	CodeLensImportGraph CodeLensSource = "import_graph"
+
 	// Re-generate cgo declarations
 	//
 	// This codelens source annotates an `import "C"` declaration
@@ -341,10 +377,31 @@ const (
 	// reports any potential problems it finds.
 	CodeLensRunGovulncheck CodeLensSource = "run_govulncheck"
 
+	// Show struct memory layout
+	//
+	// This codelens source annotates each struct type declaration
+	// with its size, alignment, and the number of bytes of padding
+	// between its fields. When reordering the fields would make
+	// the struct smaller, the optimal size is shown too.
+	//
+	// Running the lens's command displays the offset, size,
+	// alignment, and padding of each field. Use the "Reorder
+	// fields for minimal padding" code action to rearrange them.
+	//
+	// This source is off by default, as the layout depends on
+	// the target architecture and is rarely of interest.
+	//
+	//gopls:status experimental
+	CodeLensStructLayout CodeLensSource = "struct_layout"
+
 	// Run tests and benchmarks
 	//
 	// This codelens source annotates each `Test` and `Benchmark`
 	// function in a `*_test.go` file with a command to run it.
+	// Benchmarks are run with `-benchmem`, and the message that
+	// reports their completion shows the time and allocations per
+	// operation of each; the `benchTime` setting controls how long
+	// they run.
 	//
 	// This source is off by default because VS Code has
 	// a client-side custom UI for testing, and because progress
@@ -355,6 +412,22 @@ const (
 	//   for an alternative approach.
 	CodeLensTest CodeLensSource = "test"
 
+	// Run tests affected by unsaved changes
+	//
+	// This codelens source annotates the package clause of a Go
+	// file that has unsaved changes with a command to run the
+	// tests and benchmarks of its package that may be affected by
+	// them: those that refer to a changed declaration of the file,
+	// either directly or through a function of the package that
+	// they call. As `go test` reads the files on disk, the file
+	// must be saved before the command runs.
+	//
+	// This source is off by default, as it type-checks the tests
+	// of the package whenever the file changes.
+	//
+	//gopls:status experimental
+	CodeLensAffectedTests CodeLensSource = "affected_tests"
+
 	// Tidy go.mod file
 	//
 	// This codelens source annotates the `module` directive in a
@@ -411,6 +484,21 @@ type CompletionOptions struct {
 	// expected of the expression being completed, completion may suggest call
 	// expressions (i.e. may include parentheses).
 	CompleteFunctionCalls bool
+
+	// InlineCompletion enables responses to textDocument/inlineCompletion
+	// requests, which propose a continuation of the current line, such as
+	// the return statement of an "if err != nil" block, or the next field
+	// of a struct literal. Suggestions are computed by simple heuristics.
+	InlineCompletion bool `status:"experimental"`
+
+	// FieldOrder controls the order of struct field names offered as
+	// completions within a composite literal. When the order is "rank",
+	// fields are ranked like other candidates, by how well they match
+	// the text before the cursor and the expected type. When the order
+	// is "declaration", matching fields are listed first, in the order in
+	// which they are declared, which suits filling in a struct from top
+	// to bottom.
+	FieldOrder FieldOrder `status:"advanced"`
 }
 
 // Note: DocumentationOptions must be comparable with reflect.DeepEqual.
@@ -437,6 +525,12 @@ type DocumentationOptions struct {
 
 	// LinksInHover controls the presence of documentation links in hover markdown.
 	LinksInHover LinksInHoverEnum
+
+	// ImportModuleInHover adds to the hover of an import path a
+	// description of the module that provides the package: its path
+	// and version, the license detected in its files, and the
+	// deprecation notice of its go.mod file, if any.
+	ImportModuleInHover bool `status:"experimental"`
 }
 
 // LinksInHoverEnum has legal values:
@@ -540,6 +634,31 @@ type DiagnosticOptions struct {
 	// Vulncheck enables vulnerability scanning.
 	Vulncheck VulncheckMode `status:"experimental"`
 
+	// InlineMaxLines, if positive, limits the quick fixes of the
+	// `inline` analyzer to calls of functions whose declaration
+	// has at most this many lines. Calls of larger functions are
+	// still reported, but without a fix, since inlining them at
+	// many call sites would bloat the callers. The "Inline call"
+	// refactoring is not affected.
+	InlineMaxLines int64 `status:"experimental"`
+
+	// AnalysisMaxFileSize, if positive, is the size in bytes of the
+	// largest Go file in which analyzers report diagnostics. The
+	// diagnostics of larger files, which are usually generated by
+	// machines, are suppressed. Analyzers still examine these files,
+	// as their diagnostics in the rest of the package depend on them.
+	AnalysisMaxFileSize int64 `status:"experimental"`
+
+	// AnalysisSkipGenerated suppresses the diagnostics of analyzers
+	// in Go files that are marked as generated by a
+	// "// Code generated ... DO NOT EDIT." comment.
+	AnalysisSkipGenerated bool `status:"experimental"`
+
+	// AnalysisSkipExempt lists the names of analyzers whose
+	// diagnostics are reported in every file, regardless of the
+	// analysisMaxFileSize and analysisSkipGenerated settings.
+	AnalysisSkipExempt []string `status:"experimental"`
+
 	// DiagnosticsDelay controls the amount of time that gopls waits
 	// after the most recent file modification before computing deep diagnostics.
 	// Simple diagnostics (parsing and type-checking) are always run immediately
@@ -694,15 +813,29 @@ type UserOptions struct {
 
 	// FileWatcher specifies the server-side file watching strategy used by gopls.
 	//
-	// By default, this is set to "off", meaning gopls relies exclusively on the
-	// language client (e.g., the editor) to send file change notifications.
+	// By default, this is set to "auto", meaning gopls relies on the
+	// language client (e.g., the editor) to send file change notifications,
+	// falling back to periodic directory scanning if the client does not
+	// support dynamic registration of workspace/didChangeWatchedFiles.
+	// Without this fallback, minimal clients would never inform gopls of
+	// changes to go.mod files or to generated files.
 	//
 	// Available options:
-	//   - "off"      : Client-driven watching (default)
+	//   - "auto"     : Client-driven watching if supported, else polling (default)
+	//   - "off"      : Client-driven watching only
 	//   - "fsnotify" : OS-level event notifications
 	//   - "poll"     : Periodic directory scanning
+	//
+	// The polling watcher skips files and directories matched by the
+	// .gitignore file at the root of each watched directory, except
+	// Go files and the directories that contain them.
 	FileWatcher FileWatcherMode `status:"experimental"`
 
+	// FileWatcherPollInterval is the baseline interval between scans of the
+	// polling file watcher. When the file system is idle, the watcher backs
+	// off, scanning progressively less often.
+	FileWatcherPollInterval time.Duration `status:"experimental"`
+
 	// MaxFileCacheBytes sets a soft limit on the file cache size in bytes.
 	// If zero, the default budget is used.
 	//
@@ -782,6 +915,11 @@ type InternalOptions struct {
 	// MCPTools configures enabled tools (by tool name), overriding the defaults.
 	MCPTools map[string]bool
 
+	// MCPAllowWrites permits MCP tools, such as go_rename, to apply
+	// their edits to the files of the workspace. Otherwise, they may
+	// only report the edits they would make.
+	MCPAllowWrites bool
+
 	// VerboseWorkDoneProgress controls whether the LSP server should send
 	// progress reports for all work done outside the scope of an RPC.
 	// Used by the regression tests.
@@ -889,6 +1027,7 @@ type InternalOptions struct {
 type FileWatcherMode string
 
 const (
+	FileWatcherAuto     FileWatcherMode = "auto"
 	FileWatcherOff      FileWatcherMode = "off"
 	FileWatcherFSNotify FileWatcherMode = "fsnotify"
 	FileWatcherPoll     FileWatcherMode = "poll"
@@ -978,6 +1117,16 @@ const (
 	AllSymbolScope SymbolScope = "all"
 )
 
+// A FieldOrder controls the order of struct field completions.
+type FieldOrder string
+
+const (
+	// RankFieldOrder orders fields by relevance, like other candidates.
+	RankFieldOrder FieldOrder = "rank"
+	// DeclarationFieldOrder orders fields by their declaration.
+	DeclarationFieldOrder FieldOrder = "declaration"
+)
+
 type HoverKind string
 
 const (
@@ -1122,6 +1271,11 @@ func (o *Options) ForClientCapabilities(clientInfo *protocol.ClientInfo, caps pr
 		o.CompletionDeprecated = true
 	}
 
+	// Check if the client asks the user to confirm annotated rename edits.
+	if rename := caps.TextDocument.Rename; rename != nil && caps.Workspace.WorkspaceEdit != nil {
+		o.RenameChangeAnnotationsSupported = rename.HonorsChangeAnnotations && caps.Workspace.WorkspaceEdit.ChangeAnnotationSupport != nil
+	}
+
 	// Check if the client supports code actions resolving.
 	if caps.TextDocument.CodeAction.DataSupport && caps.TextDocument.CodeAction.ResolveSupport != nil {
 		o.CodeActionResolveOptions = caps.TextDocument.CodeAction.ResolveSupport.Properties
@@ -1295,6 +1449,9 @@ func (o *Options) setOne(name string, value any) (applied []CounterPath, _ error
 	case "linkTarget":
 		return nil, setString(&o.LinkTarget, value)
 
+	case "importModuleInHover":
+		return setBool(&o.ImportModuleInHover, value)
+
 	case "linksInHover":
 		switch value {
 		case false:
@@ -1329,6 +1486,21 @@ func (o *Options) setOne(name string, value any) (applied []CounterPath, _ error
 			ModeVulncheckImports,
 			ModeVulncheckPrompt)
 
+	case "inlineMaxLines":
+		return setInt64(&o.InlineMaxLines, value)
+
+	case "analysisMaxFileSize":
+		return setInt64(&o.AnalysisMaxFileSize, value)
+
+	case "analysisSkipGenerated":
+		return setBool(&o.AnalysisSkipGenerated, value)
+
+	case "analysisSkipExempt":
+		return nil, setStringSlice(&o.AnalysisSkipExempt, value)
+
+	case "benchTime":
+		return nil, setString(&o.BenchTime, value)
+
 	case "codelenses", "codelens":
 		lensOverrides, err := asBoolMap[CodeLensSource](value)
 		if err != nil {
@@ -1385,6 +1557,14 @@ func (o *Options) setOne(name string, value any) (applied []CounterPath, _ error
 	case "completeFunctionCalls":
 		return setBool(&o.CompleteFunctionCalls, value)
 
+	case "fieldOrder":
+		return setEnum(&o.FieldOrder, value,
+			RankFieldOrder,
+			DeclarationFieldOrder)
+
+	case "inlineCompletion":
+		return setBool(&o.InlineCompletion, value)
+
 	case "semanticTokens":
 		return setBool(&o.SemanticTokens, value)
 
@@ -1412,6 +1592,9 @@ func (o *Options) setOne(name string, value any) (applied []CounterPath, _ error
 	case "newGoFileHeader":
 		return setBool(&o.NewGoFileHeader, value)
 
+	case "newGoFileHeaderTemplate":
+		return nil, setString(&o.NewGoFileHeaderTemplate, value)
+
 	case "expandWorkspaceToModule":
 		// See golang/go#63536: we can consider deprecating
 		// expandWorkspaceToModule, but probably need to change the default
@@ -1473,11 +1656,17 @@ func (o *Options) setOne(name string, value any) (applied []CounterPath, _ error
 	case "mcpTools":
 		return setBoolMap(&o.MCPTools, value)
 
+	case "mcpAllowWrites":
+		return setBool(&o.MCPAllowWrites, value)
+
 	case "renameMovesSubpackages":
 		return setBool(&o.RenameMovesSubpackages, value)
 
 	case "fileWatcher":
-		return setEnum(&o.FileWatcher, value, FileWatcherOff, FileWatcherFSNotify, FileWatcherPoll)
+		return setEnum(&o.FileWatcher, value, FileWatcherAuto, FileWatcherOff, FileWatcherFSNotify, FileWatcherPoll)
+
+	case "fileWatcherPollInterval":
+		return nil, setDuration(&o.FileWatcherPollInterval, value)
 
 	case "moveType":
 		return setBool(&o.MoveType, value)
@@ -1485,6 +1674,9 @@ func (o *Options) setOne(name string, value any) (applied []CounterPath, _ error
 	case "moveDeclaration":
 		return setBool(&o.MoveDeclaration, value)
 
+	case "moveSymbol":
+		return setBool(&o.MoveSymbol, value)
+
 	// deprecated and renamed settings
 	//
 	// These should never be deleted: there is essentially no cost
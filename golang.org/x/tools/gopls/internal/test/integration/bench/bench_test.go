@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"golang.org/x/tools/gopls/internal/cmd"
+	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/gopls/internal/protocol/command"
 	"golang.org/x/tools/gopls/internal/test/integration"
 	"golang.org/x/tools/gopls/internal/test/integration/fake"
@@ -322,6 +323,34 @@ func startProfileIfSupported(b *testing.B, env *integration.Env, name string) fu
 	}
 }
 
+// startAllocsIfSupported checks to see if the remote gopls instance supports
+// the memstats command. If so, it returns a function that records the bytes
+// allocated by gopls since the call, divided by b.N, in the alloc_bytes/op
+// benchmark metric. Unlike b.ReportAllocs, this accounts for a gopls process
+// separate from the benchmark.
+//
+// If the remote gopls instance does not support the memstats command, this
+// function returns nil.
+func startAllocsIfSupported(b *testing.B, env *integration.Env) func() {
+	if !env.Editor.HasCommand(command.MemStats) {
+		return nil
+	}
+	totalAlloc := func() uint64 {
+		var memstats command.MemStatsResult
+		env.ExecuteCommand(&protocol.ExecuteCommandParams{
+			Command: command.MemStats.String(),
+		}, &memstats)
+		return memstats.TotalAlloc
+	}
+	b.StopTimer()
+	start := totalAlloc()
+	b.StartTimer()
+	return func() {
+		b.StopTimer()
+		b.ReportMetric(float64(totalAlloc()-start)/float64(b.N), "alloc_bytes/op")
+	}
+}
+
 // totalCPUForProfile reads the pprof profile with the given file name, parses,
 // and aggregates the total CPU sampled during the profile.
 func totalCPUForProfile(filename string) (time.Duration, error) {
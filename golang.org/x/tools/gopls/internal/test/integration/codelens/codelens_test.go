@@ -5,8 +5,10 @@
 package codelens
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"golang.org/x/tools/gopls/internal/server"
@@ -406,3 +408,104 @@ func Foo() {
 		)
 	})
 }
+
+// TestImportGraphCodelens checks that the "Show import graph" code lens
+// on a go.mod file shows the rendered graph to the user.
+func TestImportGraphCodelens(t *testing.T) {
+	const workspace = `
+-- go.mod --
+module example.com
+
+go 1.21
+-- a/a.go --
+package a
+
+import _ "example.com/b"
+-- b/b.go --
+package b
+`
+	WithOptions(
+		Settings{"codelenses": map[string]bool{string(settings.CodeLensImportGraph): true}},
+	).Run(t, workspace, func(t *testing.T, env *Env) {
+		env.OpenFile("go.mod")
+		env.ExecuteCodeLensCommand("go.mod", command.ImportGraph, nil)
+		var shown []*protocol.ShowDocumentParams
+		env.OnceMet(ShownDocuments(&shown))
+		if len(shown) != 1 {
+			t.Fatalf("got %d showDocument requests, want 1", len(shown))
+		}
+		data, err := os.ReadFile(protocol.DocumentURI(shown[0].URI).Path())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(data), "n0 -> n1"; !strings.Contains(got, want) {
+			t.Errorf("import graph does not contain %q:\n%s", want, got)
+		}
+	})
+}
+
+// TestAffectedTestsCodelens checks that the "run affected tests" code
+// lens runs the tests that refer, directly or through one call, to
+// the declarations changed by the unsaved edits of a file.
+func TestAffectedTestsCodelens(t *testing.T) {
+	const workspace = `
+-- go.mod --
+module example.com
+
+go 1.21
+-- a/a.go --
+package a
+
+func Double(x int) int { return x + x }
+
+func Quad(x int) int { return Double(Double(x)) }
+
+func Neg(x int) int { return -x }
+-- a/a_test.go --
+package a
+
+import "testing"
+
+func TestDouble(t *testing.T) { _ = Double(1) }
+
+func TestNeg(t *testing.T) { _ = Neg(1) }
+
+func BenchmarkQuad(b *testing.B) { _ = Quad(1) }
+-- a/x_test.go --
+package a_test
+
+import (
+	"testing"
+
+	"example.com/a"
+)
+
+func TestQuad(t *testing.T) { _ = a.Quad(1) }
+`
+	WithOptions(
+		Settings{"codelenses": map[string]bool{string(settings.CodeLensAffectedTests): true}},
+	).Run(t, workspace, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		if lenses := env.CodeLens("a/a.go"); len(lenses) != 0 {
+			t.Errorf("got %d code lenses for a saved file, want none", len(lenses))
+		}
+
+		env.RegexpReplace("a/a.go", `x \+ x`, "2 * x")
+		var args []command.RunTestsArgs
+		for _, lens := range env.CodeLens("a/a.go") {
+			if lens.Command.Command == command.RunTests.String() {
+				var arg command.RunTestsArgs
+				if err := json.Unmarshal(lens.Command.Arguments[0], &arg); err != nil {
+					t.Fatal(err)
+				}
+				args = append(args, arg)
+			}
+		}
+		if len(args) != 1 {
+			t.Fatalf("got %d run_tests code lenses, want 1", len(args))
+		}
+		if got, want := fmt.Sprint(args[0].Tests, args[0].Benchmarks), "[TestDouble TestQuad] [BenchmarkQuad]"; got != want {
+			t.Errorf("affected tests and benchmarks = %s, want %s", got, want)
+		}
+	})
+}
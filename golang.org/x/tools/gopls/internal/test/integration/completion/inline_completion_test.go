@@ -0,0 +1,135 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package completion
+
+import (
+	"testing"
+
+	"golang.org/x/tools/gopls/internal/protocol"
+	. "golang.org/x/tools/gopls/internal/test/integration"
+)
+
+func TestInlineCompletion(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.21
+-- a.go --
+package a
+
+import "os"
+
+type Point struct {
+	X, Y int
+	Label string
+}
+
+func read(name string) ([]byte, int, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		
+	}
+	_ = data
+	
+}
+
+func point() Point {
+	return Point{
+		X: 1,
+		
+	}
+}
+
+func partial() (*Point, error) {
+	_, _, err := read("x")
+	if err != nil {
+		ret
+	}
+	return nil, nil
+}
+
+func missingBody() error {
+	if _, err := os.Stat("x"); err != nil {
+		return err
+	}
+	
+	x := 1
+	_ = x
+	return nil
+}
+
+func panics() error {
+	panic("unreachable")
+	
+}
+
+func loops() error {
+	for {
+	}
+	
+}
+
+func switches(x int) error {
+	switch x {
+	case 0:
+		return nil
+	default:
+		panic(x)
+	}
+	
+}
+`
+	tests := []struct {
+		name string
+		re   string // regexp whose match is the cursor position
+		want string // inserted text, or "" for no suggestion
+	}{
+		{"error block", `if err != nil {\n\t\t()`, "return nil, 0, err"},
+		{"final return", `_ = data\n\t()`, "return nil, 0, nil"},
+		{"struct field", `X: 1,\n\t\t()`, "Y: "},
+		{"partial text", `\tret()\n`, "return nil, err"},
+		{"not at end of block", `return err\n\t}\n\t()`, ""},
+		{"after panic", `panic\("unreachable"\)\n\t()`, ""},
+		{"after infinite loop", `for {\n\t}\n\t()`, ""},
+		{"after terminating switch", `panic\(x\)\n\t}\n\t()`, ""},
+	}
+
+	inlineCompletion := func(env *Env, re string) []protocol.InlineCompletionItem {
+		loc := env.RegexpSearch("a.go", re)
+		params := &protocol.InlineCompletionParams{
+			TextDocumentPositionParams: protocol.LocationTextDocumentPositionParams(loc),
+		}
+		result, err := env.Editor.Server.InlineCompletion(env.Ctx, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.Value.(protocol.InlineCompletionList).Items
+	}
+
+	WithOptions(
+		Settings{"inlineCompletion": true},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		for _, test := range tests {
+			items := inlineCompletion(env, test.re)
+			var got string
+			if len(items) > 0 {
+				got = items[0].InsertText.Value.(string)
+			}
+			if got != test.want {
+				t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+			}
+		}
+	})
+
+	// The feature is disabled by default.
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		if items := inlineCompletion(env, tests[0].re); len(items) > 0 {
+			t.Errorf("got %d items with inlineCompletion disabled, want none", len(items))
+		}
+	})
+}
@@ -10,6 +10,7 @@ import (
 
 	"golang.org/x/tools/gopls/internal/cache"
 	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
 	. "golang.org/x/tools/gopls/internal/test/integration"
 	"golang.org/x/tools/internal/testenv"
 )
@@ -111,7 +112,7 @@ import _ "embed"
 var foo, Bar string
 
 func main() {
-	_ = foo
+	_ = foo + Bar
 }
 `
 	Run(t, generated, func(t *testing.T, env *Env) {
@@ -156,6 +157,49 @@ var Y interface{}
 	})
 }
 
+// TestHiddenSkippedDiagnostics checks that the hidden_diagnostics
+// command lists the analysis diagnostics of a generated file, which
+// the analysisSkipGenerated setting suppresses.
+func TestHiddenSkippedDiagnostics(t *testing.T) {
+	const src = `
+-- go.mod --
+module mod.com
+
+go 1.20
+
+-- a.go --
+package p
+
+-- generated.go --
+// Code generated by hand. DO NOT EDIT.
+
+package p
+
+func _(x int) {
+	x = x
+}
+`
+	WithOptions(
+		Settings{"analysisSkipGenerated": true},
+	).Run(t, src, func(t *testing.T, env *Env) {
+		env.OpenFile("generated.go")
+		env.AfterChange(NoDiagnostics(ForFile("generated.go")))
+
+		cmd := command.NewHiddenDiagnosticsCommand("", command.URIArg{URI: env.Editor.DocumentURI("generated.go")})
+		var result command.HiddenDiagnosticsResult
+		env.ExecuteCommand(&protocol.ExecuteCommandParams{
+			Command:   cmd.Command,
+			Arguments: cmd.Arguments,
+		}, &result)
+		if len(result.Diagnostics) != 1 {
+			t.Fatalf("got hidden diagnostics %v, want the self-assignment", result.Diagnostics)
+		}
+		if got, want := result.Diagnostics[0].Reason, "the file is generated (see the analysisSkipGenerated setting)"; got != want {
+			t.Errorf("got reason %q, want %q", got, want)
+		}
+	})
+}
+
 func TestModernizationConsistency_Issue75000(t *testing.T) {
 	testenv.SkipAfterGoCommand1Point(t, 24)
 	testenv.NeedsGoCommand1Point(t, 22) // uses range-over-int
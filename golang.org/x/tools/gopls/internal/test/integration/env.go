@@ -78,6 +78,8 @@ func NewAwaiter(workdir *fake.Workdir) *Awaiter {
 			work:          make(map[protocol.ProgressToken]*workProgress),
 			startedWork:   make(map[string]uint64),
 			completedWork: make(map[string]uint64),
+
+			partialResults: make(map[protocol.ProgressToken][]any),
 		},
 		waiters: make(map[uint64]*condition),
 	}
@@ -116,6 +118,10 @@ type State struct {
 	work          map[protocol.ProgressToken]*workProgress
 	startedWork   map[string]uint64 // title -> count of 'begin'
 	completedWork map[string]uint64 // title -> count of 'end'
+
+	// partialResults maps each partial result token created by
+	// newPartialResultToken to the values reported for it.
+	partialResults map[protocol.ProgressToken][]any
 }
 
 type workProgress struct {
@@ -255,6 +261,11 @@ func (a *Awaiter) onWorkDoneProgressCreate(_ context.Context, m *protocol.WorkDo
 func (a *Awaiter) onProgress(_ context.Context, m *protocol.ProgressParams) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
+	if values, ok := a.state.partialResults[m.Token]; ok {
+		a.state.partialResults[m.Token] = append(values, m.Value)
+		a.checkConditionsLocked()
+		return nil
+	}
 	work, ok := a.state.work[m.Token]
 	if !ok {
 		panic(fmt.Sprintf("got progress report for unknown report %v: %v", m.Token, m))
@@ -285,6 +296,16 @@ func (a *Awaiter) onProgress(_ context.Context, m *protocol.ProgressParams) erro
 	return nil
 }
 
+// newPartialResultToken returns a new token for the partial results
+// of a request, which are recorded as they are reported.
+func (a *Awaiter) newPartialResultToken() protocol.ProgressToken {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	token := fmt.Sprintf("partial-%d", len(a.state.partialResults)+1)
+	a.state.partialResults[token] = nil
+	return token
+}
+
 func (a *Awaiter) onRegisterCapability(_ context.Context, m *protocol.RegistrationParams) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -6,6 +6,7 @@ package integration
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"maps"
 	"regexp"
@@ -207,6 +208,36 @@ func ReadAllDiagnostics(into *map[string]*protocol.PublishDiagnosticsParams) Exp
 	}
 }
 
+// PartialResults is an expectation that is met once at least n values
+// of type T have been reported as partial results for token, in
+// batches of type []T. The values are stored into into.
+func PartialResults[T any](token protocol.ProgressToken, n int, into *[]T) Expectation {
+	check := func(s State) (Verdict, string) {
+		var all []T
+		for _, value := range s.partialResults[token] {
+			// Values were decoded without knowledge of their type.
+			data, err := json.Marshal(value)
+			if err != nil {
+				return Unmeetable, err.Error()
+			}
+			var batch []T
+			if err := json.Unmarshal(data, &batch); err != nil {
+				return Unmeetable, err.Error()
+			}
+			all = append(all, batch...)
+		}
+		if len(all) < n {
+			return Unmet, fmt.Sprintf("got %d partial results", len(all))
+		}
+		*into = all
+		return Met, ""
+	}
+	return Expectation{
+		Check:       check,
+		Description: fmt.Sprintf("at least %d partial results for token %v", n, token),
+	}
+}
+
 // ShownDocument asserts that the client has received a
 // ShowDocumentRequest for the given URI.
 func ShownDocument(uri protocol.URI) Expectation {
@@ -481,6 +481,9 @@ SKIP
 -- foo.txt --
 FOO
 
+-- bar.txt --
+BAR
+
 -- skip.bat --
 SKIP
 `
@@ -490,11 +493,17 @@ func TestEmbedDefinition(t *testing.T) {
 		env.OpenFile("main.go")
 
 		start := env.RegexpSearch("main.go", `\*.txt`)
-		loc := env.FirstDefinition(start)
+		locs, err := env.Editor.Definitions(env.Ctx, start)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-		name := env.Sandbox.Workdir.URIToPath(loc.URI)
-		if want := "foo.txt"; name != want {
-			t.Errorf("Definition: got file %q, want %q", name, want)
+		var names []string
+		for _, loc := range locs {
+			names = append(names, env.Sandbox.Workdir.URIToPath(loc.URI))
+		}
+		if want := []string{"bar.txt", "foo.txt"}; !reflect.DeepEqual(names, want) {
+			t.Errorf("Definition: got files %q, want %q", names, want)
 		}
 	})
 }
@@ -104,6 +104,8 @@ go 1.12
 -- main.go --
 package main
 
+func main() { _ = Foo }
+
 func Foo() error {
 	return
 }
@@ -565,6 +565,10 @@ func TestHoverEmbedDirective(t *testing.T) {
 			}
 		}
 
+		if want := "3 files, 12 bytes"; !strings.Contains(content, want) {
+			t.Errorf("hover: %q does not contain: %q", content, want)
+		}
+
 		// A directory should never be matched, even if it happens to have a matching name.
 		// Content in subdirectories should not match on only one asterisk.
 		skips := []string{"other.sql", "dir.txt", "skip.txt"}
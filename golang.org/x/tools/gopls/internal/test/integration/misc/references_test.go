@@ -570,3 +570,46 @@ func fileLocations(env *integration.Env, locs []protocol.Location) []string {
 	sort.Strings(got)
 	return got
 }
+
+func TestReferencesPartialResults(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.18
+-- a/a.go --
+package a
+
+func F() {}
+
+func _() { F() }
+-- b/b.go --
+package b
+
+import "mod.com/a"
+
+func _() { a.F(); a.F() }
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		loc := env.RegexpSearch("a/a.go", `func (F)`)
+		want := env.References(loc)
+		if len(want) != 4 {
+			t.Fatalf("got %d references, want 4", len(want))
+		}
+
+		// The same references are streamed, in some order.
+		token := env.ReferencesPartial(loc)
+		var got []protocol.Location
+		env.Await(PartialResults(token, len(want), &got))
+		sort.Slice(got, func(i, j int) bool {
+			return protocol.CompareLocation(got[i], got[j]) < 0
+		})
+		sort.Slice(want, func(i, j int) bool {
+			return protocol.CompareLocation(want[i], want[j]) < 0
+		})
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("partial references mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
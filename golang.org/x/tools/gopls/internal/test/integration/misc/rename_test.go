@@ -6,6 +6,7 @@ package misc
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 
@@ -1024,3 +1025,115 @@ func checkTestdata(t *testing.T, env *Env) {
 		}
 	}
 }
+
+// changeAnnotationCapabilities enables the client capabilities needed
+// for related rename edits, which require confirmation.
+const changeAnnotationCapabilities = `{
+	"textDocument": {"rename": {"honorsChangeAnnotations": true}},
+	"workspace": {"workspaceEdit": {"documentChanges": true, "changeAnnotationSupport": {}}}
+}`
+
+func TestRenameFieldUpdatesStructTags(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.21
+-- a.go --
+package a
+
+type User struct {
+	Email string
+	Phone string ` + "`" + `json:"Email" validate:"required_without=Email"` + "`" + `
+}
+
+var _ = User{Email: ""}
+`
+	const want = `package a
+
+type User struct {
+	Address string
+	Phone string ` + "`" + `json:"Email" validate:"required_without=Address"` + "`" + `
+}
+
+var _ = User{Address: ""}
+`
+	WithOptions(
+		CapabilitiesJSON([]byte(changeAnnotationCapabilities)),
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		env.Rename(env.RegexpSearch("a.go", "(Email): "), "Address")
+		if got := env.BufferText("a.go"); got != want {
+			t.Errorf("after rename:\n%s", compare.Text(want, got))
+		}
+	})
+
+	// Without support for change annotations, tags are unchanged.
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		env.Rename(env.RegexpSearch("a.go", "(Email): "), "Address")
+		if got := env.BufferText("a.go"); !strings.Contains(got, "required_without=Email") {
+			t.Errorf("struct tag unexpectedly changed:\n%s", got)
+		}
+	})
+}
+
+func TestWillRenameFilesUpdatesEmbedPatterns(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.21
+-- a.go --
+package a
+
+import "embed"
+
+//go:embed static/index.html "static/app.js" static/*.css
+var content embed.FS
+
+//go:embed static
+var all embed.FS
+-- static/index.html --
+-- static/app.js --
+-- static/style.css --
+`
+	WithOptions(
+		CapabilitiesJSON([]byte(changeAnnotationCapabilities)),
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		rename := func(from, to string) []string {
+			edit, err := env.Editor.Server.WillRenameFiles(env.Ctx, &protocol.RenameFilesParams{
+				Files: []protocol.FileRename{{
+					OldURI: env.Sandbox.Workdir.URI(from),
+					NewURI: env.Sandbox.Workdir.URI(to),
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			if edit != nil {
+				for _, change := range edit.DocumentChanges {
+					for _, te := range protocol.AsTextEdits(change.TextDocumentEdit.Edits) {
+						got = append(got, te.NewText)
+					}
+				}
+				if ann := edit.ChangeAnnotations["gopls.relatedEdits"]; !ann.NeedsConfirmation {
+					t.Errorf("related edits do not require confirmation: %+v", edit.ChangeAnnotations)
+				}
+			}
+			return got
+		}
+
+		if got, want := rename("static/index.html", "static/main.html"), []string{"static/main.html"}; !slices.Equal(got, want) {
+			t.Errorf("renaming file: got edits %q, want %q", got, want)
+		}
+		if got, want := rename("static", "public"), []string{"public/index.html", `"public/app.js"`, "public"}; !slices.Equal(got, want) {
+			t.Errorf("renaming directory: got edits %q, want %q", got, want)
+		}
+		if got := rename("static/style.css", "static/theme.css"); len(got) > 0 {
+			t.Errorf("renaming file matched by wildcard: got edits %q, want none", got)
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/gopls/internal/settings"
 	. "golang.org/x/tools/gopls/internal/test/integration"
 )
@@ -102,6 +103,35 @@ const (
 	})
 }
 
+func TestWorkspaceSymbolPartialResults(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.17
+-- a/a.go --
+package a
+
+const (
+	Foo = iota
+	FooBar
+	Bar
+)
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		token := env.SymbolPartial("Foo")
+		var got []protocol.SymbolInformation
+		env.Await(PartialResults(token, 2, &got))
+		var names []string
+		for _, info := range got {
+			names = append(names, info.Name)
+		}
+		if diff := cmp.Diff([]string{"Foo", "FooBar"}, names); diff != "" {
+			t.Errorf("unexpected partial results (-want +got):\n%s", diff)
+		}
+	})
+}
+
 func checkSymbols(env *Env, query string, want ...string) {
 	env.TB.Helper()
 	var got []string
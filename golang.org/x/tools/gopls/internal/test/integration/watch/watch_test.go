@@ -710,3 +710,32 @@ func TestSomething(t *testing.T) {}
 		)
 	})
 }
+
+// TestPollingFallback checks that gopls falls back to polling the file
+// system when the client does not support dynamic registration of
+// workspace/didChangeWatchedFiles, and so never reports on-disk changes.
+func TestPollingFallback(t *testing.T) {
+	const pkg = `
+-- go.mod --
+module mod.com
+
+go 1.14
+-- a/a.go --
+package a
+
+func _() {
+	var x int
+}
+`
+	WithOptions(
+		CapabilitiesJSON([]byte(`{"workspace": {"didChangeWatchedFiles": {"dynamicRegistration": false}}}`)),
+		Settings{"fileWatcherPollInterval": "10ms"},
+	).Run(t, pkg, func(t *testing.T, env *Env) {
+		env.OnceMet(
+			InitialWorkspaceLoad,
+			Diagnostics(env.AtRegexp("a/a.go", "x")),
+		)
+		env.WriteWorkspaceFile("a/a.go", `package a; func _() {};`)
+		env.Await(NoDiagnostics(ForFile("a/a.go")))
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	. "golang.org/x/tools/gopls/internal/test/integration"
 )
@@ -155,3 +156,41 @@ func TestDidCreateFiles_BadURI(t *testing.T) {
 		env.AfterChange()
 	})
 }
+
+// TestAutoFillPackageDeclTemplate tests that the header of a new .go file
+// is expanded from the newGoFileHeaderTemplate file, if any.
+func TestAutoFillPackageDeclTemplate(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+
+-- header.tmpl --
+// Copyright {{.Year}} The Authors.
+// File {{.Filename}} of package {{.Package}}.
+
+-- license/license.go --
+// Copyright 2025 The Go Authors. All rights reserved.
+
+package license
+
+-- license/newfile.go --
+`
+	WithOptions(
+		Settings{"newGoFileHeaderTemplate": "header.tmpl"},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.DidCreateFiles(env.Editor.DocumentURI("license/newfile.go"))
+		if err := env.Editor.SaveBuffer(context.Background(), "license/newfile.go"); err != nil {
+			t.Fatal(err)
+		}
+		want := fmt.Sprintf(`// Copyright %d The Authors.
+// File newfile.go of package license.
+
+package license
+`, time.Now().Year())
+		if got := env.FileContent("license/newfile.go"); got != want {
+			t.Fatalf("want '%s' but got '%s'", want, got)
+		}
+	})
+}
@@ -491,6 +491,48 @@ func (e *Env) References(loc protocol.Location) []protocol.Location {
 	return locations
 }
 
+// ReferencesPartial requests the references at loc with a partial
+// result token, calling t.Fatal on any error or if the final response
+// is not empty. It returns the token, whose results may be awaited
+// using [PartialResults].
+func (e *Env) ReferencesPartial(loc protocol.Location) protocol.ProgressToken {
+	e.TB.Helper()
+	token := e.Awaiter.newPartialResultToken()
+	params := &protocol.ReferenceParams{
+		TextDocumentPositionParams: protocol.LocationTextDocumentPositionParams(loc),
+		Context: protocol.ReferenceContext{
+			IncludeDeclaration: true,
+		},
+		PartialResultParams: protocol.PartialResultParams{PartialResultToken: &token},
+	}
+	locations, err := e.Editor.Server.References(e.Ctx, params)
+	if err != nil {
+		e.TB.Fatal(err)
+	}
+	if len(locations) > 0 {
+		e.TB.Fatalf("References returned %d locations in its final response, want none", len(locations))
+	}
+	return token
+}
+
+// SymbolPartial is like ReferencesPartial, for workspace symbols.
+func (e *Env) SymbolPartial(query string) protocol.ProgressToken {
+	e.TB.Helper()
+	token := e.Awaiter.newPartialResultToken()
+	params := &protocol.WorkspaceSymbolParams{
+		Query:               query,
+		PartialResultParams: protocol.PartialResultParams{PartialResultToken: &token},
+	}
+	syms, err := e.Editor.Server.Symbol(e.Ctx, params)
+	if err != nil {
+		e.TB.Fatal(err)
+	}
+	if len(syms) > 0 {
+		e.TB.Fatalf("Symbol returned %d symbols in its final response, want none", len(syms))
+	}
+	return token
+}
+
 // Rename wraps Editor.Rename, calling t.Fatal on any error.
 func (e *Env) Rename(loc protocol.Location, newName string) {
 	e.TB.Helper()
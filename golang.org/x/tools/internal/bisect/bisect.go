@@ -12,6 +12,8 @@
 //  1. Define a way to accept a change pattern on its command line or in its environment.
 //     The most common mechanism is a command-line flag.
 //     The pattern can be passed to [New] to create a [Matcher], the compiled form of a pattern.
+//     Libraries that cannot define flags, such as analyzers running deep inside
+//     a build, can use [FromEnv] to read the pattern from an environment variable.
 //
 //  2. Assign each change a unique ID. One possibility is to use a sequence number,
 //     but the most common mechanism is to hash some kind of identifying information
@@ -86,7 +88,8 @@
 // changes with IDs ending in those bits, + is set addition, - is set subtraction,
 // and the expression is evaluated in the usual left-to-right order.
 // The special binary number “y” denotes the set of all changes,
-// standing in for the empty bit string.
+// standing in for the empty bit string; it must appear by itself
+// between operators.
 // In the expression, all the + operators must appear before all the - operators.
 // A leading + adds to an empty set. A leading - subtracts from the set of all
 // possible suffixes.
@@ -143,6 +146,11 @@
 // When the textual description is expensive to compute,
 // checking [Matcher.Verbose] can help the avoid that expense
 // in most runs.
+//
+// [Matcher.AppendReport] formats a report for a change identified by a
+// file and line, optionally including an excerpt of the source code
+// around the change, so that the final report of the culprit shows its
+// context.
 package bisect
 
 // New creates and returns a new Matcher implementing the given pattern.
@@ -221,7 +229,8 @@ func New(pattern string) (*Matcher, error) {
 			bits <<= 4
 			bits |= uint64(c&^0x20 - 'A' + 10)
 		case 'y':
-			if i+1 < len(p) && (p[i+1] == '0' || p[i+1] == '1') {
+			// y must stand alone: it denotes the empty bit string.
+			if i != start || i+1 < len(p) && p[i+1] != '+' && p[i+1] != '-' {
 				return nil, &parseError{"invalid pattern syntax: " + pattern}
 			}
 			bits = 0
@@ -256,6 +265,24 @@ func New(pattern string) (*Matcher, error) {
 	return m, nil
 }
 
+// FromEnv creates a Matcher from the pattern in the environment
+// variable with the given name, in the style of a GODEBUG setting.
+// Because package bisect must not import other packages,
+// the caller supplies the environment lookup, typically [os.Getenv]:
+//
+//	m, err := bisect.FromEnv("TOOL_BISECT", os.Getenv)
+//
+// An unset or empty variable means the program is not running under
+// bisect, and FromEnv returns nil, nil, just as New("") does.
+// A malformed pattern results in an error that names the variable.
+func FromEnv(name string, getenv func(string) string) (*Matcher, error) {
+	m, err := New(getenv(name))
+	if err != nil {
+		return nil, &parseError{name + ": " + err.Error()}
+	}
+	return m, nil
+}
+
 // A Matcher is the parsed, compiled form of a PATTERN string.
 // The nil *Matcher is valid: it has all changes enabled but none reported.
 type Matcher struct {
@@ -310,6 +337,43 @@ func (m *Matcher) ShouldReport(id uint64) bool {
 	return false
 }
 
+// String returns a pattern in canonical form that New compiles
+// to a Matcher equivalent to m, or "" for the nil Matcher.
+// The canonical form has at most one leading “v” and “!”,
+// a sign before every bit string, no hexadecimal, and “y” for
+// every empty bit string: for example, New("v-x5") is formatted
+// as “v+y-0101”.
+func (m *Matcher) String() string {
+	if m == nil {
+		return ""
+	}
+	var buf []byte
+	if m.verbose {
+		buf = append(buf, 'v')
+	}
+	if !m.enable {
+		buf = append(buf, '!')
+	}
+	for _, c := range m.list {
+		if c.result {
+			buf = append(buf, '+')
+		} else {
+			buf = append(buf, '-')
+		}
+		n := 0 // number of bits in mask
+		for n < 64 && c.mask>>n&1 == 1 {
+			n++
+		}
+		if n == 0 {
+			buf = append(buf, 'y')
+		}
+		for i := n - 1; i >= 0; i-- {
+			buf = append(buf, byte('0'+c.bits>>i&1))
+		}
+	}
+	return string(buf)
+}
+
 // Marker returns the match marker text to use on any line reporting details
 // about a match of the given ID.
 // It always returns the hexadecimal format.
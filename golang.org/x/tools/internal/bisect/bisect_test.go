@@ -7,6 +7,7 @@ package bisect
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -33,3 +34,164 @@ func TestNoImports(t *testing.T) {
 		}
 	}
 }
+
+func TestAppendReport(t *testing.T) {
+	const src = "package p\n\nfunc f() {\n\tx := 1\n\tprintln(x)\n}\n"
+	id := Hash("p.go", 4)
+	marker := " " + Marker(id)
+
+	verbose, err := New("vy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(verbose.AppendReport(nil, id, "p.go", 4, "enabled change", []byte(src)))
+	want := "p.go:4: enabled change" + marker + "\n" +
+		"\t  2 | " + marker + "\n" +
+		"\t  3 | func f() {" + marker + "\n" +
+		"\t> 4 | \tx := 1" + marker + "\n" +
+		"\t  5 | \tprintln(x)" + marker + "\n" +
+		"\t  6 | }" + marker + "\n"
+	if got != want {
+		t.Errorf("verbose report with excerpt:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	// The lines of the report are displayed by bisect with markers removed.
+	for line := range strings.Lines(got) {
+		if _, gotID, ok := CutMarker(line); !ok || gotID != id {
+			t.Errorf("CutMarker(%q) = %#x, %v, want %#x, true", line, gotID, ok, id)
+		}
+	}
+
+	got = string(verbose.AppendReport(nil, id, "p.go", 4, "enabled change", nil))
+	if want := "p.go:4: enabled change" + marker + "\n"; got != want {
+		t.Errorf("verbose report without source: got %q, want %q", got, want)
+	}
+
+	quiet, err := New("y")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = string(quiet.AppendReport(nil, id, "p.go", 4, "enabled change", []byte(src)))
+	if want := Marker(id) + "\n"; got != want {
+		t.Errorf("non-verbose report: got %q, want %q", got, want)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	env := map[string]string{
+		"EMPTY_BISECT": "",
+		"GOOD_BISECT":  "v+01",
+		"BAD_BISECT":   "0+1-01+001",
+	}
+	getenv := func(name string) string { return env[name] }
+
+	for _, name := range []string{"UNSET_BISECT", "EMPTY_BISECT"} {
+		if m, err := FromEnv(name, getenv); m != nil || err != nil {
+			t.Errorf("FromEnv(%q) = %v, %v, want nil, nil", name, m, err)
+		}
+	}
+
+	m, err := FromEnv("GOOD_BISECT", getenv)
+	if err != nil || m == nil {
+		t.Fatalf("FromEnv(GOOD_BISECT) = %v, %v, want non-nil Matcher", m, err)
+	}
+	if !m.Verbose() || !m.ShouldEnable(0b101) || m.ShouldEnable(0b110) {
+		t.Errorf("FromEnv(GOOD_BISECT) did not compile pattern %q", env["GOOD_BISECT"])
+	}
+
+	if _, err := FromEnv("BAD_BISECT", getenv); err == nil || !strings.HasPrefix(err.Error(), "BAD_BISECT: ") {
+		t.Errorf("FromEnv(BAD_BISECT) error = %v, want error naming the variable", err)
+	}
+}
+
+// patternTests lists corner cases of the pattern syntax, with the
+// canonical form of each valid pattern ("" if invalid).
+var patternTests = []struct {
+	pattern, canonical string
+}{
+	{"y", "+y"},
+	{"n", "!+y"},
+	{"!y", "!+y"},
+	{"!!y", "+y"},
+	{"!n", "+y"},
+	{"vy", "v+y"},
+	{"vvn", "v!+y"},
+	{"v!y", "v!+y"},
+	{"01+10", "+01+10"},
+	{"+01+10", "+01+10"},
+	{"01+10-1001", "+01+10-1001"},
+	{"-01-1000", "+y-01-1000"},
+	{"y-01-1000", "+y-01-1000"},
+	{"x5", "+0101"},
+	{"xA+xf", "+1010+1111"},
+	{"1-x0", "+1-0000"},
+	{"xy", "+y"},
+	{"y+y", "+y+y"},
+	{"xffffffffffffffff", "+" + strings.Repeat("1", 64)},
+	{strings.Repeat("0", 64), "+" + strings.Repeat("0", 64)},
+
+	{"v", ""},
+	{"!", ""},
+	{"v!", ""},
+	{"+", ""},
+	{"-", ""},
+	{"01+", ""},
+	{"01++10", ""},
+	{"0+1-01+001", ""},
+	{"2", ""},
+	{"a", ""},
+	{"x", ""},
+	{"xx1", ""},
+	{"1x1", ""},
+	{"y0", ""},
+	{"0y", ""},
+	{"yy", ""},
+	{"xy2", ""},
+	{"nv", ""},
+	{"x1ffffffffffffffff", ""},
+	{strings.Repeat("0", 65), ""},
+}
+
+func TestPatternSyntax(t *testing.T) {
+	for _, test := range patternTests {
+		m, err := New(test.pattern)
+		if test.canonical == "" {
+			if err == nil {
+				t.Errorf("New(%q) = %v, want error", test.pattern, m)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("New(%q): %v", test.pattern, err)
+			continue
+		}
+		if got := m.String(); got != test.canonical {
+			t.Errorf("New(%q).String() = %q, want %q", test.pattern, got, test.canonical)
+		}
+	}
+}
+
+// FuzzNew checks that the canonical form of every valid
+// pattern compiles to an identical Matcher.
+func FuzzNew(f *testing.F) {
+	for _, test := range patternTests {
+		f.Add(test.pattern)
+	}
+	f.Fuzz(func(t *testing.T, pattern string) {
+		m, err := New(pattern)
+		if err != nil {
+			return
+		}
+		canonical := m.String()
+		m2, err := New(canonical)
+		if err != nil {
+			t.Fatalf("New(%q).String() = %q, which does not compile: %v", pattern, canonical, err)
+		}
+		if !reflect.DeepEqual(m, m2) {
+			t.Fatalf("New(%q) = %+v, but New(%q) = %+v", pattern, m, canonical, m2)
+		}
+		if got := m2.String(); got != canonical {
+			t.Fatalf("canonical form %q is formatted as %q", canonical, got)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bisect
+
+// excerptContext is the number of lines shown on each side of
+// the reported line in a source excerpt.
+const excerptContext = 2
+
+// AppendReport appends to dst a match report for the change with the
+// given id, which is applied at the given line (1-based) of file and is
+// described by desc. It returns the extended buffer.
+//
+// If m is verbose, the report is a line of the form
+// “file:line: desc”, followed, if src (the content of file) is non-nil,
+// by an excerpt of the lines of src surrounding line, with line itself
+// marked by “>”. Otherwise, the report is just a match marker, since
+// the description will not be shown to the user.
+// Each line of the report ends with the match marker for id, which
+// bisect removes when displaying the report.
+//
+// Package bisect has no imports and so cannot read files: the caller
+// must supply src if it wants an excerpt, which it need only do when
+// [Matcher.Verbose] reports true.
+func (m *Matcher) AppendReport(dst []byte, id uint64, file string, line int, desc string, src []byte) []byte {
+	if m == nil || !m.Verbose() {
+		dst = AppendMarker(dst, id)
+		return append(dst, '\n')
+	}
+
+	dst = append(dst, file...)
+	dst = append(dst, ':')
+	dst = appendInt(dst, line)
+	dst = append(dst, ": "...)
+	dst = append(dst, desc...)
+	dst = append(dst, ' ')
+	dst = AppendMarker(dst, id)
+	dst = append(dst, '\n')
+
+	if src == nil || line < 1 {
+		return dst
+	}
+	first, last := max(line-excerptContext, 1), line+excerptContext
+	width := len(appendInt(nil, last))
+	n := 1 // current line number
+	for start := 0; start < len(src) && n <= last; n++ {
+		end := start
+		for end < len(src) && src[end] != '\n' {
+			end++
+		}
+		if n >= first {
+			text := src[start:end]
+			if len(text) > 0 && text[len(text)-1] == '\r' {
+				text = text[:len(text)-1]
+			}
+			if n == line {
+				dst = append(dst, "\t> "...)
+			} else {
+				dst = append(dst, "\t  "...)
+			}
+			num := appendInt(nil, n)
+			for range width - len(num) {
+				dst = append(dst, ' ')
+			}
+			dst = append(dst, num...)
+			dst = append(dst, " | "...)
+			dst = append(dst, text...)
+			dst = append(dst, ' ')
+			dst = AppendMarker(dst, id)
+			dst = append(dst, '\n')
+		}
+		start = end + 1
+	}
+	return dst
+}
+
+// appendInt appends the decimal form of x to dst.
+func appendInt(dst []byte, x int) []byte {
+	if x < 0 {
+		dst = append(dst, '-')
+		x = -x
+	}
+	var buf [20]byte
+	i := len(buf)
+	for {
+		i--
+		buf[i] = byte('0' + x%10)
+		x /= 10
+		if x == 0 {
+			break
+		}
+	}
+	return append(dst, buf[i:]...)
+}
@@ -31,8 +31,12 @@ type Callee struct {
 
 func (callee *Callee) String() string { return callee.impl.Name }
 
+// Lines returns the number of source lines of the callee's declaration.
+func (callee *Callee) Lines() int { return callee.impl.Lines }
+
 type gobCallee struct {
 	Content []byte // file content, compacted to a single func decl
+	Lines   int    // number of source lines of the func decl
 
 	// results of type analysis (does not reach go/types data structures)
 	PkgPath          string                 // package path of declaring package
@@ -360,6 +364,7 @@ func AnalyzeCallee(logf func(string, ...any), fset *token.FileSet, pkg *types.Pa
 	tparams := analyzeTypeParams(logf, fset, info, decl)
 	return &Callee{gobCallee{
 		Content:          content,
+		Lines:            fset.PositionFor(decl.End(), false).Line - fset.PositionFor(decl.Pos(), false).Line + 1,
 		PkgPath:          pkg.Path(),
 		Name:             name,
 		GoVersion:        goVersion,